@@ -21,8 +21,17 @@ func (v *MemBenchResult) Encode(enc *xdr.Encoder) error {
 	// Switch based on key for union field Data
 	switch v.Status {
 
+	case MemBenchStatusError:
+		// void case - no data
+
+	case MemBenchStatusPending:
+		// void case - no data
+
+	case MemBenchStatusSuccess:
+		// void case - no data
+
 	default:
-		// unknown key - encode nothing
+		// void case - no data
 
 	}
 
@@ -40,8 +49,17 @@ func (v *MemBenchResult) Decode(dec *xdr.Decoder) error {
 	// Switch based on key for union field Data
 	switch v.Status {
 
+	case MemBenchStatusError:
+		// void case - no data
+
+	case MemBenchStatusPending:
+		// void case - no data
+
+	case MemBenchStatusSuccess:
+		// void case - no data
+
 	default:
-		// unknown key - decode nothing
+		// void case - no data
 
 	}
 
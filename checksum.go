@@ -0,0 +1,59 @@
+package xdr
+
+import (
+	"encoding/binary"
+	"hash/adler32"
+	"hash/crc32"
+)
+
+// Checksum and length fields cover the encoded bytes of other fields of the same
+// struct. Generated code passes those byte ranges in wire order, reserves the field
+// while encoding and fills it in with PatchUint32 once the covered fields are written.
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ChecksumCRC32 returns the IEEE CRC-32 of the concatenated parts
+func ChecksumCRC32(parts ...[]byte) uint32 {
+	var sum uint32
+	for _, part := range parts {
+		sum = crc32.Update(sum, crc32.IEEETable, part)
+	}
+	return sum
+}
+
+// ChecksumCRC32C returns the Castagnoli CRC-32 of the concatenated parts
+func ChecksumCRC32C(parts ...[]byte) uint32 {
+	var sum uint32
+	for _, part := range parts {
+		sum = crc32.Update(sum, castagnoliTable, part)
+	}
+	return sum
+}
+
+// ChecksumAdler32 returns the Adler-32 checksum of the concatenated parts
+func ChecksumAdler32(parts ...[]byte) uint32 {
+	h := adler32.New()
+	for _, part := range parts {
+		_, _ = h.Write(part)
+	}
+	return h.Sum32()
+}
+
+// ByteLength returns the combined length of parts
+func ByteLength(parts ...[]byte) uint32 {
+	var n int
+	for _, part := range parts {
+		n += len(part)
+	}
+	// #nosec G115
+	return uint32(n)
+}
+
+// PatchUint32 overwrites the 32-bit unsigned integer already encoded at pos
+func (e *Encoder) PatchUint32(pos int, v uint32) error {
+	if pos < 0 || pos+4 > e.pos {
+		return ErrInvalidData
+	}
+	binary.BigEndian.PutUint32(e.buf[pos:], v)
+	return nil
+}
@@ -0,0 +1,35 @@
+package xdr
+
+import (
+	"hash/adler32"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksums(t *testing.T) {
+	data := []byte("123456789")
+	head, tail := data[:4], data[4:]
+
+	assert.Equal(t, uint32(0xcbf43926), ChecksumCRC32(data), "CRC-32 check value")
+	assert.Equal(t, uint32(0xe3069283), ChecksumCRC32C(data), "CRC-32C check value")
+	assert.Equal(t, adler32.Checksum(data), ChecksumAdler32(data))
+	assert.Equal(t, crc32.ChecksumIEEE(data), ChecksumCRC32(head, tail), "Parts should be checksummed as if concatenated")
+	assert.Equal(t, ChecksumCRC32C(data), ChecksumCRC32C(head, tail))
+	assert.Equal(t, ChecksumAdler32(data), ChecksumAdler32(head, tail))
+	assert.Equal(t, uint32(9), ByteLength(head, tail))
+	assert.Equal(t, uint32(0), ByteLength())
+}
+
+func TestPatchUint32(t *testing.T) {
+	enc := NewEncoder(make([]byte, 16))
+	require.NoError(t, enc.EncodeUint32(0))
+	require.NoError(t, enc.EncodeUint32(7))
+	require.NoError(t, enc.PatchUint32(0, 0xdeadbeef))
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef, 0, 0, 0, 7}, enc.Bytes())
+
+	assert.ErrorIs(t, enc.PatchUint32(8, 1), ErrInvalidData, "Only encoded data can be patched")
+	assert.ErrorIs(t, enc.PatchUint32(-1, 1), ErrInvalidData)
+}
@@ -3,6 +3,7 @@ package xdr
 
 import (
 	"fmt"
+	"reflect"
 )
 
 // Codec interface provides consistent XDR encoding/decoding for types
@@ -52,3 +53,14 @@ func Unmarshal(data []byte, codec Codec) error {
 	}
 	return nil
 }
+
+// NewCodec returns a value ready to decode into for a type parameter T xdr.Codec
+// Pointer types get a newly allocated element; other types get their zero value
+// Generated code for generic structs uses it since T{} and new(T) do not allocate the pointee
+func NewCodec[T Codec]() T {
+	var zero T
+	if t := reflect.TypeOf(zero); t != nil && t.Kind() == reflect.Pointer {
+		return reflect.New(t.Elem()).Interface().(T)
+	}
+	return zero
+}
@@ -216,6 +216,16 @@ func TestNestedCodec(t *testing.T) {
 	assert.Equal(t, original.Count, decoded.Count, "Count mismatch")
 }
 
+func TestNewCodec(t *testing.T) {
+	ptr := NewCodec[*TestType]()
+	require.NotNil(t, ptr, "Pointer type parameter should be allocated")
+	require.NoError(t, Unmarshal([]byte{0, 0, 0, 7, 0, 0, 0, 1, 'a', 0, 0, 0}, ptr))
+	assert.Equal(t, &TestType{ID: 7, Name: "a"}, ptr)
+
+	assert.NotSame(t, ptr, NewCodec[*TestType](), "Each call should allocate")
+	assert.Nil(t, NewCodec[Codec](), "Interface type parameter has no element to allocate")
+}
+
 func BenchmarkCodec(b *testing.B) {
 	testType := &TestType{
 		ID:   12345,
@@ -48,3 +48,27 @@ type MultiDepthAlias = altpkg2.MyString
 
 // Build assertion to verify MultiDepthAlias inherits interface compliance from altpkg2.MyString
 var _ xdr.Codec = MultiDepthAlias("")
+
+// Header is a struct with a handwritten xdr.Codec, embedded by types in other packages
+type Header struct {
+	Version uint32
+	Flags   uint32
+}
+
+// Encode implements xdr.Codec
+func (h *Header) Encode(enc *xdr.Encoder) error {
+	if err := enc.EncodeUint32(h.Version); err != nil {
+		return err
+	}
+	return enc.EncodeUint32(h.Flags)
+}
+
+// Decode implements xdr.Codec
+func (h *Header) Decode(dec *xdr.Decoder) error {
+	var err error
+	if h.Version, err = dec.DecodeUint32(); err != nil {
+		return err
+	}
+	h.Flags, err = dec.DecodeUint32()
+	return err
+}
@@ -20,6 +20,18 @@ func (v *Operation) Encode(enc *xdr.Encoder) error {
 	// Switch based on key for union field Result
 	switch v.OpCode {
 
+	case OpClose:
+		// void case - no data
+
+	case OpOpen:
+		// void case - no data
+
+	case OpRead:
+		// void case - no data
+
+	case OpWrite:
+		// void case - no data
+
 	default:
 		// unknown key - encode nothing
 
@@ -39,6 +51,18 @@ func (v *Operation) Decode(dec *xdr.Decoder) error {
 	// Switch based on key for union field Result
 	switch v.OpCode {
 
+	case OpClose:
+		// void case - no data
+
+	case OpOpen:
+		// void case - no data
+
+	case OpRead:
+		// void case - no data
+
+	case OpWrite:
+		// void case - no data
+
 	default:
 		// unknown key - decode nothing
 
@@ -193,6 +217,12 @@ func (v *VoidOperation) Encode(enc *xdr.Encoder) error {
 	// Switch based on key for union field Data
 	switch v.OpCode {
 
+	case VoidOpNone:
+		// void case - no data
+
+	case VoidOpPing:
+		// void case - no data
+
 	default:
 		// unknown key - encode nothing
 
@@ -212,6 +242,12 @@ func (v *VoidOperation) Decode(dec *xdr.Decoder) error {
 	// Switch based on key for union field Data
 	switch v.OpCode {
 
+	case VoidOpNone:
+		// void case - no data
+
+	case VoidOpPing:
+		// void case - no data
+
 	default:
 		// unknown key - decode nothing
 
@@ -0,0 +1,110 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/adler32"
+	"hash/crc32"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestChecksumFields tests checksum and length fields computed by the generated code
+// over other fields with xdr:"crc32=Field", crc32c, adler32 and len tags.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+type SumDigest uint32
+
+// +xdr:generate
+type SumHeader struct {
+	Seq  uint32
+	Kind uint32
+}
+
+// +xdr:generate
+type SumFrame struct {
+	Length  uint32 `xdr:"len=Header|Body"`
+	CRC     uint32 `xdr:"crc32=Body|Header"`
+	Header  SumHeader
+	Body    []byte
+	CRC32C  uint32    `xdr:"crc32c=Body"`
+	Adler   SumDigest `xdr:"adler32=Header"`
+	Trailer uint32
+}
+
+func TestChecksumFields(t *testing.T) {
+	frame := &SumFrame{Header: SumHeader{Seq: 1, Kind: 2}, Body: []byte("hello"), Trailer: 7}
+
+	encode := func(t *testing.T) []byte {
+		t.Helper()
+		data, err := xdr.Marshal(frame)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		return data
+	}
+
+	t.Run("encode", func(t *testing.T) {
+		data := encode(t)
+		// Length, CRC, then the header (8 bytes) and body (4+5+3 bytes) they cover
+		covered := data[8:28]
+		header, body := data[8:16], data[16:28]
+		if got := binary.BigEndian.Uint32(data[0:]); got != 20 {
+			t.Errorf("Expected length 20, got %d", got)
+		}
+		if got, want := binary.BigEndian.Uint32(data[4:]), crc32.ChecksumIEEE(covered); got != want {
+			t.Errorf("Expected CRC32 %#x, got %#x", want, got)
+		}
+		if got, want := binary.BigEndian.Uint32(data[28:]), crc32.Checksum(body, crc32.MakeTable(crc32.Castagnoli)); got != want {
+			t.Errorf("Expected CRC32C %#x, got %#x", want, got)
+		}
+		if got, want := binary.BigEndian.Uint32(data[32:]), adler32.Checksum(header); got != want {
+			t.Errorf("Expected Adler32 %#x, got %#x", want, got)
+		}
+		if frame.CRC != 0 {
+			t.Error("Expected Marshal() to leave the struct unchanged")
+		}
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		data := encode(t)
+		var decoded SumFrame
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Length != 20 || decoded.CRC != binary.BigEndian.Uint32(data[4:]) || string(decoded.Body) != "hello" || decoded.Trailer != 7 {
+			t.Errorf("Unexpected decoded frame %+v", decoded)
+		}
+	})
+
+	t.Run("corrupt body", func(t *testing.T) {
+		data := encode(t)
+		data[20] ^= 0xff
+		var decoded SumFrame
+		if err := xdr.Unmarshal(data, &decoded); !errors.Is(err, xdr.ErrChecksumMismatch) {
+			t.Errorf("Expected ErrChecksumMismatch, got %v", err)
+		}
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		data := encode(t)
+		data[3] = 24
+		var decoded SumFrame
+		if err := xdr.Unmarshal(data, &decoded); !errors.Is(err, xdr.ErrChecksumMismatch) {
+			t.Errorf("Expected ErrChecksumMismatch, got %v", err)
+		}
+	})
+
+	t.Run("uncovered field", func(t *testing.T) {
+		data := encode(t)
+		data[len(data)-1] = 8
+		var decoded SumFrame
+		if err := xdr.Unmarshal(data, &decoded); err != nil || decoded.Trailer != 8 {
+			t.Errorf("Expected the trailer to be outside the checksums, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: checksum_field_test.go
+// Generated 2 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *SumHeader) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Seq); err != nil {
+		return fmt.Errorf("failed to encode Seq: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Kind); err != nil {
+		return fmt.Errorf("failed to encode Kind: %w", err)
+	}
+
+	return nil
+}
+
+func (v *SumHeader) Decode(dec *xdr.Decoder) error {
+
+	tempSeq, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Seq: %w", err)
+	}
+	v.Seq = tempSeq
+
+	tempKind, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Kind: %w", err)
+	}
+	v.Kind = tempKind
+
+	return nil
+}
+
+var _ xdr.Codec = (*SumHeader)(nil)
+
+func (v *SumFrame) Encode(enc *xdr.Encoder) error {
+
+	posLength := enc.Len()
+	if err := enc.EncodeUint32(0); err != nil {
+		return fmt.Errorf("failed to encode Length: %w", err)
+	}
+
+	posCRC := enc.Len()
+	if err := enc.EncodeUint32(0); err != nil {
+		return fmt.Errorf("failed to encode CRC: %w", err)
+	}
+
+	startHeader := enc.Len()
+
+	if err := v.Header.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode Header: %w", err)
+	}
+
+	endHeader := enc.Len()
+
+	startBody := enc.Len()
+	if err := enc.EncodeBytes(v.Body); err != nil {
+		return fmt.Errorf("failed to encode Body: %w", err)
+	}
+	endBody := enc.Len()
+
+	posCRC32C := enc.Len()
+	if err := enc.EncodeUint32(0); err != nil {
+		return fmt.Errorf("failed to encode CRC32C: %w", err)
+	}
+
+	posAdler := enc.Len()
+	if err := enc.EncodeUint32(0); err != nil {
+		return fmt.Errorf("failed to encode Adler: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Trailer); err != nil {
+		return fmt.Errorf("failed to encode Trailer: %w", err)
+	}
+
+	// Length covers Header and Body, filled in now that they are encoded
+	if err := enc.PatchUint32(posLength, xdr.ByteLength(enc.Bytes()[startHeader:endHeader], enc.Bytes()[startBody:endBody])); err != nil {
+		return fmt.Errorf("failed to encode Length: %w", err)
+	}
+
+	// CRC covers Header and Body, filled in now that they are encoded
+	if err := enc.PatchUint32(posCRC, xdr.ChecksumCRC32(enc.Bytes()[startHeader:endHeader], enc.Bytes()[startBody:endBody])); err != nil {
+		return fmt.Errorf("failed to encode CRC: %w", err)
+	}
+
+	// CRC32C covers Body, filled in now that it is encoded
+	if err := enc.PatchUint32(posCRC32C, xdr.ChecksumCRC32C(enc.Bytes()[startBody:endBody])); err != nil {
+		return fmt.Errorf("failed to encode CRC32C: %w", err)
+	}
+
+	// Adler covers Header, filled in now that it is encoded
+	if err := enc.PatchUint32(posAdler, xdr.ChecksumAdler32(enc.Bytes()[startHeader:endHeader])); err != nil {
+		return fmt.Errorf("failed to encode Adler: %w", err)
+	}
+
+	return nil
+}
+
+func (v *SumFrame) Decode(dec *xdr.Decoder) error {
+
+	tempLength, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Length: %w", err)
+	}
+	v.Length = tempLength
+
+	tempCRC, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode CRC: %w", err)
+	}
+	v.CRC = tempCRC
+
+	startHeader := dec.Position()
+
+	if err := v.Header.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode Header: %w", err)
+	}
+
+	endHeader := dec.Position()
+
+	startBody := dec.Position()
+	tempBody, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Body: %w", err)
+	}
+	v.Body = tempBody
+	endBody := dec.Position()
+
+	tempCRC32C, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode CRC32C: %w", err)
+	}
+	v.CRC32C = tempCRC32C
+
+	tempAdler, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Adler: %w", err)
+	}
+	v.Adler = SumDigest(tempAdler)
+
+	tempTrailer, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Trailer: %w", err)
+	}
+	v.Trailer = tempTrailer
+
+	// Length covers Header and Body
+	if sum := xdr.ByteLength(dec.GetSlice(startHeader, endHeader), dec.GetSlice(startBody, endBody)); sum != tempLength {
+		return fmt.Errorf("%w: Length is %d, computed %d over Header and Body", xdr.ErrChecksumMismatch, tempLength, sum)
+	}
+
+	// CRC covers Header and Body
+	if sum := xdr.ChecksumCRC32(dec.GetSlice(startHeader, endHeader), dec.GetSlice(startBody, endBody)); sum != tempCRC {
+		return fmt.Errorf("%w: CRC is %#x, computed %#x over Header and Body", xdr.ErrChecksumMismatch, tempCRC, sum)
+	}
+
+	// CRC32C covers Body
+	if sum := xdr.ChecksumCRC32C(dec.GetSlice(startBody, endBody)); sum != tempCRC32C {
+		return fmt.Errorf("%w: CRC32C is %#x, computed %#x over Body", xdr.ErrChecksumMismatch, tempCRC32C, sum)
+	}
+
+	// Adler covers Header
+	if sum := xdr.ChecksumAdler32(dec.GetSlice(startHeader, endHeader)); sum != tempAdler {
+		return fmt.Errorf("%w: Adler is %#x, computed %#x over Header", xdr.ErrChecksumMismatch, tempAdler, sum)
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*SumFrame)(nil)
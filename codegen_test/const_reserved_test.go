@@ -0,0 +1,100 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestConstReserved tests magic numbers and reserved areas declared with
+// xdr:"const=V" and xdr:"reserved=N" tags.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+type FixedVersion uint16
+
+// +xdr:generate
+type FixedHeader struct {
+	_       uint32       `xdr:"const=0xDEADBEEF"`
+	Version FixedVersion `xdr:"const=2"`
+	_       [6]byte      `xdr:"reserved=6"`
+	Flags   uint32
+	_       [8]byte `xdr:"reserved=8,strict"`
+	Offset  int64   `xdr:"const=-1"`
+	Length  uint64
+}
+
+func TestConstReserved(t *testing.T) {
+	wire := []byte{
+		0xde, 0xad, 0xbe, 0xef,
+		0, 0, 0, 2,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 7,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0, 0, 0, 0, 0, 0, 0x10, 0,
+	}
+
+	t.Run("encode", func(t *testing.T) {
+		// Constant fields are written from the tag whatever the struct holds
+		data, err := xdr.Marshal(&FixedHeader{Flags: 7, Length: 4096})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if !bytes.Equal(data, wire) {
+			t.Errorf("Expected %x, got %x", wire, data)
+		}
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		var decoded FixedHeader
+		if err := xdr.Unmarshal(wire, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		want := FixedHeader{Version: 2, Flags: 7, Offset: -1, Length: 4096}
+		if decoded != want {
+			t.Errorf("Expected %+v, got %+v", want, decoded)
+		}
+	})
+
+	t.Run("wrong magic", func(t *testing.T) {
+		data := bytes.Clone(wire)
+		data[3] = 0xee
+		var decoded FixedHeader
+		err := xdr.Unmarshal(data, &decoded)
+		if !errors.Is(err, xdr.ErrInvalidData) {
+			t.Fatalf("Expected ErrInvalidData, got %v", err)
+		}
+		want := "FixedHeader constant is 0xdeadbeee, expected 0xDEADBEEF"
+		if !bytes.Contains([]byte(err.Error()), []byte(want)) {
+			t.Errorf("Expected error to mention %q, got %q", want, err)
+		}
+	})
+
+	t.Run("wrong version", func(t *testing.T) {
+		data := bytes.Clone(wire)
+		data[7] = 3
+		var decoded FixedHeader
+		if err := xdr.Unmarshal(data, &decoded); !errors.Is(err, xdr.ErrInvalidData) {
+			t.Errorf("Expected ErrInvalidData, got %v", err)
+		}
+	})
+
+	t.Run("reserved", func(t *testing.T) {
+		// The first reserved area is skipped, the strict one must be zero
+		data := bytes.Clone(wire)
+		data[9] = 1
+		var decoded FixedHeader
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("Expected non-strict reserved bytes to be ignored, got %v", err)
+		}
+		data[21] = 1
+		if err := xdr.Unmarshal(data, &decoded); !errors.Is(err, xdr.ErrInvalidData) {
+			t.Errorf("Expected ErrInvalidData for non-zero strict reserved bytes, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: const_reserved_test.go
+// Generated 1 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *FixedHeader) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(0xDEADBEEF); err != nil {
+		return fmt.Errorf("failed to encode FixedHeader constant: %w", err)
+	}
+
+	if err := enc.EncodeUint16(2); err != nil {
+		return fmt.Errorf("failed to encode Version: %w", err)
+	}
+
+	if err := enc.EncodeReserved(6); err != nil {
+		return fmt.Errorf("failed to encode FixedHeader reserved area: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Flags); err != nil {
+		return fmt.Errorf("failed to encode Flags: %w", err)
+	}
+
+	if err := enc.EncodeReserved(8); err != nil {
+		return fmt.Errorf("failed to encode FixedHeader reserved area: %w", err)
+	}
+
+	if err := enc.EncodeInt64(-1); err != nil {
+		return fmt.Errorf("failed to encode Offset: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Length); err != nil {
+		return fmt.Errorf("failed to encode Length: %w", err)
+	}
+
+	return nil
+}
+
+func (v *FixedHeader) Decode(dec *xdr.Decoder) error {
+
+	blank0, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode FixedHeader constant: %w", err)
+	}
+	if blank0 != 0xDEADBEEF {
+		return fmt.Errorf("%w: FixedHeader constant is %#x, expected 0xDEADBEEF", xdr.ErrInvalidData, blank0)
+	}
+
+	tempVersion, err := dec.DecodeUint16()
+	if err != nil {
+		return fmt.Errorf("failed to decode Version: %w", err)
+	}
+	if tempVersion != 2 {
+		return fmt.Errorf("%w: Version is %#x, expected 2", xdr.ErrInvalidData, tempVersion)
+	}
+	v.Version = FixedVersion(tempVersion)
+
+	if err := dec.SkipReserved(6); err != nil {
+		return fmt.Errorf("failed to decode FixedHeader reserved area: %w", err)
+	}
+
+	tempFlags, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Flags: %w", err)
+	}
+	v.Flags = tempFlags
+
+	if err := dec.DecodeReserved(8); err != nil {
+		return fmt.Errorf("failed to decode FixedHeader reserved area: %w", err)
+	}
+
+	tempOffset, err := dec.DecodeInt64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Offset: %w", err)
+	}
+	if tempOffset != -1 {
+		return fmt.Errorf("%w: Offset is %#x, expected -1", xdr.ErrInvalidData, tempOffset)
+	}
+	v.Offset = tempOffset
+
+	tempLength, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Length: %w", err)
+	}
+	v.Length = tempLength
+
+	return nil
+}
+
+var _ xdr.Codec = (*FixedHeader)(nil)
@@ -0,0 +1,129 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestConverters tests third-party types bound to user encode/decode functions
+// with +xdr:converter, used as fields, array elements and union arms.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// +xdr:converter,type=netip.Addr,encode=encodeConvAddr,decode=decodeConvAddr
+
+// encodeConvAddr writes an address as variable-length opaque (4 or 16 bytes)
+func encodeConvAddr(enc *xdr.Encoder, addr netip.Addr) error {
+	return enc.EncodeBytes(addr.AsSlice())
+}
+
+// decodeConvAddr reads an address written by encodeConvAddr
+func decodeConvAddr(dec *xdr.Decoder) (netip.Addr, error) {
+	data, err := dec.DecodeBytes()
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	addr, ok := netip.AddrFromSlice(data)
+	if !ok {
+		return netip.Addr{}, xdr.ErrInvalidData
+	}
+	return addr, nil
+}
+
+type ConvKind uint32
+
+const (
+	ConvKindNone ConvKind = 0
+	ConvKindAddr ConvKind = 1
+)
+
+// +xdr:generate
+type ConvEndpoint struct {
+	Name     string
+	Addr     netip.Addr
+	Backups  []netip.Addr
+	Resolver [2]netip.Addr
+	Port     uint32
+}
+
+// +xdr:arm,union=ConvResult,discriminant=ConvKindAddr,type=netip.Addr
+
+// +xdr:union,key=Kind
+type ConvResult struct {
+	Kind ConvKind
+	Body []byte
+}
+
+func TestConverters(t *testing.T) {
+	endpoint := &ConvEndpoint{
+		Name:     "nfs",
+		Addr:     netip.MustParseAddr("192.0.2.1"),
+		Backups:  []netip.Addr{netip.MustParseAddr("2001:db8::1"), netip.MustParseAddr("198.51.100.7")},
+		Resolver: [2]netip.Addr{netip.MustParseAddr("192.0.2.53"), netip.MustParseAddr("2001:db8::53")},
+		Port:     2049,
+	}
+
+	t.Run("fields and array elements", func(t *testing.T) {
+		data, err := xdr.Marshal(endpoint)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded ConvEndpoint
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Addr != endpoint.Addr || decoded.Port != endpoint.Port || decoded.Resolver != endpoint.Resolver {
+			t.Errorf("Expected %+v, got %+v", endpoint, decoded)
+		}
+		if len(decoded.Backups) != 2 || decoded.Backups[0] != endpoint.Backups[0] || decoded.Backups[1] != endpoint.Backups[1] {
+			t.Errorf("Expected backups %v, got %v", endpoint.Backups, decoded.Backups)
+		}
+	})
+
+	t.Run("wire format", func(t *testing.T) {
+		data, err := xdr.Marshal(&ConvEndpoint{Addr: netip.MustParseAddr("10.0.0.1")})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		dec := xdr.NewDecoder(data)
+		if _, err := dec.DecodeString(); err != nil {
+			t.Fatalf("DecodeString() failed: %v", err)
+		}
+		addr, err := dec.DecodeBytes()
+		if err != nil || len(addr) != 4 || addr[0] != 10 || addr[3] != 1 {
+			t.Errorf("Expected 4-byte opaque 10.0.0.1, got %v (%v)", addr, err)
+		}
+	})
+
+	t.Run("union arm", func(t *testing.T) {
+		var result ConvResult
+		if err := result.SetConvKindAddr(endpoint.Addr); err != nil {
+			t.Fatalf("SetConvKindAddr() failed: %v", err)
+		}
+		data, err := xdr.Marshal(&result)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded ConvResult
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		addr, err := decoded.ConvKindAddr()
+		if err != nil || addr != endpoint.Addr {
+			t.Errorf("Expected %v, got %v (%v)", endpoint.Addr, addr, err)
+		}
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		data := []byte{0, 0, 0, 0, 0, 0, 0, 3, 1, 2, 3, 0}
+		var decoded ConvEndpoint
+		if err := xdr.Unmarshal(data, &decoded); !errors.Is(err, xdr.ErrInvalidData) {
+			t.Errorf("Expected ErrInvalidData for a 3-byte address, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: converter_test.go
+// Generated 3 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+
+	"net/netip"
+)
+
+func (v *ConvEndpoint) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Name); err != nil {
+		return fmt.Errorf("failed to encode Name: %w", err)
+	}
+
+	if err := encodeConvAddr(enc, v.Addr); err != nil {
+		return fmt.Errorf("failed to encode Addr: %w", err)
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Backups))); err != nil {
+		return fmt.Errorf("failed to encode Backups length: %w", err)
+	}
+	for _, elem := range v.Backups {
+
+		if err := encodeConvAddr(enc, elem); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	for _, elem := range v.Resolver {
+
+		if err := encodeConvAddr(enc, elem); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	if err := enc.EncodeUint32(v.Port); err != nil {
+		return fmt.Errorf("failed to encode Port: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ConvEndpoint) Decode(dec *xdr.Decoder) error {
+
+	tempName, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	v.Name = tempName
+
+	tempAddr, err := decodeConvAddr(dec)
+	if err != nil {
+		return fmt.Errorf("failed to decode Addr: %w", err)
+	}
+	v.Addr = tempAddr
+
+	BackupsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Backups length: %w", err)
+	}
+	v.Backups = make([]netip.Addr, BackupsLen)
+	for i := range v.Backups {
+
+		val, err := decodeConvAddr(dec)
+		if err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+		v.Backups[i] = val
+
+	}
+
+	for i := range v.Resolver {
+
+		val, err := decodeConvAddr(dec)
+		if err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+		v.Resolver[i] = val
+
+	}
+
+	tempPort, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Port: %w", err)
+	}
+	v.Port = tempPort
+
+	return nil
+}
+
+var _ xdr.Codec = (*ConvEndpoint)(nil)
+
+func (v *ConvResult) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(uint32(v.Kind)); err != nil {
+		return fmt.Errorf("failed to encode Kind: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case ConvKindAddr:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case ConvKindNone:
+		// void case - no data
+
+	default:
+		// unknown key - encode nothing
+
+	}
+
+	return nil
+}
+
+func (v *ConvResult) Decode(dec *xdr.Decoder) error {
+
+	tempKind, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Kind: %w", err)
+	}
+	v.Kind = ConvKind(tempKind)
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case ConvKindAddr:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case ConvKindNone:
+		// void case - no data
+
+	default:
+		// unknown key - decode nothing
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ConvResult)(nil)
+
+// convResultConvKindAddrArm wraps the ConvKindAddr arm of ConvResult
+type convResultConvKindAddrArm struct {
+	Value netip.Addr
+}
+
+// ConvKindAddr returns the ConvKindAddr arm of ConvResult
+func (v *ConvResult) ConvKindAddr() (netip.Addr, error) {
+	var arm convResultConvKindAddrArm
+	if v.Kind != ConvKindAddr {
+		return arm.Value, fmt.Errorf("%w: Kind=%v, want ConvKindAddr", xdr.ErrArmNotSelected, v.Kind)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode ConvKindAddr arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetConvKindAddr sets ConvResult to the ConvKindAddr arm with the given value
+func (v *ConvResult) SetConvKindAddr(val netip.Addr) error {
+	data, err := xdr.Marshal(&convResultConvKindAddrArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode ConvKindAddr arm: %w", err)
+	}
+	v.Kind = ConvKindAddr
+	v.Body = data
+	return nil
+}
+
+func (v *convResultConvKindAddrArm) Encode(enc *xdr.Encoder) error {
+
+	if err := encodeConvAddr(enc, v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *convResultConvKindAddrArm) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := decodeConvAddr(dec)
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+var _ xdr.Codec = (*convResultConvKindAddrArm)(nil)
@@ -31,7 +31,7 @@ func (v *OperationResult) Encode(enc *xdr.Encoder) error {
 		}
 
 	default:
-		// unknown key - encode nothing
+		// void case - no data
 
 	}
 
@@ -64,7 +64,7 @@ func (v *OperationResult) Decode(dec *xdr.Decoder) error {
 		}
 
 	default:
-		// unknown key - decode nothing
+		// void case - no data
 
 	}
 
@@ -0,0 +1,28 @@
+//go:build ignore
+
+package feature
+
+import "github.com/tempusfrangit/go-xdr/codegen_test/cross_package_union_test/proto"
+
+//go:generate ../../../bin/xdrgen $GOFILE
+
+// +xdr:payload,union=proto.Message,discriminant=proto.MsgText
+type TextBody struct {
+	Text string
+}
+
+// +xdr:payload,union=proto.Message,discriminant=proto.MsgBlob
+type BlobBody struct {
+	Data []byte
+	Size uint64
+}
+
+// +xdr:payload,union=proto.Reply,field=Result,discriminant=proto.StatusOK
+type ReplyOK struct {
+	Count uint32
+}
+
+// NewTextMessage wraps text in a proto.Message
+func NewTextMessage(text string) (*proto.Message, error) {
+	return (&TextBody{Text: text}).ToUnion()
+}
@@ -0,0 +1,59 @@
+//go:build ignore
+
+package feature
+
+import (
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+	"github.com/tempusfrangit/go-xdr/codegen_test/cross_package_union_test/proto"
+)
+
+// TestCrossPackageUnion tests payloads declared in a different package from their union
+func TestCrossPackageUnion(t *testing.T) {
+	t.Run("ToUnion builds the foreign union", func(t *testing.T) {
+		msg, err := (&TextBody{Text: "hello"}).ToUnion()
+		if err != nil {
+			t.Fatalf("ToUnion() failed: %v", err)
+		}
+		data, err := xdr.Marshal(msg)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+
+		var decoded proto.Message
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		var body TextBody
+		if err := xdr.Unmarshal(decoded.Body, &body); err != nil {
+			t.Fatalf("Unmarshal() body failed: %v", err)
+		}
+		if decoded.Type != proto.MsgText || body.Text != "hello" {
+			t.Errorf("Expected text message hello, got %v %q", decoded.Type, body.Text)
+		}
+	})
+
+	t.Run("union package knows foreign payload cases", func(t *testing.T) {
+		blob, err := (&BlobBody{Data: []byte{1, 2}, Size: 2}).ToUnion()
+		if err != nil {
+			t.Fatalf("ToUnion() failed: %v", err)
+		}
+		if _, err := xdr.Marshal(blob); err != nil {
+			t.Errorf("Expected MsgBlob to be a known case, got %v", err)
+		}
+		if _, err := xdr.Marshal(&proto.Message{Type: 9}); err == nil {
+			t.Error("Expected unknown discriminant to be rejected")
+		}
+	})
+
+	t.Run("EncodeToUnion uses foreign key type", func(t *testing.T) {
+		enc := xdr.NewEncoder(make([]byte, 64))
+		if err := (&ReplyOK{Count: 3}).EncodeToUnion(enc); err != nil {
+			t.Fatalf("EncodeToUnion() failed: %v", err)
+		}
+		if string(enc.Bytes()) != "\x00\x00\x00\x00\x00\x00\x00\x03" {
+			t.Errorf("Expected StatusOK discriminant and count, got %x", enc.Bytes())
+		}
+	})
+}
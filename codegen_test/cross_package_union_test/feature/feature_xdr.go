@@ -0,0 +1,171 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: feature.go
+// Generated 3 XDR types
+
+package feature
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+
+	"github.com/tempusfrangit/go-xdr/codegen_test/cross_package_union_test/proto"
+)
+
+func (v *TextBody) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Text); err != nil {
+		return fmt.Errorf("failed to encode Text: %w", err)
+	}
+
+	return nil
+}
+
+func (v *TextBody) Decode(dec *xdr.Decoder) error {
+
+	tempText, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Text: %w", err)
+	}
+	v.Text = tempText
+
+	return nil
+}
+
+// ToUnion converts TextBody to proto.Message
+func (p *TextBody) ToUnion() (*proto.Message, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode TextBody: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &proto.Message{
+		Type: proto.MsgText,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes TextBody directly to union format
+func (p *TextBody) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeUint32(uint32(proto.MsgText)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*TextBody)(nil)
+
+func (v *BlobBody) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeBytes(v.Data); err != nil {
+		return fmt.Errorf("failed to encode Data: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Size); err != nil {
+		return fmt.Errorf("failed to encode Size: %w", err)
+	}
+
+	return nil
+}
+
+func (v *BlobBody) Decode(dec *xdr.Decoder) error {
+
+	tempData, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Data: %w", err)
+	}
+	v.Data = tempData
+
+	tempSize, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Size: %w", err)
+	}
+	v.Size = tempSize
+
+	return nil
+}
+
+// ToUnion converts BlobBody to proto.Message
+func (p *BlobBody) ToUnion() (*proto.Message, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode BlobBody: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &proto.Message{
+		Type: proto.MsgBlob,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes BlobBody directly to union format
+func (p *BlobBody) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeUint32(uint32(proto.MsgBlob)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*BlobBody)(nil)
+
+func (v *ReplyOK) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Count); err != nil {
+		return fmt.Errorf("failed to encode Count: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ReplyOK) Decode(dec *xdr.Decoder) error {
+
+	tempCount, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Count: %w", err)
+	}
+	v.Count = tempCount
+
+	return nil
+}
+
+// EncodeToUnion encodes ReplyOK directly to union format
+func (p *ReplyOK) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeInt32(int32(proto.StatusOK)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*ReplyOK)(nil)
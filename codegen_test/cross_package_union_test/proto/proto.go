@@ -0,0 +1,36 @@
+//go:build ignore
+
+package proto
+
+//go:generate ../../../bin/xdrgen $GOFILE
+
+// MsgType identifies the body of a Message
+type MsgType uint32
+
+const (
+	MsgPing MsgType = 0
+	MsgText MsgType = 1
+	MsgBlob MsgType = 2
+)
+
+// Message is a union whose payloads are declared in the feature package
+// +xdr:union,key=Type,unknown=reject
+type Message struct {
+	Type MsgType // discriminant
+	Body []byte  // auto-detected as union payload
+}
+
+// Status is a signed result code
+type Status int32
+
+const (
+	StatusOK     Status = 0
+	StatusDenied Status = -13
+)
+
+// +xdr:generate
+type Reply struct {
+	XID    uint32
+	Stat   Status
+	Result []byte `xdr:"union=Stat"`
+}
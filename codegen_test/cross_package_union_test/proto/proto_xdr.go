@@ -0,0 +1,146 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: proto.go
+// Generated 2 XDR types
+
+package proto
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *Message) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(uint32(v.Type)); err != nil {
+		return fmt.Errorf("failed to encode Type: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Type {
+
+	case MsgBlob:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case MsgText:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case MsgPing:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Type=%v", xdr.ErrUnknownDiscriminant, v.Type)
+
+	}
+
+	return nil
+}
+
+func (v *Message) Decode(dec *xdr.Decoder) error {
+
+	tempType, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Type: %w", err)
+	}
+	v.Type = MsgType(tempType)
+
+	// Switch based on key for union field Body
+	switch v.Type {
+
+	case MsgBlob:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case MsgText:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case MsgPing:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Type=%v", xdr.ErrUnknownDiscriminant, v.Type)
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*Message)(nil)
+
+func (v *Reply) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.XID); err != nil {
+		return fmt.Errorf("failed to encode XID: %w", err)
+	}
+
+	if err := enc.EncodeInt32(int32(v.Stat)); err != nil {
+		return fmt.Errorf("failed to encode Stat: %w", err)
+	}
+
+	// Switch based on key for union field Result
+	switch v.Stat {
+
+	case StatusOK:
+		if err := enc.EncodeBytes(v.Result); err != nil {
+			return fmt.Errorf("failed to encode Result: %w", err)
+		}
+
+	case StatusDenied:
+		// void case - no data
+
+	default:
+		// unknown key - encode nothing
+
+	}
+
+	return nil
+}
+
+func (v *Reply) Decode(dec *xdr.Decoder) error {
+
+	tempXID, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode XID: %w", err)
+	}
+	v.XID = tempXID
+
+	tempStat, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Stat: %w", err)
+	}
+	v.Stat = Status(tempStat)
+
+	// Switch based on key for union field Result
+	switch v.Stat {
+
+	case StatusOK:
+		var err error
+		v.Result, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Result: %w", err)
+		}
+
+	case StatusDenied:
+		// void case - no data
+
+	default:
+		// unknown key - decode nothing
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*Reply)(nil)
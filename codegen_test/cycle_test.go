@@ -3,10 +3,9 @@
 package main
 
 import (
-	"fmt"
-	"strings"
+	"encoding/binary"
+	"errors"
 	"testing"
-	"unsafe"
 
 	"github.com/tempusfrangit/go-xdr"
 )
@@ -38,17 +37,16 @@ type SimpleStruct struct {
 
 func TestCycleDetection(t *testing.T) {
 	tests := []struct {
-		name          string
-		setupData     func() interface{ Encode(enc *xdr.Encoder) error }
-		expectError   bool
-		errorContains string
+		name       string
+		setupData  func() interface{ Encode(enc *xdr.Encoder) error }
+		cycleCheck bool
+		expectErr  error
 	}{
 		{
 			name: "simple_struct_no_cycles",
 			setupData: func() interface{ Encode(enc *xdr.Encoder) error } {
 				return &SimpleStruct{ID: 1, Name: "test", Count: 42}
 			},
-			expectError: false,
 		},
 		{
 			name: "node_no_cycles",
@@ -62,10 +60,10 @@ func TestCycleDetection(t *testing.T) {
 					},
 				}
 			},
-			expectError: false,
+			cycleCheck: true,
 		},
 		{
-			name: "node_with_cycle",
+			name: "node_with_cycle_hits_depth_limit",
 			setupData: func() interface{ Encode(enc *xdr.Encoder) error } {
 				root := &Node{ID: 1, Value: "root"}
 				child := &Node{ID: 2, Value: "child"}
@@ -76,15 +74,19 @@ func TestCycleDetection(t *testing.T) {
 
 				return root
 			},
-			expectError:   true,
-			errorContains: "encoding loop detected",
+			expectErr: xdr.ErrMaxDepthExceeded,
 		},
 		{
-			name: "flexible_data_no_cycles",
+			name: "node_with_cycle_detected",
 			setupData: func() interface{ Encode(enc *xdr.Encoder) error } {
-				return &FlexibleData{ID: 1, Next: nil}
+				root := &Node{ID: 1, Value: "root"}
+				child := &Node{ID: 2, Value: "child"}
+				root.Children = []*Node{child}
+				child.Children = []*Node{root}
+				return root
 			},
-			expectError: false,
+			cycleCheck: true,
+			expectErr:  xdr.ErrEncodingLoop,
 		},
 		{
 			name: "flexible_data_with_cycle",
@@ -93,47 +95,38 @@ func TestCycleDetection(t *testing.T) {
 				flex.Next = flex // Self-reference through pointer field
 				return flex
 			},
-			expectError:   true,
-			errorContains: "encoding loop detected",
+			cycleCheck: true,
+			expectErr:  xdr.ErrEncodingLoop,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create encoder with sufficient buffer
-			enc := xdr.NewEncoder(make([]byte, 4096))
+			enc := xdr.NewEncoder(make([]byte, 1<<16))
+			enc.SetCycleCheck(tt.cycleCheck)
 
-			// Setup test data
-			testData := tt.setupData()
-
-			// Attempt encoding
-			err := testData.Encode(enc)
-
-			// Check expectations
-			if tt.expectError {
-				if err == nil {
-					t.Errorf("Expected error but got none")
-					return
-				}
-				if !strings.Contains(err.Error(), tt.errorContains) {
-					t.Errorf("Expected error to contain %q, got: %v", tt.errorContains, err)
-				}
-			} else {
-				if err != nil {
-					t.Errorf("Expected no error but got: %v", err)
+			err := tt.setupData().Encode(enc)
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Errorf("Expected %v, got: %v", tt.expectErr, err)
 				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
 			}
 		})
 	}
 }
 
 func TestCycleDetectionCleanup(t *testing.T) {
-	// Test that the encoding set is properly cleaned up after successful encoding
+	// Test that depth and visited pointers are released after successful encoding
 	node1 := &Node{ID: 1, Value: "node1"}
 	node2 := &Node{ID: 2, Value: "node2"}
-	node1.Children = []*Node{node2}
+	node1.Children = []*Node{node2, node2} // shared, but not a cycle
 
 	enc := xdr.NewEncoder(make([]byte, 1024))
+	enc.SetCycleCheck(true)
+	enc.SetMaxDepth(2)
 
 	// First encoding should succeed
 	err := node1.Encode(enc)
@@ -151,6 +144,28 @@ func TestCycleDetectionCleanup(t *testing.T) {
 	}
 }
 
+func TestDecodeDepthLimit(t *testing.T) {
+	// Hostile input: every node claims exactly one child, nesting far past the limit
+	var data []byte
+	for i := 0; i < 2*xdr.DefaultMaxDepth; i++ {
+		data = binary.BigEndian.AppendUint32(data, uint32(i)) // ID
+		data = binary.BigEndian.AppendUint32(data, 0)         // empty Value
+		data = binary.BigEndian.AppendUint32(data, 1)         // one child
+	}
+
+	var node Node
+	if err := xdr.Unmarshal(data, &node); !errors.Is(err, xdr.ErrMaxDepthExceeded) {
+		t.Errorf("Expected ErrMaxDepthExceeded, got: %v", err)
+	}
+
+	// With a larger limit the same input is simply truncated
+	dec := xdr.NewDecoder(data)
+	dec.SetMaxDepth(4 * xdr.DefaultMaxDepth)
+	if err := node.Decode(dec); !errors.Is(err, xdr.ErrUnexpectedEOF) {
+		t.Errorf("Expected ErrUnexpectedEOF, got: %v", err)
+	}
+}
+
 func BenchmarkEncodingWithoutLoopDetection(b *testing.B) {
 	// SimpleStruct should not have loop detection (fast path)
 	simple := &SimpleStruct{ID: 1, Name: "benchmark", Count: 12345}
@@ -188,16 +203,18 @@ func BenchmarkEncodingWithLoopDetection(b *testing.B) {
 }
 
 func BenchmarkEncodingFlexibleData(b *testing.B) {
-	// FlexibleData should have loop detection (due to self-reference)
-	flex := &FlexibleData{ID: 1, Next: nil}
+	// FlexibleData can only be finite through a cycle, which the cycle check rejects
+	flex := &FlexibleData{ID: 1, Next: &FlexibleData{ID: 2}}
+	flex.Next.Next = flex
 	enc := xdr.NewEncoder(make([]byte, 1024))
+	enc.SetCycleCheck(true)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		enc.Reset(make([]byte, 1024))
 		err := flex.Encode(enc)
-		if err != nil {
-			b.Fatalf("Encoding failed: %v", err)
+		if !errors.Is(err, xdr.ErrEncodingLoop) {
+			b.Fatalf("Expected encoding loop, got: %v", err)
 		}
 	}
 }
@@ -9,22 +9,14 @@ package main
 import (
 	"fmt"
 	"github.com/tempusfrangit/go-xdr"
-
-	"unsafe"
 )
 
 func (v *Node) Encode(enc *xdr.Encoder) error {
-	return v.EncodeWithContext(enc, make(map[unsafe.Pointer]bool))
-}
 
-func (v *Node) EncodeWithContext(enc *xdr.Encoder, encodingSet map[unsafe.Pointer]bool) error {
-	// Check for encoding loop using pointer address (prevents GC from moving objects)
-	ptr := unsafe.Pointer(v)
-	if encodingSet[ptr] {
-		return fmt.Errorf("encoding loop detected for Node")
+	if err := enc.Enter(v); err != nil {
+		return fmt.Errorf("failed to encode Node: %w", err)
 	}
-	encodingSet[ptr] = true
-	defer delete(encodingSet, ptr)
+	defer enc.Leave(v)
 
 	if err := enc.EncodeUint32(v.ID); err != nil {
 		return fmt.Errorf("failed to encode ID: %w", err)
@@ -40,7 +32,7 @@ func (v *Node) EncodeWithContext(enc *xdr.Encoder, encodingSet map[unsafe.Pointe
 	}
 	for _, elem := range v.Children {
 
-		if err := elem.EncodeWithContext(enc, encodingSet); err != nil {
+		if err := elem.Encode(enc); err != nil {
 			return fmt.Errorf("failed to encode element: %w", err)
 		}
 
@@ -51,6 +43,11 @@ func (v *Node) EncodeWithContext(enc *xdr.Encoder, encodingSet map[unsafe.Pointe
 
 func (v *Node) Decode(dec *xdr.Decoder) error {
 
+	if err := dec.Enter(); err != nil {
+		return fmt.Errorf("failed to decode Node: %w", err)
+	}
+	defer dec.Leave()
+
 	tempID, err := dec.DecodeUint32()
 	if err != nil {
 		return fmt.Errorf("failed to decode ID: %w", err)
@@ -85,17 +82,11 @@ func (v *Node) Decode(dec *xdr.Decoder) error {
 var _ xdr.Codec = (*Node)(nil)
 
 func (v *FlexibleData) Encode(enc *xdr.Encoder) error {
-	return v.EncodeWithContext(enc, make(map[unsafe.Pointer]bool))
-}
 
-func (v *FlexibleData) EncodeWithContext(enc *xdr.Encoder, encodingSet map[unsafe.Pointer]bool) error {
-	// Check for encoding loop using pointer address (prevents GC from moving objects)
-	ptr := unsafe.Pointer(v)
-	if encodingSet[ptr] {
-		return fmt.Errorf("encoding loop detected for FlexibleData")
+	if err := enc.Enter(v); err != nil {
+		return fmt.Errorf("failed to encode FlexibleData: %w", err)
 	}
-	encodingSet[ptr] = true
-	defer delete(encodingSet, ptr)
+	defer enc.Leave(v)
 
 	if err := enc.EncodeUint32(v.ID); err != nil {
 		return fmt.Errorf("failed to encode ID: %w", err)
@@ -105,7 +96,7 @@ func (v *FlexibleData) EncodeWithContext(enc *xdr.Encoder, encodingSet map[unsaf
 		return fmt.Errorf("pointer field Next is nil")
 	}
 
-	if err := v.Next.EncodeWithContext(enc, encodingSet); err != nil {
+	if err := v.Next.Encode(enc); err != nil {
 		return fmt.Errorf("failed to encode Next: %w", err)
 	}
 
@@ -114,6 +105,11 @@ func (v *FlexibleData) EncodeWithContext(enc *xdr.Encoder, encodingSet map[unsaf
 
 func (v *FlexibleData) Decode(dec *xdr.Decoder) error {
 
+	if err := dec.Enter(); err != nil {
+		return fmt.Errorf("failed to decode FlexibleData: %w", err)
+	}
+	defer dec.Leave()
+
 	tempID, err := dec.DecodeUint32()
 	if err != nil {
 		return fmt.Errorf("failed to decode ID: %w", err)
@@ -0,0 +1,157 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+	altpkg "github.com/tempusfrangit/go-xdr/codegen_test/alt_pkg"
+)
+
+// TestEmbeddedStructs tests embedded struct fields: by default they delegate to the
+// embedded type's Codec, and xdr:"inline" flattens their fields into the outer struct.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// EmbedFileHandle is a shared request header with its own Codec
+// +xdr:generate
+type EmbedFileHandle struct {
+	Handle []byte
+}
+
+// EmbedAuth has no Codec of its own and is only ever inlined
+type EmbedAuth struct {
+	UID uint32
+	GID uint32
+}
+
+// +xdr:generate
+type EmbedWriteArgs struct {
+	EmbedFileHandle
+	Offset uint64
+	Data   []byte
+}
+
+// +xdr:generate
+type EmbedReadArgs struct {
+	*EmbedFileHandle
+	Offset uint64
+	Count  uint32
+}
+
+// +xdr:generate
+type EmbedCreateArgs struct {
+	EmbedAuth `xdr:"inline"`
+	Name      string
+}
+
+// +xdr:generate
+type EmbedSetAttrArgs struct {
+	*EmbedAuth `xdr:"inline"`
+	Mode       uint32
+}
+
+// +xdr:generate
+type EmbedRemote struct {
+	altpkg.Header
+	altpkg.MyStruct `xdr:"inline"`
+	Size            uint64
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	handle := EmbedFileHandle{Handle: []byte{1, 2, 3, 4}}
+
+	t.Run("delegate", func(t *testing.T) {
+		args := &EmbedWriteArgs{EmbedFileHandle: handle, Offset: 4096, Data: []byte("data")}
+		data, err := xdr.Marshal(args)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		header, err := xdr.Marshal(&handle)
+		if err != nil {
+			t.Fatalf("Marshal() header failed: %v", err)
+		}
+		if !bytes.HasPrefix(data, header) {
+			t.Errorf("Expected the embedded header first:\nheader %x\ndata   %x", header, data)
+		}
+
+		var decoded EmbedWriteArgs
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if !bytes.Equal(decoded.Handle, handle.Handle) || decoded.Offset != 4096 || string(decoded.Data) != "data" {
+			t.Errorf("Expected %+v, got %+v", args, decoded)
+		}
+	})
+
+	t.Run("delegate pointer", func(t *testing.T) {
+		data, err := xdr.Marshal(&EmbedReadArgs{EmbedFileHandle: &handle, Offset: 1, Count: 512})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded EmbedReadArgs
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.EmbedFileHandle == nil || !bytes.Equal(decoded.Handle, handle.Handle) || decoded.Count != 512 {
+			t.Errorf("Expected handle and count to round trip, got %+v", decoded)
+		}
+
+		if _, err := xdr.Marshal(&EmbedReadArgs{}); err == nil {
+			t.Error("Expected error for nil embedded pointer")
+		}
+	})
+
+	t.Run("inline", func(t *testing.T) {
+		data, err := xdr.Marshal(&EmbedCreateArgs{EmbedAuth: EmbedAuth{UID: 1000, GID: 100}, Name: "a"})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		want := []byte{
+			0, 0, 0x03, 0xe8,
+			0, 0, 0, 100,
+			0, 0, 0, 1, 'a', 0, 0, 0,
+		}
+		if !bytes.Equal(data, want) {
+			t.Errorf("Expected flattened fields %x, got %x", want, data)
+		}
+	})
+
+	t.Run("inline pointer", func(t *testing.T) {
+		data, err := xdr.Marshal(&EmbedSetAttrArgs{EmbedAuth: &EmbedAuth{UID: 7, GID: 8}, Mode: 0o644})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if len(data) != 12 {
+			t.Errorf("Expected 12 bytes, got %d", len(data))
+		}
+		var decoded EmbedSetAttrArgs
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.EmbedAuth == nil || decoded.UID != 7 || decoded.GID != 8 || decoded.Mode != 0o644 {
+			t.Errorf("Expected inline pointer to be allocated and decoded, got %+v", decoded)
+		}
+
+		if _, err := xdr.Marshal(&EmbedSetAttrArgs{}); err == nil {
+			t.Error("Expected error for nil inline pointer")
+		}
+	})
+
+	t.Run("other package", func(t *testing.T) {
+		remote := &EmbedRemote{Header: altpkg.Header{Version: 3, Flags: 1}, MyStruct: altpkg.MyStruct{Field: "x"}, Size: 9}
+		data, err := xdr.Marshal(remote)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded EmbedRemote
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded != *remote {
+			t.Errorf("Expected %+v, got %+v", remote, decoded)
+		}
+	})
+}
@@ -0,0 +1,252 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: embedded_struct_test.go
+// Generated 6 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *EmbedFileHandle) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeBytes(v.Handle); err != nil {
+		return fmt.Errorf("failed to encode Handle: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbedFileHandle) Decode(dec *xdr.Decoder) error {
+
+	tempHandle, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Handle: %w", err)
+	}
+	v.Handle = tempHandle
+
+	return nil
+}
+
+var _ xdr.Codec = (*EmbedFileHandle)(nil)
+
+func (v *EmbedWriteArgs) Encode(enc *xdr.Encoder) error {
+
+	if err := v.EmbedFileHandle.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode EmbedFileHandle: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Offset); err != nil {
+		return fmt.Errorf("failed to encode Offset: %w", err)
+	}
+
+	if err := enc.EncodeBytes(v.Data); err != nil {
+		return fmt.Errorf("failed to encode Data: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbedWriteArgs) Decode(dec *xdr.Decoder) error {
+
+	if err := v.EmbedFileHandle.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode EmbedFileHandle: %w", err)
+	}
+
+	tempOffset, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Offset: %w", err)
+	}
+	v.Offset = tempOffset
+
+	tempData, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Data: %w", err)
+	}
+	v.Data = tempData
+
+	return nil
+}
+
+var _ xdr.Codec = (*EmbedWriteArgs)(nil)
+
+func (v *EmbedReadArgs) Encode(enc *xdr.Encoder) error {
+
+	if v.EmbedFileHandle == nil {
+		return fmt.Errorf("pointer field EmbedFileHandle is nil")
+	}
+
+	if err := v.EmbedFileHandle.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode EmbedFileHandle: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Offset); err != nil {
+		return fmt.Errorf("failed to encode Offset: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Count); err != nil {
+		return fmt.Errorf("failed to encode Count: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbedReadArgs) Decode(dec *xdr.Decoder) error {
+
+	// Allocate pointer field before decoding
+	v.EmbedFileHandle = &EmbedFileHandle{}
+
+	if err := v.EmbedFileHandle.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode EmbedFileHandle: %w", err)
+	}
+
+	tempOffset, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Offset: %w", err)
+	}
+	v.Offset = tempOffset
+
+	tempCount, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Count: %w", err)
+	}
+	v.Count = tempCount
+
+	return nil
+}
+
+var _ xdr.Codec = (*EmbedReadArgs)(nil)
+
+func (v *EmbedCreateArgs) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.UID); err != nil {
+		return fmt.Errorf("failed to encode UID: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.GID); err != nil {
+		return fmt.Errorf("failed to encode GID: %w", err)
+	}
+
+	if err := enc.EncodeString(v.Name); err != nil {
+		return fmt.Errorf("failed to encode Name: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbedCreateArgs) Decode(dec *xdr.Decoder) error {
+
+	tempUID, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode UID: %w", err)
+	}
+	v.UID = tempUID
+
+	tempGID, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode GID: %w", err)
+	}
+	v.GID = tempGID
+
+	tempName, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	v.Name = tempName
+
+	return nil
+}
+
+var _ xdr.Codec = (*EmbedCreateArgs)(nil)
+
+func (v *EmbedSetAttrArgs) Encode(enc *xdr.Encoder) error {
+
+	if v.EmbedAuth == nil {
+		return fmt.Errorf("embedded field EmbedAuth is nil")
+	}
+
+	if err := enc.EncodeUint32(v.UID); err != nil {
+		return fmt.Errorf("failed to encode UID: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.GID); err != nil {
+		return fmt.Errorf("failed to encode GID: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Mode); err != nil {
+		return fmt.Errorf("failed to encode Mode: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbedSetAttrArgs) Decode(dec *xdr.Decoder) error {
+
+	// Allocate inline embedded pointer before decoding its fields
+	v.EmbedAuth = &EmbedAuth{}
+
+	tempUID, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode UID: %w", err)
+	}
+	v.UID = tempUID
+
+	tempGID, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode GID: %w", err)
+	}
+	v.GID = tempGID
+
+	tempMode, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Mode: %w", err)
+	}
+	v.Mode = tempMode
+
+	return nil
+}
+
+var _ xdr.Codec = (*EmbedSetAttrArgs)(nil)
+
+func (v *EmbedRemote) Encode(enc *xdr.Encoder) error {
+
+	if err := v.Header.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode Header: %w", err)
+	}
+
+	if err := enc.EncodeString(v.Field); err != nil {
+		return fmt.Errorf("failed to encode Field: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Size); err != nil {
+		return fmt.Errorf("failed to encode Size: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbedRemote) Decode(dec *xdr.Decoder) error {
+
+	if err := v.Header.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode Header: %w", err)
+	}
+
+	tempField, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Field: %w", err)
+	}
+	v.Field = tempField
+
+	tempSize, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Size: %w", err)
+	}
+	v.Size = tempSize
+
+	return nil
+}
+
+var _ xdr.Codec = (*EmbedRemote)(nil)
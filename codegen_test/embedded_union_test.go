@@ -0,0 +1,151 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestEmbeddedUnions tests multiple union-valued fields inside an ordinary struct,
+// each with its own discriminant field, mirroring NFS post_op_attr and wcc_data.
+
+//go:generate ../bin/xdrgen $GOFILE
+type EmbeddedFollows bool
+
+type EmbeddedStat int32
+
+const (
+	EmbeddedStatOK    EmbeddedStat = 0
+	EmbeddedStatPerm  EmbeddedStat = 1
+	EmbeddedStatNoEnt EmbeddedStat = 2
+)
+
+// +xdr:arm,union=EmbeddedReply,field=Verf,discriminant=true,type=[8]byte
+
+// +xdr:generate
+type EmbeddedReply struct {
+	Status      EmbeddedStat
+	Result      []byte `xdr:"union=Status,unknown=reject"`
+	AttrFollows EmbeddedFollows
+	Attr        []byte `xdr:"union=AttrFollows"`
+	VerfFollows bool
+	Verf        []byte `xdr:"union=VerfFollows"`
+	Trailer     uint32
+}
+
+// +xdr:generate
+type EmbeddedCaptured struct {
+	Status EmbeddedStat
+	Body   []byte `xdr:"union=Status,unknown=capture"`
+	Trail  uint32
+}
+
+// +xdr:payload,union=EmbeddedReply,field=Result,discriminant=EmbeddedStatOK
+type EmbeddedReadOK struct {
+	Count uint32
+	EOF   bool
+}
+
+// +xdr:payload,union=EmbeddedReply,field=Attr,discriminant=true
+type EmbeddedAttr struct {
+	Mode uint32
+	Size uint64
+}
+
+func TestEmbeddedUnions(t *testing.T) {
+	t.Run("all unions populated", func(t *testing.T) {
+		result, err := xdr.Marshal(&EmbeddedReadOK{Count: 12, EOF: true})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		attr, err := xdr.Marshal(&EmbeddedAttr{Mode: 0o644, Size: 12})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		reply := &EmbeddedReply{
+			Status:      EmbeddedStatOK,
+			Result:      result,
+			AttrFollows: true,
+			Attr:        attr,
+			Trailer:     7,
+		}
+		if err := reply.SetVerfTrue([8]byte{1, 2, 3, 4, 5, 6, 7, 8}); err != nil {
+			t.Fatalf("SetVerfTrue() failed: %v", err)
+		}
+
+		data, err := xdr.Marshal(reply)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded EmbeddedReply
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+
+		var decodedAttr EmbeddedAttr
+		if err := xdr.Unmarshal(decoded.Attr, &decodedAttr); err != nil {
+			t.Fatalf("Unmarshal() attr failed: %v", err)
+		}
+		if decodedAttr.Size != 12 || decoded.Trailer != 7 {
+			t.Errorf("Expected size 12 and trailer 7, got %d and %d", decodedAttr.Size, decoded.Trailer)
+		}
+		verf, err := decoded.VerfTrue()
+		if err != nil || verf[7] != 8 {
+			t.Errorf("Expected verifier, got %v (%v)", verf, err)
+		}
+	})
+
+	t.Run("void arms encode only discriminants", func(t *testing.T) {
+		reply := &EmbeddedReply{Status: EmbeddedStatPerm, Trailer: 1}
+		data, err := xdr.Marshal(reply)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if len(data) != 16 {
+			t.Errorf("Expected 16 bytes (three discriminants and trailer), got %d", len(data))
+		}
+	})
+
+	t.Run("per-field unknown policy", func(t *testing.T) {
+		_, err := xdr.Marshal(&EmbeddedReply{Status: 42})
+		if !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+			t.Errorf("Expected ErrUnknownDiscriminant, got %v", err)
+		}
+	})
+
+	t.Run("captured arm leaves following fields", func(t *testing.T) {
+		data := []byte{
+			0x00, 0x00, 0x00, 0x09, // unknown status
+			0x00, 0x00, 0x00, 0x02, 0xab, 0xcd, 0x00, 0x00, // opaque arm
+			0x00, 0x00, 0x00, 0x07, // Trail
+		}
+		var decoded EmbeddedCaptured
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if string(decoded.Body) != "\xab\xcd" || decoded.Trail != 7 {
+			t.Errorf("Expected body abcd and trail 7, got %x and %d", decoded.Body, decoded.Trail)
+		}
+
+		again, err := xdr.Marshal(&decoded)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if string(again) != string(data) {
+			t.Errorf("Expected %x, got %x", data, again)
+		}
+	})
+
+	t.Run("EncodeToUnion writes discriminant and payload", func(t *testing.T) {
+		enc := xdr.NewEncoder(make([]byte, 64))
+		if err := (&EmbeddedAttr{Mode: 1, Size: 2}).EncodeToUnion(enc); err != nil {
+			t.Fatalf("EncodeToUnion() failed: %v", err)
+		}
+		if string(enc.Bytes()[:4]) != "\x00\x00\x00\x01" {
+			t.Errorf("Expected true discriminant, got %x", enc.Bytes()[:4])
+		}
+	})
+}
@@ -0,0 +1,392 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: embedded_union_test.go
+// Generated 5 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *EmbeddedReply) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeInt32(int32(v.Status)); err != nil {
+		return fmt.Errorf("failed to encode Status: %w", err)
+	}
+
+	// Switch based on key for union field Result
+	switch v.Status {
+
+	case EmbeddedStatOK:
+		if err := enc.EncodeBytes(v.Result); err != nil {
+			return fmt.Errorf("failed to encode Result: %w", err)
+		}
+
+	case EmbeddedStatNoEnt:
+		// void case - no data
+
+	case EmbeddedStatPerm:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Status=%v", xdr.ErrUnknownDiscriminant, v.Status)
+
+	}
+
+	if err := enc.EncodeBool(bool(v.AttrFollows)); err != nil {
+		return fmt.Errorf("failed to encode AttrFollows: %w", err)
+	}
+
+	// Switch based on key for union field Attr
+	switch v.AttrFollows {
+
+	case true:
+		if err := enc.EncodeBytes(v.Attr); err != nil {
+			return fmt.Errorf("failed to encode Attr: %w", err)
+		}
+
+	case false:
+		// void case - no data
+
+	default:
+		// unknown key - encode nothing
+
+	}
+
+	if err := enc.EncodeBool(v.VerfFollows); err != nil {
+		return fmt.Errorf("failed to encode VerfFollows: %w", err)
+	}
+
+	// Switch based on key for union field Verf
+	switch v.VerfFollows {
+
+	case true:
+		if err := enc.EncodeBytes(v.Verf); err != nil {
+			return fmt.Errorf("failed to encode Verf: %w", err)
+		}
+
+	case false:
+		// void case - no data
+
+	default:
+		// unknown key - encode nothing
+
+	}
+
+	if err := enc.EncodeUint32(v.Trailer); err != nil {
+		return fmt.Errorf("failed to encode Trailer: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbeddedReply) Decode(dec *xdr.Decoder) error {
+
+	tempStatus, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Status: %w", err)
+	}
+	v.Status = EmbeddedStat(tempStatus)
+
+	// Switch based on key for union field Result
+	switch v.Status {
+
+	case EmbeddedStatOK:
+		var err error
+		v.Result, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Result: %w", err)
+		}
+
+	case EmbeddedStatNoEnt:
+		// void case - no data
+
+	case EmbeddedStatPerm:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Status=%v", xdr.ErrUnknownDiscriminant, v.Status)
+
+	}
+
+	tempAttrFollows, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode AttrFollows: %w", err)
+	}
+	v.AttrFollows = EmbeddedFollows(tempAttrFollows)
+
+	// Switch based on key for union field Attr
+	switch v.AttrFollows {
+
+	case true:
+		var err error
+		v.Attr, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Attr: %w", err)
+		}
+
+	case false:
+		// void case - no data
+
+	default:
+		// unknown key - decode nothing
+
+	}
+
+	tempVerfFollows, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode VerfFollows: %w", err)
+	}
+	v.VerfFollows = tempVerfFollows
+
+	// Switch based on key for union field Verf
+	switch v.VerfFollows {
+
+	case true:
+		var err error
+		v.Verf, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Verf: %w", err)
+		}
+
+	case false:
+		// void case - no data
+
+	default:
+		// unknown key - decode nothing
+
+	}
+
+	tempTrailer, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Trailer: %w", err)
+	}
+	v.Trailer = tempTrailer
+
+	return nil
+}
+
+var _ xdr.Codec = (*EmbeddedReply)(nil)
+
+// embeddedReplyVerfTrueArm wraps the true arm of EmbeddedReply
+type embeddedReplyVerfTrueArm struct {
+	Value [8]byte
+}
+
+// VerfTrue returns the true arm of EmbeddedReply
+func (v *EmbeddedReply) VerfTrue() ([8]byte, error) {
+	var arm embeddedReplyVerfTrueArm
+	if v.VerfFollows != true {
+		return arm.Value, fmt.Errorf("%w: VerfFollows=%v, want true", xdr.ErrArmNotSelected, v.VerfFollows)
+	}
+	if err := xdr.Unmarshal(v.Verf, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode true arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetVerfTrue sets EmbeddedReply to the true arm with the given value
+func (v *EmbeddedReply) SetVerfTrue(val [8]byte) error {
+	data, err := xdr.Marshal(&embeddedReplyVerfTrueArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode true arm: %w", err)
+	}
+	v.VerfFollows = true
+	v.Verf = data
+	return nil
+}
+
+func (v *embeddedReplyVerfTrueArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeFixedBytes(v.Value[:]); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *embeddedReplyVerfTrueArm) Decode(dec *xdr.Decoder) error {
+
+	if err := dec.DecodeFixedBytesInto(v.Value[:]); err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*embeddedReplyVerfTrueArm)(nil)
+
+func (v *EmbeddedCaptured) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeInt32(int32(v.Status)); err != nil {
+		return fmt.Errorf("failed to encode Status: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Status {
+
+	case EmbeddedStatNoEnt:
+		// void case - no data
+
+	case EmbeddedStatOK:
+		// void case - no data
+
+	case EmbeddedStatPerm:
+		// void case - no data
+
+	default:
+		// unknown key - encode the captured arm
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	}
+
+	if err := enc.EncodeUint32(v.Trail); err != nil {
+		return fmt.Errorf("failed to encode Trail: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbeddedCaptured) Decode(dec *xdr.Decoder) error {
+
+	tempStatus, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Status: %w", err)
+	}
+	v.Status = EmbeddedStat(tempStatus)
+
+	// Switch based on key for union field Body
+	switch v.Status {
+
+	case EmbeddedStatNoEnt:
+		// void case - no data
+
+	case EmbeddedStatOK:
+		// void case - no data
+
+	case EmbeddedStatPerm:
+		// void case - no data
+
+	default:
+		// unknown key - capture the opaque arm
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	}
+
+	tempTrail, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Trail: %w", err)
+	}
+	v.Trail = tempTrail
+
+	return nil
+}
+
+var _ xdr.Codec = (*EmbeddedCaptured)(nil)
+
+func (v *EmbeddedReadOK) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Count); err != nil {
+		return fmt.Errorf("failed to encode Count: %w", err)
+	}
+
+	if err := enc.EncodeBool(v.EOF); err != nil {
+		return fmt.Errorf("failed to encode EOF: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbeddedReadOK) Decode(dec *xdr.Decoder) error {
+
+	tempCount, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Count: %w", err)
+	}
+	v.Count = tempCount
+
+	tempEOF, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode EOF: %w", err)
+	}
+	v.EOF = tempEOF
+
+	return nil
+}
+
+// EncodeToUnion encodes EmbeddedReadOK directly to union format
+func (p *EmbeddedReadOK) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeInt32(int32(EmbeddedStatOK)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*EmbeddedReadOK)(nil)
+
+func (v *EmbeddedAttr) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Mode); err != nil {
+		return fmt.Errorf("failed to encode Mode: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Size); err != nil {
+		return fmt.Errorf("failed to encode Size: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EmbeddedAttr) Decode(dec *xdr.Decoder) error {
+
+	tempMode, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Mode: %w", err)
+	}
+	v.Mode = tempMode
+
+	tempSize, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Size: %w", err)
+	}
+	v.Size = tempSize
+
+	return nil
+}
+
+// EncodeToUnion encodes EmbeddedAttr directly to union format
+func (p *EmbeddedAttr) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeBool(bool(true)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*EmbeddedAttr)(nil)
@@ -0,0 +1,92 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestEnvelopes tests message types registered for xdr.MarshalAny and xdr.UnmarshalAny
+// with +xdr:envelope, written to and read back from one stream.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+const EnvDeletedID = 0x4e560002
+
+// +xdr:envelope,type=EnvCreated,id=0x4e560001,version=2
+// +xdr:envelope,type=EnvDeleted,id=EnvDeletedID
+
+// +xdr:generate
+type EnvCreated struct {
+	Name  string
+	Owner uint32
+}
+
+// +xdr:generate
+type EnvDeleted struct {
+	Name string
+}
+
+func TestEnvelopes(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		data, err := xdr.MarshalAny(&EnvCreated{Name: "a", Owner: 7})
+		if err != nil {
+			t.Fatalf("MarshalAny() failed: %v", err)
+		}
+		var envelope xdr.Envelope
+		if err := xdr.Unmarshal(data, &envelope); err != nil {
+			t.Fatalf("Unmarshal() envelope failed: %v", err)
+		}
+		if envelope.TypeID != 0x4e560001 || envelope.Version != 2 {
+			t.Errorf("Expected type 0x4e560001 version 2, got %#x version %d", envelope.TypeID, envelope.Version)
+		}
+
+		v, err := xdr.UnmarshalAny(data)
+		if err != nil {
+			t.Fatalf("UnmarshalAny() failed: %v", err)
+		}
+		if created, ok := v.(*EnvCreated); !ok || *created != (EnvCreated{Name: "a", Owner: 7}) {
+			t.Errorf("Expected *EnvCreated, got %#v", v)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		enc := xdr.NewEncoder(make([]byte, 256))
+		for _, message := range []xdr.Codec{&EnvCreated{Name: "b"}, &EnvDeleted{Name: "b"}} {
+			if err := xdr.EncodeAny(enc, message); err != nil {
+				t.Fatalf("EncodeAny() failed: %v", err)
+			}
+		}
+
+		dec := xdr.NewDecoder(enc.Bytes())
+		var names []string
+		for dec.Remaining() > 0 {
+			v, err := xdr.DecodeAny(dec)
+			if err != nil {
+				t.Fatalf("DecodeAny() failed: %v", err)
+			}
+			switch m := v.(type) {
+			case *EnvCreated:
+				names = append(names, "created "+m.Name)
+			case *EnvDeleted:
+				names = append(names, "deleted "+m.Name)
+			}
+		}
+		if len(names) != 2 || names[0] != "created b" || names[1] != "deleted b" {
+			t.Errorf("Expected created and deleted events, got %v", names)
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		data, err := xdr.Marshal(&xdr.Envelope{TypeID: 0x4e56ffff, Version: 1})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if _, err := xdr.UnmarshalAny(data); !errors.Is(err, xdr.ErrUnregisteredType) {
+			t.Errorf("Expected ErrUnregisteredType, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: envelope_test.go
+// Generated 2 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *EnvCreated) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Name); err != nil {
+		return fmt.Errorf("failed to encode Name: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Owner); err != nil {
+		return fmt.Errorf("failed to encode Owner: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EnvCreated) Decode(dec *xdr.Decoder) error {
+
+	tempName, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	v.Name = tempName
+
+	tempOwner, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Owner: %w", err)
+	}
+	v.Owner = tempOwner
+
+	return nil
+}
+
+var _ xdr.Codec = (*EnvCreated)(nil)
+
+func (v *EnvDeleted) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Name); err != nil {
+		return fmt.Errorf("failed to encode Name: %w", err)
+	}
+
+	return nil
+}
+
+func (v *EnvDeleted) Decode(dec *xdr.Decoder) error {
+
+	tempName, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	v.Name = tempName
+
+	return nil
+}
+
+var _ xdr.Codec = (*EnvDeleted)(nil)
+
+// Register the +xdr:envelope message types for xdr.UnmarshalAny
+func init() {
+	xdr.MustRegister[EnvCreated](0x4e560001, 2)
+	xdr.MustRegister[EnvDeleted](uint32(EnvDeletedID), 1)
+}
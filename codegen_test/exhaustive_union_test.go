@@ -0,0 +1,86 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestExhaustiveUnion tests an exhaustive union over an iota enum whose constants all
+// select a payload or arm, including an alias constant sharing a selected value.
+
+//go:generate ../bin/xdrgen $GOFILE
+type ExhaustiveOp uint32
+
+const (
+	ExhaustiveOpRead ExhaustiveOp = iota + 1
+	ExhaustiveOpWrite
+	ExhaustiveOpSync
+
+	// ExhaustiveOpLatest shares the value of ExhaustiveOpWrite and needs no arm of its own
+	ExhaustiveOpLatest = ExhaustiveOpWrite
+)
+
+// +xdr:arm,union=ExhaustiveRequest,discriminant=ExhaustiveOpSync,type=uint64
+
+// +xdr:union,key=Op,exhaustive,unknown=reject
+type ExhaustiveRequest struct {
+	Op   ExhaustiveOp // discriminant
+	Args []byte       // auto-detected as union payload
+}
+
+// +xdr:payload,union=ExhaustiveRequest,discriminant=ExhaustiveOpRead
+type ExhaustiveReadArgs struct {
+	Offset uint64
+	Count  uint32
+}
+
+// +xdr:payload,union=ExhaustiveRequest,discriminant=ExhaustiveOpWrite
+type ExhaustiveWriteArgs struct {
+	Offset uint64
+	Data   []byte
+}
+
+func TestExhaustiveUnion(t *testing.T) {
+	t.Run("iota discriminants encode their values", func(t *testing.T) {
+		request, err := (&ExhaustiveWriteArgs{Offset: 8, Data: []byte("abc")}).ToUnion()
+		if err != nil {
+			t.Fatalf("ToUnion() failed: %v", err)
+		}
+		data, err := xdr.Marshal(request)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if string(data[:4]) != "\x00\x00\x00\x02" {
+			t.Errorf("Expected discriminant 2, got %x", data[:4])
+		}
+
+		var decoded ExhaustiveRequest
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Op != ExhaustiveOpLatest {
+			t.Errorf("Expected ExhaustiveOpLatest alias to match, got %v", decoded.Op)
+		}
+	})
+
+	t.Run("arm discriminant", func(t *testing.T) {
+		var request ExhaustiveRequest
+		if err := request.SetExhaustiveOpSync(42); err != nil {
+			t.Fatalf("SetExhaustiveOpSync() failed: %v", err)
+		}
+		data, err := xdr.Marshal(&request)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded ExhaustiveRequest
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if cookie, err := decoded.ExhaustiveOpSync(); err != nil || cookie != 42 {
+			t.Errorf("Expected 42, got %d (%v)", cookie, err)
+		}
+	})
+}
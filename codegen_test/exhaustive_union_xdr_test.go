@@ -0,0 +1,264 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: exhaustive_union_test.go
+// Generated 4 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *ExhaustiveRequest) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(uint32(v.Op)); err != nil {
+		return fmt.Errorf("failed to encode Op: %w", err)
+	}
+
+	// Switch based on key for union field Args
+	switch v.Op {
+
+	case ExhaustiveOpRead:
+		if err := enc.EncodeBytes(v.Args); err != nil {
+			return fmt.Errorf("failed to encode Args: %w", err)
+		}
+
+	case ExhaustiveOpSync:
+		if err := enc.EncodeBytes(v.Args); err != nil {
+			return fmt.Errorf("failed to encode Args: %w", err)
+		}
+
+	case ExhaustiveOpWrite:
+		if err := enc.EncodeBytes(v.Args); err != nil {
+			return fmt.Errorf("failed to encode Args: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("%w: Op=%v", xdr.ErrUnknownDiscriminant, v.Op)
+
+	}
+
+	return nil
+}
+
+func (v *ExhaustiveRequest) Decode(dec *xdr.Decoder) error {
+
+	tempOp, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Op: %w", err)
+	}
+	v.Op = ExhaustiveOp(tempOp)
+
+	// Switch based on key for union field Args
+	switch v.Op {
+
+	case ExhaustiveOpRead:
+		var err error
+		v.Args, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Args: %w", err)
+		}
+
+	case ExhaustiveOpSync:
+		var err error
+		v.Args, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Args: %w", err)
+		}
+
+	case ExhaustiveOpWrite:
+		var err error
+		v.Args, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Args: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("%w: Op=%v", xdr.ErrUnknownDiscriminant, v.Op)
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ExhaustiveRequest)(nil)
+
+// exhaustiveRequestExhaustiveOpSyncArm wraps the ExhaustiveOpSync arm of ExhaustiveRequest
+type exhaustiveRequestExhaustiveOpSyncArm struct {
+	Value uint64
+}
+
+// ExhaustiveOpSync returns the ExhaustiveOpSync arm of ExhaustiveRequest
+func (v *ExhaustiveRequest) ExhaustiveOpSync() (uint64, error) {
+	var arm exhaustiveRequestExhaustiveOpSyncArm
+	if v.Op != ExhaustiveOpSync {
+		return arm.Value, fmt.Errorf("%w: Op=%v, want ExhaustiveOpSync", xdr.ErrArmNotSelected, v.Op)
+	}
+	if err := xdr.Unmarshal(v.Args, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode ExhaustiveOpSync arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetExhaustiveOpSync sets ExhaustiveRequest to the ExhaustiveOpSync arm with the given value
+func (v *ExhaustiveRequest) SetExhaustiveOpSync(val uint64) error {
+	data, err := xdr.Marshal(&exhaustiveRequestExhaustiveOpSyncArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode ExhaustiveOpSync arm: %w", err)
+	}
+	v.Op = ExhaustiveOpSync
+	v.Args = data
+	return nil
+}
+
+func (v *exhaustiveRequestExhaustiveOpSyncArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *exhaustiveRequestExhaustiveOpSyncArm) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+var _ xdr.Codec = (*exhaustiveRequestExhaustiveOpSyncArm)(nil)
+
+func (v *ExhaustiveReadArgs) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Offset); err != nil {
+		return fmt.Errorf("failed to encode Offset: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Count); err != nil {
+		return fmt.Errorf("failed to encode Count: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ExhaustiveReadArgs) Decode(dec *xdr.Decoder) error {
+
+	tempOffset, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Offset: %w", err)
+	}
+	v.Offset = tempOffset
+
+	tempCount, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Count: %w", err)
+	}
+	v.Count = tempCount
+
+	return nil
+}
+
+// ToUnion converts ExhaustiveReadArgs to ExhaustiveRequest
+func (p *ExhaustiveReadArgs) ToUnion() (*ExhaustiveRequest, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode ExhaustiveReadArgs: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &ExhaustiveRequest{
+		Op:   ExhaustiveOpRead,
+		Args: data,
+	}, nil
+}
+
+// EncodeToUnion encodes ExhaustiveReadArgs directly to union format
+func (p *ExhaustiveReadArgs) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeUint32(uint32(ExhaustiveOpRead)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*ExhaustiveReadArgs)(nil)
+
+func (v *ExhaustiveWriteArgs) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Offset); err != nil {
+		return fmt.Errorf("failed to encode Offset: %w", err)
+	}
+
+	if err := enc.EncodeBytes(v.Data); err != nil {
+		return fmt.Errorf("failed to encode Data: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ExhaustiveWriteArgs) Decode(dec *xdr.Decoder) error {
+
+	tempOffset, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Offset: %w", err)
+	}
+	v.Offset = tempOffset
+
+	tempData, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Data: %w", err)
+	}
+	v.Data = tempData
+
+	return nil
+}
+
+// ToUnion converts ExhaustiveWriteArgs to ExhaustiveRequest
+func (p *ExhaustiveWriteArgs) ToUnion() (*ExhaustiveRequest, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode ExhaustiveWriteArgs: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &ExhaustiveRequest{
+		Op:   ExhaustiveOpWrite,
+		Args: data,
+	}, nil
+}
+
+// EncodeToUnion encodes ExhaustiveWriteArgs directly to union format
+func (p *ExhaustiveWriteArgs) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeUint32(uint32(ExhaustiveOpWrite)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*ExhaustiveWriteArgs)(nil)
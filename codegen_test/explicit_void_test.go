@@ -0,0 +1,101 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestExplicitVoidArms tests unions that list their void arms with void=A|B, so
+// constants of the key type that are not declared follow the unknown policy.
+
+//go:generate ../bin/xdrgen $GOFILE
+type ExplicitStat uint32
+
+// ExplicitStat values are shared by several operations; each union picks its own arms
+const (
+	ExplicitStatOK     ExplicitStat = 0
+	ExplicitStatNoEnt  ExplicitStat = 2
+	ExplicitStatIO     ExplicitStat = 5
+	ExplicitStatAccess ExplicitStat = 13
+)
+
+// +xdr:union,key=Stat,void=ExplicitStatNoEnt|ExplicitStatAccess,unknown=reject
+type ExplicitLookupResult struct {
+	Stat ExplicitStat // discriminant
+	Body []byte       // auto-detected as union payload
+}
+
+// +xdr:payload,union=ExplicitLookupResult,discriminant=ExplicitStatOK
+type ExplicitLookupOK struct {
+	Handle []byte
+}
+
+// +xdr:generate
+type ExplicitAttrReply struct {
+	Follows bool
+	Attr    []byte `xdr:"union=Follows,void=false,unknown=reject"`
+}
+
+// +xdr:payload,union=ExplicitAttrReply,field=Attr,discriminant=true
+type ExplicitAttr struct {
+	Size uint64
+}
+
+func TestExplicitVoidArms(t *testing.T) {
+	t.Run("declared void arms encode only the discriminant", func(t *testing.T) {
+		for _, stat := range []ExplicitStat{ExplicitStatNoEnt, ExplicitStatAccess} {
+			data, err := xdr.Marshal(&ExplicitLookupResult{Stat: stat})
+			if err != nil {
+				t.Fatalf("Marshal() failed for %d: %v", stat, err)
+			}
+			if len(data) != 4 {
+				t.Errorf("Expected 4 bytes for void arm %d, got %d", stat, len(data))
+			}
+		}
+	})
+
+	t.Run("undeclared constant follows unknown policy", func(t *testing.T) {
+		_, err := xdr.Marshal(&ExplicitLookupResult{Stat: ExplicitStatIO})
+		if !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+			t.Errorf("Expected ErrUnknownDiscriminant on encode, got %v", err)
+		}
+
+		var decoded ExplicitLookupResult
+		err = xdr.Unmarshal([]byte{0, 0, 0, 5}, &decoded)
+		if !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+			t.Errorf("Expected ErrUnknownDiscriminant on decode, got %v", err)
+		}
+	})
+
+	t.Run("payload arm round-trips", func(t *testing.T) {
+		result, err := (&ExplicitLookupOK{Handle: []byte{1, 2, 3}}).ToUnion()
+		if err != nil {
+			t.Fatalf("ToUnion() failed: %v", err)
+		}
+		data, err := xdr.Marshal(result)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded ExplicitLookupResult
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Stat != ExplicitStatOK || string(decoded.Body) != string(result.Body) {
+			t.Errorf("Expected %v, got %v", result, decoded)
+		}
+	})
+
+	t.Run("bool void arm", func(t *testing.T) {
+		data, err := xdr.Marshal(&ExplicitAttrReply{Follows: false})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if len(data) != 4 {
+			t.Errorf("Expected 4 bytes for absent attributes, got %d", len(data))
+		}
+	})
+}
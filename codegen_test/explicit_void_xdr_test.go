@@ -0,0 +1,223 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: explicit_void_test.go
+// Generated 4 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *ExplicitLookupResult) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(uint32(v.Stat)); err != nil {
+		return fmt.Errorf("failed to encode Stat: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Stat {
+
+	case ExplicitStatOK:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case ExplicitStatAccess:
+		// void case - no data
+
+	case ExplicitStatNoEnt:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Stat=%v", xdr.ErrUnknownDiscriminant, v.Stat)
+
+	}
+
+	return nil
+}
+
+func (v *ExplicitLookupResult) Decode(dec *xdr.Decoder) error {
+
+	tempStat, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Stat: %w", err)
+	}
+	v.Stat = ExplicitStat(tempStat)
+
+	// Switch based on key for union field Body
+	switch v.Stat {
+
+	case ExplicitStatOK:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case ExplicitStatAccess:
+		// void case - no data
+
+	case ExplicitStatNoEnt:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Stat=%v", xdr.ErrUnknownDiscriminant, v.Stat)
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ExplicitLookupResult)(nil)
+
+func (v *ExplicitLookupOK) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeBytes(v.Handle); err != nil {
+		return fmt.Errorf("failed to encode Handle: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ExplicitLookupOK) Decode(dec *xdr.Decoder) error {
+
+	tempHandle, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Handle: %w", err)
+	}
+	v.Handle = tempHandle
+
+	return nil
+}
+
+// ToUnion converts ExplicitLookupOK to ExplicitLookupResult
+func (p *ExplicitLookupOK) ToUnion() (*ExplicitLookupResult, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode ExplicitLookupOK: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &ExplicitLookupResult{
+		Stat: ExplicitStatOK,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes ExplicitLookupOK directly to union format
+func (p *ExplicitLookupOK) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeUint32(uint32(ExplicitStatOK)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*ExplicitLookupOK)(nil)
+
+func (v *ExplicitAttrReply) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeBool(v.Follows); err != nil {
+		return fmt.Errorf("failed to encode Follows: %w", err)
+	}
+
+	// Switch based on key for union field Attr
+	switch v.Follows {
+
+	case true:
+		if err := enc.EncodeBytes(v.Attr); err != nil {
+			return fmt.Errorf("failed to encode Attr: %w", err)
+		}
+
+	case false:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Follows=%v", xdr.ErrUnknownDiscriminant, v.Follows)
+
+	}
+
+	return nil
+}
+
+func (v *ExplicitAttrReply) Decode(dec *xdr.Decoder) error {
+
+	tempFollows, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode Follows: %w", err)
+	}
+	v.Follows = tempFollows
+
+	// Switch based on key for union field Attr
+	switch v.Follows {
+
+	case true:
+		var err error
+		v.Attr, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Attr: %w", err)
+		}
+
+	case false:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Follows=%v", xdr.ErrUnknownDiscriminant, v.Follows)
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ExplicitAttrReply)(nil)
+
+func (v *ExplicitAttr) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Size); err != nil {
+		return fmt.Errorf("failed to encode Size: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ExplicitAttr) Decode(dec *xdr.Decoder) error {
+
+	tempSize, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Size: %w", err)
+	}
+	v.Size = tempSize
+
+	return nil
+}
+
+// EncodeToUnion encodes ExplicitAttr directly to union format
+func (p *ExplicitAttr) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeBool(bool(true)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*ExplicitAttr)(nil)
@@ -0,0 +1,117 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestExtUnions tests version extension unions generated with +xdr:ext: version 0 is
+// void, version N carries the Nth payload, and EncodeVersion downgrades for older peers.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// +xdr:ext,type=ExtAccountExt,versions=ExtAccountV1|ExtAccountV2
+
+// +xdr:generate
+type ExtAccountV1 struct {
+	Liabilities uint64
+}
+
+// +xdr:generate
+type ExtAccountV2 struct {
+	Liabilities uint64
+	Sponsors    uint32
+}
+
+// +xdr:generate
+type ExtAccount struct {
+	ID      string
+	Balance int64
+	Ext     ExtAccountExt
+}
+
+func TestExtUnions(t *testing.T) {
+	t.Run("void version", func(t *testing.T) {
+		data, err := xdr.Marshal(&ExtAccount{ID: "a", Balance: 5})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		want := []byte{
+			0, 0, 0, 1, 'a', 0, 0, 0,
+			0, 0, 0, 0, 0, 0, 0, 5,
+			0, 0, 0, 0,
+		}
+		if string(data) != string(want) {
+			t.Errorf("Expected %x, got %x", want, data)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		account := &ExtAccount{ID: "b", Ext: ExtAccountExt{V: 2, V2: &ExtAccountV2{Liabilities: 7, Sponsors: 3}}}
+		data, err := xdr.Marshal(account)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded ExtAccount
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Ext.V != 2 || decoded.Ext.V1 != nil || decoded.Ext.V2 == nil || *decoded.Ext.V2 != *account.Ext.V2 {
+			t.Errorf("Expected %+v, got %+v", account.Ext, decoded.Ext)
+		}
+		if decoded.Ext.Latest() != ExtAccountExtMaxVersion {
+			t.Errorf("Expected Latest() %d, got %d", ExtAccountExtMaxVersion, decoded.Ext.Latest())
+		}
+	})
+
+	t.Run("encode version", func(t *testing.T) {
+		ext := ExtAccountExt{V1: &ExtAccountV1{Liabilities: 9}, V2: &ExtAccountV2{Liabilities: 9, Sponsors: 1}}
+		for _, tc := range []struct {
+			version int32
+			want    int32
+		}{
+			{0, 0},
+			{1, 1},
+			{2, 2},
+			{5, 2},
+		} {
+			enc := xdr.NewEncoder(make([]byte, 64))
+			if err := ext.EncodeVersion(enc, tc.version); err != nil {
+				t.Fatalf("EncodeVersion(%d) failed: %v", tc.version, err)
+			}
+			var decoded ExtAccountExt
+			if err := xdr.Unmarshal(enc.Bytes(), &decoded); err != nil {
+				t.Fatalf("Unmarshal() failed: %v", err)
+			}
+			if decoded.V != tc.want || decoded.Latest() != tc.want {
+				t.Errorf("EncodeVersion(%d): expected version %d, got %d", tc.version, tc.want, decoded.V)
+			}
+		}
+
+		// Without a V1 payload an old peer gets the void version
+		enc := xdr.NewEncoder(make([]byte, 64))
+		if err := (&ExtAccountExt{V2: ext.V2}).EncodeVersion(enc, 1); err != nil {
+			t.Fatalf("EncodeVersion() failed: %v", err)
+		}
+		if len(enc.Bytes()) != 4 {
+			t.Errorf("Expected only the void discriminant, got %x", enc.Bytes())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := xdr.Marshal(&ExtAccountExt{V: 1}); err == nil {
+			t.Error("Expected error for nil V1 payload")
+		}
+		if _, err := xdr.Marshal(&ExtAccountExt{V: 3}); !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+			t.Errorf("Expected ErrUnknownDiscriminant encoding version 3, got %v", err)
+		}
+		var decoded ExtAccountExt
+		if err := xdr.Unmarshal([]byte{0, 0, 0, 3}, &decoded); !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+			t.Errorf("Expected ErrUnknownDiscriminant decoding version 3, got %v", err)
+		}
+	})
+}
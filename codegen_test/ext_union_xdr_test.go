@@ -0,0 +1,196 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: ext_union_test.go
+// Generated 4 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *ExtAccountV1) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Liabilities); err != nil {
+		return fmt.Errorf("failed to encode Liabilities: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ExtAccountV1) Decode(dec *xdr.Decoder) error {
+
+	tempLiabilities, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Liabilities: %w", err)
+	}
+	v.Liabilities = tempLiabilities
+
+	return nil
+}
+
+var _ xdr.Codec = (*ExtAccountV1)(nil)
+
+func (v *ExtAccountV2) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Liabilities); err != nil {
+		return fmt.Errorf("failed to encode Liabilities: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Sponsors); err != nil {
+		return fmt.Errorf("failed to encode Sponsors: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ExtAccountV2) Decode(dec *xdr.Decoder) error {
+
+	tempLiabilities, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Liabilities: %w", err)
+	}
+	v.Liabilities = tempLiabilities
+
+	tempSponsors, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Sponsors: %w", err)
+	}
+	v.Sponsors = tempSponsors
+
+	return nil
+}
+
+var _ xdr.Codec = (*ExtAccountV2)(nil)
+
+func (v *ExtAccount) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.ID); err != nil {
+		return fmt.Errorf("failed to encode ID: %w", err)
+	}
+
+	if err := enc.EncodeInt64(v.Balance); err != nil {
+		return fmt.Errorf("failed to encode Balance: %w", err)
+	}
+
+	if err := v.Ext.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode Ext: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ExtAccount) Decode(dec *xdr.Decoder) error {
+
+	tempID, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode ID: %w", err)
+	}
+	v.ID = tempID
+
+	tempBalance, err := dec.DecodeInt64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Balance: %w", err)
+	}
+	v.Balance = tempBalance
+
+	if err := v.Ext.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode Ext: %w", err)
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ExtAccount)(nil)
+
+// ExtAccountExtMaxVersion is the highest ExtAccountExt version this code understands
+const ExtAccountExtMaxVersion int32 = 2
+
+// ExtAccountExt is a version extension union: version 0 carries no data and version N carries VN
+type ExtAccountExt struct {
+	V  int32
+	V1 *ExtAccountV1
+	V2 *ExtAccountV2
+}
+
+func (v *ExtAccountExt) Encode(enc *xdr.Encoder) error {
+	if err := enc.EncodeInt32(v.V); err != nil {
+		return fmt.Errorf("failed to encode V: %w", err)
+	}
+	switch v.V {
+	case 0:
+	case 1:
+		if v.V1 == nil {
+			return fmt.Errorf("pointer field V1 is nil")
+		}
+		if err := v.V1.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode V1: %w", err)
+		}
+	case 2:
+		if v.V2 == nil {
+			return fmt.Errorf("pointer field V2 is nil")
+		}
+		if err := v.V2.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode V2: %w", err)
+		}
+	default:
+		return fmt.Errorf("%w: ExtAccountExt version %d, max 2", xdr.ErrUnknownDiscriminant, v.V)
+	}
+	return nil
+}
+
+func (v *ExtAccountExt) Decode(dec *xdr.Decoder) error {
+	version, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode V: %w", err)
+	}
+	*v = ExtAccountExt{V: version}
+	switch version {
+	case 0:
+	case 1:
+		v.V1 = &ExtAccountV1{}
+		if err := v.V1.Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode V1: %w", err)
+		}
+	case 2:
+		v.V2 = &ExtAccountV2{}
+		if err := v.V2.Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode V2: %w", err)
+		}
+	default:
+		return fmt.Errorf("%w: ExtAccountExt version %d, max 2", xdr.ErrUnknownDiscriminant, version)
+	}
+	return nil
+}
+
+// Latest returns the highest version of ExtAccountExt with its payload set, or 0 when none is
+func (v *ExtAccountExt) Latest() int32 {
+	if v.V2 != nil {
+		return 2
+	}
+	if v.V1 != nil {
+		return 1
+	}
+	return 0
+}
+
+// EncodeVersion encodes ExtAccountExt at the highest version with its payload set that is no newer
+// than version, so a peer that understands up to version can decode it
+func (v *ExtAccountExt) EncodeVersion(enc *xdr.Encoder, version int32) error {
+	if version < 0 {
+		return fmt.Errorf("%w: ExtAccountExt version %d", xdr.ErrUnknownDiscriminant, version)
+	}
+	ext := *v
+	ext.V = 0
+	if ext.V == 0 && version >= 2 && v.V2 != nil {
+		ext.V = 2
+	}
+	if ext.V == 0 && version >= 1 && v.V1 != nil {
+		ext.V = 1
+	}
+	return ext.Encode(enc)
+}
+
+var _ xdr.Codec = (*ExtAccountExt)(nil)
@@ -0,0 +1,150 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestGenericStructs tests generic containers over a type parameter T xdr.Codec, instantiated
+// as GenPage[*GenEntry], and over T any with its pointer PT interface{ *T; xdr.Codec }.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// +xdr:generate
+type GenEntry struct {
+	Name string
+	Size uint64
+}
+
+// GenPage is a reusable paged result
+// +xdr:generate
+type GenPage[T xdr.Codec] struct {
+	Items  []T
+	Cookie uint64
+}
+
+// GenBox holds a single codec and a fixed pair of them
+// +xdr:generate
+type GenBox[T xdr.Codec] struct {
+	Head T
+	Pair [2]T
+}
+
+// GenPair holds two codecs of different types, allocated as PK(new(K)) without reflection
+// +xdr:generate
+type GenPair[K any, PK interface {
+	*K
+	xdr.Codec
+}, V any, PV interface {
+	*V
+	xdr.Codec
+}] struct {
+	Key   PK
+	Value PV
+	Spare [2]PV
+}
+
+// +xdr:generate
+type GenListing struct {
+	Dir  string
+	Page GenPage[*GenEntry]
+}
+
+func TestGenericStructs(t *testing.T) {
+	page := &GenPage[*GenEntry]{
+		Items:  []*GenEntry{{Name: "a", Size: 1}, {Name: "bb", Size: 2}},
+		Cookie: 42,
+	}
+
+	t.Run("page", func(t *testing.T) {
+		data, err := xdr.Marshal(page)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded GenPage[*GenEntry]
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if len(decoded.Items) != 2 || *decoded.Items[0] != *page.Items[0] || *decoded.Items[1] != *page.Items[1] || decoded.Cookie != 42 {
+			t.Errorf("Expected %+v, got %+v", page, decoded)
+		}
+	})
+
+	t.Run("same wire format as a slice of entries", func(t *testing.T) {
+		data, err := xdr.Marshal(page)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		enc := xdr.NewEncoder(make([]byte, 256))
+		if err := enc.EncodeUint32(2); err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range page.Items {
+			if err := item.Encode(enc); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.EncodeUint64(42); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, enc.Bytes()) {
+			t.Errorf("Expected %x, got %x", enc.Bytes(), data)
+		}
+	})
+
+	t.Run("multiple type parameters", func(t *testing.T) {
+		pair := &GenPair[GenEntry, *GenEntry, GenListing, *GenListing]{
+			Key:   &GenEntry{Name: "k"},
+			Value: &GenListing{Dir: "/", Page: *page},
+			Spare: [2]*GenListing{{Dir: "x"}, {Dir: "y"}},
+		}
+		data, err := xdr.Marshal(pair)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded GenPair[GenEntry, *GenEntry, GenListing, *GenListing]
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Key.Name != "k" || decoded.Value.Dir != "/" || len(decoded.Value.Page.Items) != 2 || decoded.Spare[1].Dir != "y" {
+			t.Errorf("Expected %+v, got %+v", pair, decoded)
+		}
+	})
+
+	t.Run("codec field and fixed array", func(t *testing.T) {
+		box := &GenBox[*GenEntry]{
+			Head: &GenEntry{Name: "head"},
+			Pair: [2]*GenEntry{{Name: "x", Size: 1}, {Name: "y", Size: 2}},
+		}
+		data, err := xdr.Marshal(box)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded GenBox[*GenEntry]
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Head.Name != "head" || *decoded.Pair[1] != *box.Pair[1] {
+			t.Errorf("Expected %+v, got %+v", box, decoded)
+		}
+	})
+
+	t.Run("nested instantiation", func(t *testing.T) {
+		listing := &GenListing{Dir: "/export", Page: *page}
+		data, err := xdr.Marshal(listing)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded GenListing
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Dir != "/export" || len(decoded.Page.Items) != 2 || decoded.Page.Items[1].Name != "bb" {
+			t.Errorf("Expected %+v, got %+v", listing, decoded)
+		}
+	})
+}
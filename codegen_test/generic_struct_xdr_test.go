@@ -0,0 +1,228 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: generic_struct_test.go
+// Generated 5 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *GenEntry) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Name); err != nil {
+		return fmt.Errorf("failed to encode Name: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Size); err != nil {
+		return fmt.Errorf("failed to encode Size: %w", err)
+	}
+
+	return nil
+}
+
+func (v *GenEntry) Decode(dec *xdr.Decoder) error {
+
+	tempName, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	v.Name = tempName
+
+	tempSize, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Size: %w", err)
+	}
+	v.Size = tempSize
+
+	return nil
+}
+
+var _ xdr.Codec = (*GenEntry)(nil)
+
+func (v *GenPage[T]) Encode(enc *xdr.Encoder) error {
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Items))); err != nil {
+		return fmt.Errorf("failed to encode Items length: %w", err)
+	}
+	for _, elem := range v.Items {
+
+		if err := elem.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	if err := enc.EncodeUint64(v.Cookie); err != nil {
+		return fmt.Errorf("failed to encode Cookie: %w", err)
+	}
+
+	return nil
+}
+
+func (v *GenPage[T]) Decode(dec *xdr.Decoder) error {
+
+	ItemsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Items length: %w", err)
+	}
+	v.Items = make([]T, ItemsLen)
+	for i := range v.Items {
+
+		// Codec type parameters are allocated with xdr.NewCodec
+		v.Items[i] = xdr.NewCodec[T]()
+
+		if err := v.Items[i].Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+
+	}
+
+	tempCookie, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Cookie: %w", err)
+	}
+	v.Cookie = tempCookie
+
+	return nil
+}
+func _[T xdr.Codec]() {
+	var _ xdr.Codec = (*GenPage[T])(nil)
+}
+
+func (v *GenBox[T]) Encode(enc *xdr.Encoder) error {
+
+	if err := v.Head.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode Head: %w", err)
+	}
+
+	for _, elem := range v.Pair {
+
+		// Element type T - delegate to element's Encode method
+		if err := elem.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *GenBox[T]) Decode(dec *xdr.Decoder) error {
+
+	// Codec type parameters are allocated with xdr.NewCodec
+	v.Head = xdr.NewCodec[T]()
+
+	if err := v.Head.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode Head: %w", err)
+	}
+
+	for i := range v.Pair {
+
+		// Codec type parameters are allocated with xdr.NewCodec
+		v.Pair[i] = xdr.NewCodec[T]()
+		if err := v.Pair[i].Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+func _[T xdr.Codec]() {
+	var _ xdr.Codec = (*GenBox[T])(nil)
+}
+
+func (v *GenPair[K, PK, V, PV]) Encode(enc *xdr.Encoder) error {
+
+	if err := v.Key.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode Key: %w", err)
+	}
+
+	if err := v.Value.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	for _, elem := range v.Spare {
+
+		// Element type PV - delegate to element's Encode method
+		if err := elem.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *GenPair[K, PK, V, PV]) Decode(dec *xdr.Decoder) error {
+
+	// Pointer type parameters are allocated as PT(new(T))
+	v.Key = PK(new(K))
+
+	if err := v.Key.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode Key: %w", err)
+	}
+
+	// Pointer type parameters are allocated as PT(new(T))
+	v.Value = PV(new(V))
+
+	if err := v.Value.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+
+	for i := range v.Spare {
+
+		// Pointer type parameters are allocated as PT(new(T))
+		v.Spare[i] = PV(new(V))
+		if err := v.Spare[i].Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+func _[K any, PK interface {
+	*K
+	xdr.Codec
+}, V any, PV interface {
+	*V
+	xdr.Codec
+}]() {
+	var _ xdr.Codec = (*GenPair[K, PK, V, PV])(nil)
+}
+
+func (v *GenListing) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Dir); err != nil {
+		return fmt.Errorf("failed to encode Dir: %w", err)
+	}
+
+	if err := v.Page.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode Page: %w", err)
+	}
+
+	return nil
+}
+
+func (v *GenListing) Decode(dec *xdr.Decoder) error {
+
+	tempDir, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Dir: %w", err)
+	}
+	v.Dir = tempDir
+
+	if err := v.Page.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode Page: %w", err)
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*GenListing)(nil)
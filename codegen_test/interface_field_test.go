@@ -0,0 +1,109 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestInterfaceFields tests interface-typed fields encoded as {type id, opaque body}
+// through the registry generated from +xdr:register directives.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// IfacePlugin is implemented by every plugin a message can carry
+type IfacePlugin interface {
+	xdr.Codec
+	Name() string
+}
+
+const IfaceRenameID uint32 = 2
+
+// +xdr:register,interface=IfacePlugin,type=IfaceGzip,id=1
+// +xdr:register,interface=IfacePlugin,type=IfaceRename,id=IfaceRenameID
+
+// +xdr:generate
+type IfaceGzip struct {
+	Level uint32
+}
+
+func (*IfaceGzip) Name() string { return "gzip" }
+
+// +xdr:generate
+type IfaceRename struct {
+	From string
+	To   string
+}
+
+func (*IfaceRename) Name() string { return "rename" }
+
+// IfaceUnregistered implements IfacePlugin without being registered
+type IfaceUnregistered struct {
+	IfaceGzip
+}
+
+// +xdr:generate
+type IfaceMessage struct {
+	ID     uint32
+	Plugin IfacePlugin
+}
+
+func TestInterfaceFields(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, plugin := range []IfacePlugin{&IfaceGzip{Level: 9}, &IfaceRename{From: "a", To: "b"}} {
+			data, err := xdr.Marshal(&IfaceMessage{ID: 1, Plugin: plugin})
+			if err != nil {
+				t.Fatalf("Marshal() failed: %v", err)
+			}
+			var decoded IfaceMessage
+			if err := xdr.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal() failed: %v", err)
+			}
+			if decoded.Plugin == nil || decoded.Plugin.Name() != plugin.Name() {
+				t.Fatalf("Expected %s plugin, got %#v", plugin.Name(), decoded.Plugin)
+			}
+			body, _ := xdr.Marshal(plugin)
+			got, _ := xdr.Marshal(decoded.Plugin)
+			if !bytes.Equal(body, got) {
+				t.Errorf("Expected plugin %x, got %x", body, got)
+			}
+		}
+	})
+
+	t.Run("wire format", func(t *testing.T) {
+		data, err := xdr.Marshal(&IfaceMessage{ID: 7, Plugin: &IfaceGzip{Level: 3}})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		want := []byte{
+			0, 0, 0, 7,
+			0, 0, 0, 1,
+			0, 0, 0, 4,
+			0, 0, 0, 3,
+		}
+		if !bytes.Equal(data, want) {
+			t.Errorf("Expected %x, got %x", want, data)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := xdr.Marshal(&IfaceMessage{}); !errors.Is(err, xdr.ErrInvalidData) {
+			t.Errorf("Expected ErrInvalidData for nil interface field, got %v", err)
+		}
+		if _, err := xdr.Marshal(&IfaceMessage{Plugin: (*IfaceGzip)(nil)}); !errors.Is(err, xdr.ErrInvalidData) {
+			t.Errorf("Expected ErrInvalidData for typed nil interface field, got %v", err)
+		}
+		if _, err := xdr.Marshal(&IfaceMessage{Plugin: &IfaceUnregistered{}}); !errors.Is(err, xdr.ErrUnregisteredType) {
+			t.Errorf("Expected ErrUnregisteredType encoding an unregistered type, got %v", err)
+		}
+		var decoded IfaceMessage
+		data := []byte{0, 0, 0, 7, 0, 0, 0, 9, 0, 0, 0, 0}
+		if err := xdr.Unmarshal(data, &decoded); !errors.Is(err, xdr.ErrUnregisteredType) {
+			t.Errorf("Expected ErrUnregisteredType decoding type ID 9, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: interface_field_test.go
+// Generated 3 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *IfaceGzip) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Level); err != nil {
+		return fmt.Errorf("failed to encode Level: %w", err)
+	}
+
+	return nil
+}
+
+func (v *IfaceGzip) Decode(dec *xdr.Decoder) error {
+
+	tempLevel, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Level: %w", err)
+	}
+	v.Level = tempLevel
+
+	return nil
+}
+
+var _ xdr.Codec = (*IfaceGzip)(nil)
+
+func (v *IfaceRename) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.From); err != nil {
+		return fmt.Errorf("failed to encode From: %w", err)
+	}
+
+	if err := enc.EncodeString(v.To); err != nil {
+		return fmt.Errorf("failed to encode To: %w", err)
+	}
+
+	return nil
+}
+
+func (v *IfaceRename) Decode(dec *xdr.Decoder) error {
+
+	tempFrom, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode From: %w", err)
+	}
+	v.From = tempFrom
+
+	tempTo, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode To: %w", err)
+	}
+	v.To = tempTo
+
+	return nil
+}
+
+var _ xdr.Codec = (*IfaceRename)(nil)
+
+func (v *IfaceMessage) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.ID); err != nil {
+		return fmt.Errorf("failed to encode ID: %w", err)
+	}
+
+	if v.Plugin == nil {
+		return fmt.Errorf("interface field Plugin is nil: %w", xdr.ErrInvalidData)
+	}
+	if err := IfacePluginRegistry.Encode(enc, v.Plugin); err != nil {
+		return fmt.Errorf("failed to encode Plugin: %w", err)
+	}
+
+	return nil
+}
+
+func (v *IfaceMessage) Decode(dec *xdr.Decoder) error {
+
+	tempID, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode ID: %w", err)
+	}
+	v.ID = tempID
+
+	tempPlugin, err := xdr.DecodeInterface[IfacePlugin](dec, IfacePluginRegistry)
+	if err != nil {
+		return fmt.Errorf("failed to decode Plugin: %w", err)
+	}
+	v.Plugin = tempPlugin
+
+	return nil
+}
+
+var _ xdr.Codec = (*IfaceMessage)(nil)
+
+// IfacePluginRegistry holds the IfacePlugin implementations registered with +xdr:register
+var IfacePluginRegistry = xdr.NewTypeRegistry()
+
+func init() {
+	IfacePluginRegistry.MustRegister(1, func() xdr.Codec { return &IfaceGzip{} })
+	IfacePluginRegistry.MustRegister(uint32(IfaceRenameID), func() xdr.Codec { return &IfaceRename{} })
+}
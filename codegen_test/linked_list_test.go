@@ -0,0 +1,147 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestLinkedLists tests XDR optional-data linked lists (entry3 *nextentry in NFS
+// READDIR) encoded iteratively, both as pointer chains and as Go slices.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// ListEntry mirrors NFSv3 entry3
+// +xdr:generate
+type ListEntry struct {
+	FileID uint64
+	Name   string
+	Cookie uint64
+	Next   *ListEntry `xdr:"list"`
+}
+
+// ListDirHead mirrors NFSv3 dirlist3 with a head pointer
+// +xdr:generate
+type ListDirHead struct {
+	Entries *ListEntry `xdr:"list"`
+	EOF     bool
+}
+
+// ListItem is an entry3 without the next pointer, for slice-backed lists
+// +xdr:generate
+type ListItem struct {
+	FileID uint64
+	Name   string
+	Cookie uint64
+}
+
+// ListDirSlice is dirlist3 with the chain mapped to a slice
+// +xdr:generate
+type ListDirSlice struct {
+	Entries []ListItem `xdr:"list"`
+	EOF     bool
+}
+
+func TestLinkedLists(t *testing.T) {
+	items := make([]ListItem, 3)
+	var head *ListEntry
+	for i := len(items) - 1; i >= 0; i-- {
+		items[i] = ListItem{FileID: uint64(i + 1), Name: fmt.Sprintf("file%d", i), Cookie: uint64(i * 10)}
+		head = &ListEntry{FileID: items[i].FileID, Name: items[i].Name, Cookie: items[i].Cookie, Next: head}
+	}
+
+	t.Run("chain and slice share the wire format", func(t *testing.T) {
+		chain, err := xdr.Marshal(&ListDirHead{Entries: head, EOF: true})
+		if err != nil {
+			t.Fatalf("Marshal() chain failed: %v", err)
+		}
+		slice, err := xdr.Marshal(&ListDirSlice{Entries: items, EOF: true})
+		if err != nil {
+			t.Fatalf("Marshal() slice failed: %v", err)
+		}
+		if !bytes.Equal(chain, slice) {
+			t.Errorf("Expected identical encodings:\nchain %x\nslice %x", chain, slice)
+		}
+
+		var decoded ListDirSlice
+		if err := xdr.Unmarshal(chain, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if len(decoded.Entries) != 3 || decoded.Entries[2].Name != "file2" || !decoded.EOF {
+			t.Errorf("Expected three entries and EOF, got %+v", decoded)
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		data, err := xdr.Marshal(&ListDirHead{EOF: true})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if len(data) != 8 {
+			t.Errorf("Expected FALSE and EOF (8 bytes), got %d", len(data))
+		}
+		var decoded ListDirSlice
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if len(decoded.Entries) != 0 {
+			t.Errorf("Expected no entries, got %d", len(decoded.Entries))
+		}
+	})
+
+	t.Run("long chains do not recurse", func(t *testing.T) {
+		const count = 100000
+		var long *ListEntry
+		for i := count; i > 0; i-- {
+			long = &ListEntry{FileID: uint64(i), Name: "f", Next: long}
+		}
+
+		enc := xdr.NewEncoder(make([]byte, count*32+16))
+		if err := (&ListDirHead{Entries: long, EOF: true}).Encode(enc); err != nil {
+			t.Fatalf("Encode() failed: %v", err)
+		}
+
+		var decoded ListDirHead
+		if err := xdr.Unmarshal(enc.Bytes(), &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		n := 0
+		for e := decoded.Entries; e != nil; e = e.Next {
+			n++
+			if e.FileID != uint64(n) {
+				t.Fatalf("Expected FileID %d, got %d", n, e.FileID)
+			}
+		}
+		if n != count || !decoded.EOF {
+			t.Errorf("Expected %d entries and EOF, got %d", count, n)
+		}
+	})
+
+	t.Run("cyclic chain", func(t *testing.T) {
+		cyclic := &ListEntry{FileID: 1, Name: "a", Next: &ListEntry{FileID: 2, Name: "b"}}
+		cyclic.Next.Next = cyclic
+
+		enc := xdr.NewEncoder(make([]byte, 1024))
+		enc.SetCycleCheck(true)
+		err := (&ListDirHead{Entries: cyclic}).Encode(enc)
+		if !errors.Is(err, xdr.ErrEncodingLoop) {
+			t.Errorf("Expected ErrEncodingLoop, got %v", err)
+		}
+	})
+
+	t.Run("truncated chain", func(t *testing.T) {
+		data, err := xdr.Marshal(&ListDirHead{Entries: head, EOF: true})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded ListDirHead
+		if err := xdr.Unmarshal(data[:len(data)-12], &decoded); err == nil {
+			t.Error("Expected error for truncated chain")
+		}
+	})
+}
@@ -0,0 +1,221 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: linked_list_test.go
+// Generated 4 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *ListEntry) Encode(enc *xdr.Encoder) error {
+
+	// Walk the Next chain iteratively; v is rebound to each node in turn
+	var seen map[*ListEntry]struct{}
+	if enc.CycleCheck() {
+		seen = make(map[*ListEntry]struct{})
+	}
+	for v := v; v != nil; v = v.Next {
+		if seen != nil {
+			if _, ok := seen[v]; ok {
+				return fmt.Errorf("failed to encode ListEntry: %w", xdr.ErrEncodingLoop)
+			}
+			seen[v] = struct{}{}
+		}
+
+		if err := enc.EncodeUint64(v.FileID); err != nil {
+			return fmt.Errorf("failed to encode FileID: %w", err)
+		}
+
+		if err := enc.EncodeString(v.Name); err != nil {
+			return fmt.Errorf("failed to encode Name: %w", err)
+		}
+
+		if err := enc.EncodeUint64(v.Cookie); err != nil {
+			return fmt.Errorf("failed to encode Cookie: %w", err)
+		}
+
+		if err := enc.EncodeBool(v.Next != nil); err != nil {
+			return fmt.Errorf("failed to encode Next: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (v *ListEntry) Decode(dec *xdr.Decoder) error {
+
+	// Decode the Next chain iteratively; v is rebound to each node in turn
+	for v := v; v != nil; v = v.Next {
+
+		tempFileID, err := dec.DecodeUint64()
+		if err != nil {
+			return fmt.Errorf("failed to decode FileID: %w", err)
+		}
+		v.FileID = tempFileID
+
+		tempName, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Name: %w", err)
+		}
+		v.Name = tempName
+
+		tempCookie, err := dec.DecodeUint64()
+		if err != nil {
+			return fmt.Errorf("failed to decode Cookie: %w", err)
+		}
+		v.Cookie = tempCookie
+
+		more, err := dec.DecodeBool()
+		if err != nil {
+			return fmt.Errorf("failed to decode Next: %w", err)
+		}
+		v.Next = nil
+		if more {
+			v.Next = &ListEntry{}
+		}
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ListEntry)(nil)
+
+func (v *ListDirHead) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeBool(v.Entries != nil); err != nil {
+		return fmt.Errorf("failed to encode Entries: %w", err)
+	}
+	if v.Entries != nil {
+		if err := v.Entries.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode Entries: %w", err)
+		}
+	}
+
+	if err := enc.EncodeBool(v.EOF); err != nil {
+		return fmt.Errorf("failed to encode EOF: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ListDirHead) Decode(dec *xdr.Decoder) error {
+
+	EntriesPresent, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode Entries: %w", err)
+	}
+	v.Entries = nil
+	if EntriesPresent {
+		v.Entries = &ListEntry{}
+		if err := v.Entries.Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode Entries: %w", err)
+		}
+	}
+
+	tempEOF, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode EOF: %w", err)
+	}
+	v.EOF = tempEOF
+
+	return nil
+}
+
+var _ xdr.Codec = (*ListDirHead)(nil)
+
+func (v *ListItem) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.FileID); err != nil {
+		return fmt.Errorf("failed to encode FileID: %w", err)
+	}
+
+	if err := enc.EncodeString(v.Name); err != nil {
+		return fmt.Errorf("failed to encode Name: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Cookie); err != nil {
+		return fmt.Errorf("failed to encode Cookie: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ListItem) Decode(dec *xdr.Decoder) error {
+
+	tempFileID, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode FileID: %w", err)
+	}
+	v.FileID = tempFileID
+
+	tempName, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	v.Name = tempName
+
+	tempCookie, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Cookie: %w", err)
+	}
+	v.Cookie = tempCookie
+
+	return nil
+}
+
+var _ xdr.Codec = (*ListItem)(nil)
+
+func (v *ListDirSlice) Encode(enc *xdr.Encoder) error {
+
+	// Each element is preceded by TRUE and the list is terminated by FALSE
+	for _, elem := range v.Entries {
+		if err := enc.EncodeBool(true); err != nil {
+			return fmt.Errorf("failed to encode Entries: %w", err)
+		}
+		if err := elem.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+	}
+	if err := enc.EncodeBool(false); err != nil {
+		return fmt.Errorf("failed to encode Entries: %w", err)
+	}
+
+	if err := enc.EncodeBool(v.EOF); err != nil {
+		return fmt.Errorf("failed to encode EOF: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ListDirSlice) Decode(dec *xdr.Decoder) error {
+
+	v.Entries = nil
+	for {
+		more, err := dec.DecodeBool()
+		if err != nil {
+			return fmt.Errorf("failed to decode Entries: %w", err)
+		}
+		if !more {
+			break
+		}
+		var elem ListItem
+		if err := elem.Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+		v.Entries = append(v.Entries, elem)
+	}
+
+	tempEOF, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode EOF: %w", err)
+	}
+	v.EOF = tempEOF
+
+	return nil
+}
+
+var _ xdr.Codec = (*ListDirSlice)(nil)
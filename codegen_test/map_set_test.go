@@ -0,0 +1,137 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestMapsAndSets tests map fields encoded as counted arrays of key/value pairs
+// sorted by key, and map[K]struct{} fields encoded as sorted sets.
+
+//go:generate ../bin/xdrgen $GOFILE
+type MapLabel string
+
+type MapScore int32
+
+// +xdr:generate
+type MapAttr struct {
+	Mode uint32
+	Size uint64
+}
+
+// +xdr:generate
+type MapRecord struct {
+	Labels  map[string]string
+	Counts  map[uint32]uint64
+	Scores  map[MapLabel]MapScore
+	Attrs   map[string]MapAttr
+	Handles map[int64]*MapAttr
+	Blobs   map[uint32][]byte
+	Members map[string]struct{}
+}
+
+// +xdr:generate
+type MapSet struct {
+	IDs map[int32]struct{}
+}
+
+func TestMapsAndSets(t *testing.T) {
+	record := &MapRecord{
+		Labels:  map[string]string{"zone": "eu", "app": "nfs", "tier": "gold"},
+		Counts:  map[uint32]uint64{3: 30, 1: 10, 2: 20},
+		Scores:  map[MapLabel]MapScore{"low": -1, "high": 9},
+		Attrs:   map[string]MapAttr{"a": {Mode: 0o644, Size: 1}, "b": {Mode: 0o755, Size: 2}},
+		Handles: map[int64]*MapAttr{-7: {Mode: 1}, 7: {Mode: 2}},
+		Blobs:   map[uint32][]byte{9: []byte("nine"), 4: []byte("four")},
+		Members: map[string]struct{}{"carol": {}, "alice": {}, "bob": {}},
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := xdr.Marshal(record)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded MapRecord
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if !reflect.DeepEqual(record, &decoded) {
+			t.Errorf("Round trip mismatch:\nwant %+v\ngot  %+v", record, &decoded)
+		}
+	})
+
+	t.Run("deterministic encoding", func(t *testing.T) {
+		first, err := xdr.Marshal(record)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		for i := 0; i < 20; i++ {
+			again, err := xdr.Marshal(record)
+			if err != nil {
+				t.Fatalf("Marshal() failed: %v", err)
+			}
+			if !bytes.Equal(first, again) {
+				t.Fatalf("Expected identical encodings on iteration %d", i)
+			}
+		}
+	})
+
+	t.Run("set is sorted by key", func(t *testing.T) {
+		data, err := xdr.Marshal(&MapSet{IDs: map[int32]struct{}{5: {}, -2: {}, 0: {}}})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		want := []byte{
+			0, 0, 0, 3,
+			0xff, 0xff, 0xff, 0xfe,
+			0, 0, 0, 0,
+			0, 0, 0, 5,
+		}
+		if !bytes.Equal(data, want) {
+			t.Errorf("Expected %x, got %x", want, data)
+		}
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		data := []byte{
+			0, 0, 0, 2,
+			0, 0, 0, 1,
+			0, 0, 0, 1,
+		}
+		var set MapSet
+		if err := xdr.Unmarshal(data, &set); !errors.Is(err, xdr.ErrDuplicateKey) {
+			t.Errorf("Expected ErrDuplicateKey, got %v", err)
+		}
+	})
+
+	t.Run("hostile count", func(t *testing.T) {
+		data := []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 1}
+		var set MapSet
+		if err := xdr.Unmarshal(data, &set); !errors.Is(err, xdr.ErrUnexpectedEOF) {
+			t.Errorf("Expected ErrUnexpectedEOF, got %v", err)
+		}
+	})
+
+	t.Run("nil struct pointer value", func(t *testing.T) {
+		_, err := xdr.Marshal(&MapRecord{Handles: map[int64]*MapAttr{1: {Mode: 1}, 2: nil}})
+		if !errors.Is(err, xdr.ErrInvalidData) {
+			t.Errorf("Expected ErrInvalidData, got %v", err)
+		}
+	})
+
+	t.Run("nil map encodes as empty", func(t *testing.T) {
+		data, err := xdr.Marshal(&MapSet{})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if len(data) != 4 {
+			t.Errorf("Expected count only (4 bytes), got %d", len(data))
+		}
+	})
+}
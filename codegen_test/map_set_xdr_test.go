@@ -0,0 +1,393 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: map_set_test.go
+// Generated 3 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *MapAttr) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Mode); err != nil {
+		return fmt.Errorf("failed to encode Mode: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Size); err != nil {
+		return fmt.Errorf("failed to encode Size: %w", err)
+	}
+
+	return nil
+}
+
+func (v *MapAttr) Decode(dec *xdr.Decoder) error {
+
+	tempMode, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Mode: %w", err)
+	}
+	v.Mode = tempMode
+
+	tempSize, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Size: %w", err)
+	}
+	v.Size = tempSize
+
+	return nil
+}
+
+var _ xdr.Codec = (*MapAttr)(nil)
+
+func (v *MapRecord) Encode(enc *xdr.Encoder) error {
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Labels))); err != nil {
+		return fmt.Errorf("failed to encode Labels length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Labels) {
+		if err := enc.EncodeString(key); err != nil {
+			return fmt.Errorf("failed to encode Labels key: %w", err)
+		}
+
+		if err := enc.EncodeString(v.Labels[key]); err != nil {
+			return fmt.Errorf("failed to encode Labels value: %w", err)
+		}
+
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Counts))); err != nil {
+		return fmt.Errorf("failed to encode Counts length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Counts) {
+		if err := enc.EncodeUint32(key); err != nil {
+			return fmt.Errorf("failed to encode Counts key: %w", err)
+		}
+
+		if err := enc.EncodeUint64(v.Counts[key]); err != nil {
+			return fmt.Errorf("failed to encode Counts value: %w", err)
+		}
+
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Scores))); err != nil {
+		return fmt.Errorf("failed to encode Scores length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Scores) {
+		if err := enc.EncodeString(string(key)); err != nil {
+			return fmt.Errorf("failed to encode Scores key: %w", err)
+		}
+
+		if err := enc.EncodeInt32(int32(v.Scores[key])); err != nil {
+			return fmt.Errorf("failed to encode Scores value: %w", err)
+		}
+
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Attrs))); err != nil {
+		return fmt.Errorf("failed to encode Attrs length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Attrs) {
+		if err := enc.EncodeString(key); err != nil {
+			return fmt.Errorf("failed to encode Attrs key: %w", err)
+		}
+
+		elem := v.Attrs[key]
+		if err := elem.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode Attrs value: %w", err)
+		}
+
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Handles))); err != nil {
+		return fmt.Errorf("failed to encode Handles length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Handles) {
+		if err := enc.EncodeInt64(key); err != nil {
+			return fmt.Errorf("failed to encode Handles key: %w", err)
+		}
+
+		elem := v.Handles[key]
+		if elem == nil {
+			return fmt.Errorf("failed to encode Handles: nil value for key %v: %w", key, xdr.ErrInvalidData)
+		}
+		if err := elem.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode Handles value: %w", err)
+		}
+
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Blobs))); err != nil {
+		return fmt.Errorf("failed to encode Blobs length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Blobs) {
+		if err := enc.EncodeUint32(key); err != nil {
+			return fmt.Errorf("failed to encode Blobs key: %w", err)
+		}
+
+		if err := enc.EncodeBytes(v.Blobs[key]); err != nil {
+			return fmt.Errorf("failed to encode Blobs value: %w", err)
+		}
+
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Members))); err != nil {
+		return fmt.Errorf("failed to encode Members length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Members) {
+		if err := enc.EncodeString(key); err != nil {
+			return fmt.Errorf("failed to encode Members key: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *MapRecord) Decode(dec *xdr.Decoder) error {
+
+	LabelsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Labels length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(LabelsLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Labels: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Labels = make(map[string]string, LabelsLen)
+	for range LabelsLen {
+		key, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Labels key: %w", err)
+		}
+		if _, exists := v.Labels[key]; exists {
+			return fmt.Errorf("failed to decode Labels key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		value, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Labels value: %w", err)
+		}
+		v.Labels[key] = value
+
+	}
+
+	CountsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Counts length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(CountsLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Counts: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Counts = make(map[uint32]uint64, CountsLen)
+	for range CountsLen {
+		key, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Counts key: %w", err)
+		}
+		if _, exists := v.Counts[key]; exists {
+			return fmt.Errorf("failed to decode Counts key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		value, err := dec.DecodeUint64()
+		if err != nil {
+			return fmt.Errorf("failed to decode Counts value: %w", err)
+		}
+		v.Counts[key] = value
+
+	}
+
+	ScoresLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Scores length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(ScoresLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Scores: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Scores = make(map[MapLabel]MapScore, ScoresLen)
+	for range ScoresLen {
+		key, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Scores key: %w", err)
+		}
+		if _, exists := v.Scores[MapLabel(key)]; exists {
+			return fmt.Errorf("failed to decode Scores key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		value, err := dec.DecodeInt32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Scores value: %w", err)
+		}
+		v.Scores[MapLabel(key)] = MapScore(value)
+
+	}
+
+	AttrsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Attrs length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(AttrsLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Attrs: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Attrs = make(map[string]MapAttr, AttrsLen)
+	for range AttrsLen {
+		key, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Attrs key: %w", err)
+		}
+		if _, exists := v.Attrs[key]; exists {
+			return fmt.Errorf("failed to decode Attrs key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		var elem MapAttr
+		if err := elem.Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode Attrs value: %w", err)
+		}
+		v.Attrs[key] = elem
+
+	}
+
+	HandlesLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Handles length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(HandlesLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Handles: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Handles = make(map[int64]*MapAttr, HandlesLen)
+	for range HandlesLen {
+		key, err := dec.DecodeInt64()
+		if err != nil {
+			return fmt.Errorf("failed to decode Handles key: %w", err)
+		}
+		if _, exists := v.Handles[key]; exists {
+			return fmt.Errorf("failed to decode Handles key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		elem := &MapAttr{}
+		if err := elem.Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode Handles value: %w", err)
+		}
+		v.Handles[key] = elem
+
+	}
+
+	BlobsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Blobs length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(BlobsLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Blobs: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Blobs = make(map[uint32][]byte, BlobsLen)
+	for range BlobsLen {
+		key, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Blobs key: %w", err)
+		}
+		if _, exists := v.Blobs[key]; exists {
+			return fmt.Errorf("failed to decode Blobs key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		value, err := dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Blobs value: %w", err)
+		}
+		v.Blobs[key] = value
+
+	}
+
+	MembersLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Members length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(MembersLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Members: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Members = make(map[string]struct{}, MembersLen)
+	for range MembersLen {
+		key, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Members key: %w", err)
+		}
+		if _, exists := v.Members[key]; exists {
+			return fmt.Errorf("failed to decode Members key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		v.Members[key] = struct{}{}
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*MapRecord)(nil)
+
+func (v *MapSet) Encode(enc *xdr.Encoder) error {
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.IDs))); err != nil {
+		return fmt.Errorf("failed to encode IDs length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.IDs) {
+		if err := enc.EncodeInt32(key); err != nil {
+			return fmt.Errorf("failed to encode IDs key: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *MapSet) Decode(dec *xdr.Decoder) error {
+
+	IDsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode IDs length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(IDsLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode IDs: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.IDs = make(map[int32]struct{}, IDsLen)
+	for range IDsLen {
+		key, err := dec.DecodeInt32()
+		if err != nil {
+			return fmt.Errorf("failed to decode IDs key: %w", err)
+		}
+		if _, exists := v.IDs[key]; exists {
+			return fmt.Errorf("failed to decode IDs key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		v.IDs[key] = struct{}{}
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*MapSet)(nil)
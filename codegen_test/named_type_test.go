@@ -0,0 +1,210 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestNamedTypes tests Codec generation for named slice, array, map and primitive
+// types, marshaled directly and used as union payloads and arms.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// +xdr:generate
+type NamedEntry struct {
+	ID   uint32
+	Name string
+}
+
+// NamedEntries is a top-level list message
+// +xdr:generate
+type NamedEntries []NamedEntry
+
+// NamedGroups is a fixed set of group IDs
+// +xdr:generate
+type NamedGroups [4]uint32
+
+// NamedPath is a bare string message
+// +xdr:generate
+type NamedPath string
+
+// NamedAttrs maps attribute names to values
+// +xdr:generate
+type NamedAttrs map[string]uint64
+
+// NamedCookie is an opaque verifier
+// +xdr:generate
+type NamedCookie [8]byte
+
+type NamedStat uint32
+
+const (
+	NamedStatOK    NamedStat = 0
+	NamedStatPath  NamedStat = 1
+	NamedStatError NamedStat = 2
+)
+
+// NamedListing is the payload of a successful reply
+// +xdr:payload,union=NamedReply,discriminant=NamedStatOK
+type NamedListing []NamedEntry
+
+// +xdr:arm,union=NamedReply,discriminant=NamedStatPath,type=NamedPath
+
+// +xdr:union,key=Stat
+type NamedReply struct {
+	Stat NamedStat
+	Body []byte
+}
+
+func TestNamedTypes(t *testing.T) {
+	t.Run("slice", func(t *testing.T) {
+		entries := NamedEntries{{ID: 1, Name: "a"}, {ID: 2, Name: "bc"}}
+		data, err := xdr.Marshal(&entries)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		want := []byte{
+			0, 0, 0, 2,
+			0, 0, 0, 1, 0, 0, 0, 1, 'a', 0, 0, 0,
+			0, 0, 0, 2, 0, 0, 0, 2, 'b', 'c', 0, 0,
+		}
+		if !bytes.Equal(data, want) {
+			t.Errorf("Expected %x, got %x", want, data)
+		}
+		var decoded NamedEntries
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if len(decoded) != 2 || decoded[0] != entries[0] || decoded[1] != entries[1] {
+			t.Errorf("Expected %+v, got %+v", entries, decoded)
+		}
+	})
+
+	t.Run("fixed array", func(t *testing.T) {
+		groups := NamedGroups{10, 20, 30, 40}
+		data, err := xdr.Marshal(&groups)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if len(data) != 16 {
+			t.Errorf("Expected 16 bytes without a length prefix, got %d", len(data))
+		}
+		var decoded NamedGroups
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded != groups {
+			t.Errorf("Expected %v, got %v", groups, decoded)
+		}
+	})
+
+	t.Run("primitive", func(t *testing.T) {
+		path := NamedPath("/export")
+		data, err := xdr.Marshal(&path)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		want := []byte{0, 0, 0, 7, '/', 'e', 'x', 'p', 'o', 'r', 't', 0}
+		if !bytes.Equal(data, want) {
+			t.Errorf("Expected %x, got %x", want, data)
+		}
+		var decoded NamedPath
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded != path {
+			t.Errorf("Expected %q, got %q", path, decoded)
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		attrs := NamedAttrs{"size": 4096, "mode": 0o644}
+		data, err := xdr.Marshal(&attrs)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded NamedAttrs
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if len(decoded) != 2 || decoded["size"] != 4096 || decoded["mode"] != 0o644 {
+			t.Errorf("Expected %v, got %v", attrs, decoded)
+		}
+	})
+
+	t.Run("opaque", func(t *testing.T) {
+		cookie := NamedCookie{1, 2, 3, 4, 5, 6, 7, 8}
+		data, err := xdr.Marshal(&cookie)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if !bytes.Equal(data, cookie[:]) {
+			t.Errorf("Expected fixed opaque %x, got %x", cookie[:], data)
+		}
+		var decoded NamedCookie
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded != cookie {
+			t.Errorf("Expected %x, got %x", cookie, decoded)
+		}
+	})
+
+	t.Run("union payload", func(t *testing.T) {
+		listing := NamedListing{{ID: 7, Name: "x"}}
+		reply, err := listing.ToUnion()
+		if err != nil {
+			t.Fatalf("ToUnion() failed: %v", err)
+		}
+		data, err := xdr.Marshal(reply)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded NamedReply
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		var got NamedListing
+		if err := xdr.Unmarshal(decoded.Body, &got); err != nil {
+			t.Fatalf("Unmarshal() listing failed: %v", err)
+		}
+		if len(got) != 1 || got[0] != listing[0] {
+			t.Errorf("Expected %+v, got %+v", listing, got)
+		}
+	})
+
+	t.Run("union arm", func(t *testing.T) {
+		var reply NamedReply
+		if err := reply.SetNamedStatPath("/tmp"); err != nil {
+			t.Fatalf("SetNamedStatPath() failed: %v", err)
+		}
+		data, err := xdr.Marshal(&reply)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded NamedReply
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		path, err := decoded.NamedStatPath()
+		if err != nil || path != "/tmp" {
+			t.Errorf("Expected /tmp, got %q (%v)", path, err)
+		}
+
+		if _, err := (&NamedReply{Stat: NamedStatOK}).NamedStatPath(); !errors.Is(err, xdr.ErrArmNotSelected) {
+			t.Errorf("Expected ErrArmNotSelected, got %v", err)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		var decoded NamedGroups
+		if err := xdr.Unmarshal([]byte{0, 0, 0, 1}, &decoded); !errors.Is(err, xdr.ErrUnexpectedEOF) {
+			t.Errorf("Expected ErrUnexpectedEOF, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,508 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: named_type_test.go
+// Generated 9 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *NamedEntry) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.ID); err != nil {
+		return fmt.Errorf("failed to encode ID: %w", err)
+	}
+
+	if err := enc.EncodeString(v.Name); err != nil {
+		return fmt.Errorf("failed to encode Name: %w", err)
+	}
+
+	return nil
+}
+
+func (v *NamedEntry) Decode(dec *xdr.Decoder) error {
+
+	tempID, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode ID: %w", err)
+	}
+	v.ID = tempID
+
+	tempName, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	v.Name = tempName
+
+	return nil
+}
+
+var _ xdr.Codec = (*NamedEntry)(nil)
+
+// namedEntriesValue holds a NamedEntries as its underlying type for encoding
+type namedEntriesValue struct {
+	Value []NamedEntry
+}
+
+func (v *namedEntriesValue) Encode(enc *xdr.Encoder) error {
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Value))); err != nil {
+		return fmt.Errorf("failed to encode Value length: %w", err)
+	}
+	for _, elem := range v.Value {
+
+		if err := elem.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *namedEntriesValue) Decode(dec *xdr.Decoder) error {
+
+	ValueLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value length: %w", err)
+	}
+	v.Value = make([]NamedEntry, ValueLen)
+	for i := range v.Value {
+
+		if err := v.Value[i].Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *NamedEntries) Encode(enc *xdr.Encoder) error {
+	w := namedEntriesValue{Value: []NamedEntry(*v)}
+	return w.Encode(enc)
+}
+
+func (v *NamedEntries) Decode(dec *xdr.Decoder) error {
+	var w namedEntriesValue
+	if err := w.Decode(dec); err != nil {
+		return err
+	}
+	*v = NamedEntries(w.Value)
+	return nil
+}
+
+var _ xdr.Codec = (*NamedEntries)(nil)
+
+// namedGroupsValue holds a NamedGroups as its underlying type for encoding
+type namedGroupsValue struct {
+	Value [4]uint32
+}
+
+func (v *namedGroupsValue) Encode(enc *xdr.Encoder) error {
+
+	for _, elem := range v.Value {
+
+		if err := enc.EncodeUint32(uint32(elem)); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *namedGroupsValue) Decode(dec *xdr.Decoder) error {
+
+	for i := range v.Value {
+
+		val, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+		v.Value[i] = val
+
+	}
+
+	return nil
+}
+
+func (v *NamedGroups) Encode(enc *xdr.Encoder) error {
+	w := namedGroupsValue{Value: [4]uint32(*v)}
+	return w.Encode(enc)
+}
+
+func (v *NamedGroups) Decode(dec *xdr.Decoder) error {
+	var w namedGroupsValue
+	if err := w.Decode(dec); err != nil {
+		return err
+	}
+	*v = NamedGroups(w.Value)
+	return nil
+}
+
+var _ xdr.Codec = (*NamedGroups)(nil)
+
+// namedPathValue holds a NamedPath as its underlying type for encoding
+type namedPathValue struct {
+	Value string
+}
+
+func (v *namedPathValue) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *namedPathValue) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+func (v *NamedPath) Encode(enc *xdr.Encoder) error {
+	w := namedPathValue{Value: string(*v)}
+	return w.Encode(enc)
+}
+
+func (v *NamedPath) Decode(dec *xdr.Decoder) error {
+	var w namedPathValue
+	if err := w.Decode(dec); err != nil {
+		return err
+	}
+	*v = NamedPath(w.Value)
+	return nil
+}
+
+var _ xdr.Codec = (*NamedPath)(nil)
+
+// namedAttrsValue holds a NamedAttrs as its underlying type for encoding
+type namedAttrsValue struct {
+	Value map[string]uint64
+}
+
+func (v *namedAttrsValue) Encode(enc *xdr.Encoder) error {
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Value))); err != nil {
+		return fmt.Errorf("failed to encode Value length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Value) {
+		if err := enc.EncodeString(key); err != nil {
+			return fmt.Errorf("failed to encode Value key: %w", err)
+		}
+
+		if err := enc.EncodeUint64(v.Value[key]); err != nil {
+			return fmt.Errorf("failed to encode Value value: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *namedAttrsValue) Decode(dec *xdr.Decoder) error {
+
+	ValueLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(ValueLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Value: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Value = make(map[string]uint64, ValueLen)
+	for range ValueLen {
+		key, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Value key: %w", err)
+		}
+		if _, exists := v.Value[key]; exists {
+			return fmt.Errorf("failed to decode Value key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		value, err := dec.DecodeUint64()
+		if err != nil {
+			return fmt.Errorf("failed to decode Value value: %w", err)
+		}
+		v.Value[key] = value
+
+	}
+
+	return nil
+}
+
+func (v *NamedAttrs) Encode(enc *xdr.Encoder) error {
+	w := namedAttrsValue{Value: map[string]uint64(*v)}
+	return w.Encode(enc)
+}
+
+func (v *NamedAttrs) Decode(dec *xdr.Decoder) error {
+	var w namedAttrsValue
+	if err := w.Decode(dec); err != nil {
+		return err
+	}
+	*v = NamedAttrs(w.Value)
+	return nil
+}
+
+var _ xdr.Codec = (*NamedAttrs)(nil)
+
+// namedCookieValue holds a NamedCookie as its underlying type for encoding
+type namedCookieValue struct {
+	Value [8]byte
+}
+
+func (v *namedCookieValue) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeFixedBytes(v.Value[:]); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *namedCookieValue) Decode(dec *xdr.Decoder) error {
+
+	if err := dec.DecodeFixedBytesInto(v.Value[:]); err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *NamedCookie) Encode(enc *xdr.Encoder) error {
+	w := namedCookieValue{Value: [8]byte(*v)}
+	return w.Encode(enc)
+}
+
+func (v *NamedCookie) Decode(dec *xdr.Decoder) error {
+	var w namedCookieValue
+	if err := w.Decode(dec); err != nil {
+		return err
+	}
+	*v = NamedCookie(w.Value)
+	return nil
+}
+
+var _ xdr.Codec = (*NamedCookie)(nil)
+
+// namedListingValue holds a NamedListing as its underlying type for encoding
+type namedListingValue struct {
+	Value []NamedEntry
+}
+
+func (v *namedListingValue) Encode(enc *xdr.Encoder) error {
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Value))); err != nil {
+		return fmt.Errorf("failed to encode Value length: %w", err)
+	}
+	for _, elem := range v.Value {
+
+		if err := elem.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *namedListingValue) Decode(dec *xdr.Decoder) error {
+
+	ValueLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value length: %w", err)
+	}
+	v.Value = make([]NamedEntry, ValueLen)
+	for i := range v.Value {
+
+		if err := v.Value[i].Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *NamedListing) Encode(enc *xdr.Encoder) error {
+	w := namedListingValue{Value: []NamedEntry(*v)}
+	return w.Encode(enc)
+}
+
+func (v *NamedListing) Decode(dec *xdr.Decoder) error {
+	var w namedListingValue
+	if err := w.Decode(dec); err != nil {
+		return err
+	}
+	*v = NamedListing(w.Value)
+	return nil
+}
+
+// ToUnion converts NamedListing to NamedReply
+func (p *NamedListing) ToUnion() (*NamedReply, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode NamedListing: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &NamedReply{
+		Stat: NamedStatOK,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes NamedListing directly to union format
+func (p *NamedListing) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeUint32(uint32(NamedStatOK)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*NamedListing)(nil)
+
+func (v *NamedReply) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(uint32(v.Stat)); err != nil {
+		return fmt.Errorf("failed to encode Stat: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Stat {
+
+	case NamedStatOK:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case NamedStatPath:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case NamedStatError:
+		// void case - no data
+
+	default:
+		// unknown key - encode nothing
+
+	}
+
+	return nil
+}
+
+func (v *NamedReply) Decode(dec *xdr.Decoder) error {
+
+	tempStat, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Stat: %w", err)
+	}
+	v.Stat = NamedStat(tempStat)
+
+	// Switch based on key for union field Body
+	switch v.Stat {
+
+	case NamedStatOK:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case NamedStatPath:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case NamedStatError:
+		// void case - no data
+
+	default:
+		// unknown key - decode nothing
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*NamedReply)(nil)
+
+// namedReplyNamedStatPathArm wraps the NamedStatPath arm of NamedReply
+type namedReplyNamedStatPathArm struct {
+	Value NamedPath
+}
+
+// NamedStatPath returns the NamedStatPath arm of NamedReply
+func (v *NamedReply) NamedStatPath() (NamedPath, error) {
+	var arm namedReplyNamedStatPathArm
+	if v.Stat != NamedStatPath {
+		return arm.Value, fmt.Errorf("%w: Stat=%v, want NamedStatPath", xdr.ErrArmNotSelected, v.Stat)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode NamedStatPath arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetNamedStatPath sets NamedReply to the NamedStatPath arm with the given value
+func (v *NamedReply) SetNamedStatPath(val NamedPath) error {
+	data, err := xdr.Marshal(&namedReplyNamedStatPathArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode NamedStatPath arm: %w", err)
+	}
+	v.Stat = NamedStatPath
+	v.Body = data
+	return nil
+}
+
+func (v *namedReplyNamedStatPathArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(string(v.Value)); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *namedReplyNamedStatPathArm) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = NamedPath(tempValue)
+
+	return nil
+}
+
+var _ xdr.Codec = (*namedReplyNamedStatPathArm)(nil)
@@ -0,0 +1,117 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestNarrowIntegers tests uint8, uint16, int8, int16, int and uint fields, which are
+// widened to 32-bit (or 64-bit for int/uint) on the wire and range-checked on decode.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// NarrowMode is an alias of a narrow integer
+type NarrowMode uint16
+
+// +xdr:generate
+type NarrowInts struct {
+	U8     uint8
+	U16    uint16
+	I8     int8
+	I16    int16
+	I      int
+	U      uint
+	B      byte
+	Mode   NarrowMode
+	Ports  []uint16
+	Modes  []NarrowMode
+	Deltas [3]int8
+	Opaque []uint8
+	Hash   [4]uint8
+	Counts map[uint16]int
+}
+
+func TestNarrowIntegers(t *testing.T) {
+	value := &NarrowInts{
+		U8:     math.MaxUint8,
+		U16:    math.MaxUint16,
+		I8:     math.MinInt8,
+		I16:    -300,
+		I:      -1 << 40,
+		U:      1 << 40,
+		B:      'x',
+		Mode:   0o755,
+		Ports:  []uint16{22, 2049},
+		Modes:  []NarrowMode{0o644},
+		Deltas: [3]int8{-1, 0, 1},
+		Opaque: []uint8{1, 2, 3},
+		Hash:   [4]uint8{0xde, 0xad, 0xbe, 0xef},
+		Counts: map[uint16]int{80: -1, 443: 7},
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := xdr.Marshal(value)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded NarrowInts
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.U8 != value.U8 || decoded.U16 != value.U16 || decoded.I8 != value.I8 || decoded.I16 != value.I16 ||
+			decoded.I != value.I || decoded.U != value.U || decoded.B != value.B || decoded.Mode != value.Mode {
+			t.Errorf("Expected scalars %+v, got %+v", value, decoded)
+		}
+		if len(decoded.Ports) != 2 || decoded.Ports[1] != 2049 || len(decoded.Modes) != 1 || decoded.Modes[0] != 0o644 || decoded.Deltas != value.Deltas {
+			t.Errorf("Expected arrays %+v, got %+v", value, decoded)
+		}
+		if !bytes.Equal(decoded.Opaque, value.Opaque) || decoded.Hash != value.Hash {
+			t.Errorf("Expected opaque data %+v, got %+v", value, decoded)
+		}
+		if len(decoded.Counts) != 2 || decoded.Counts[80] != -1 || decoded.Counts[443] != 7 {
+			t.Errorf("Expected map %v, got %v", value.Counts, decoded.Counts)
+		}
+	})
+
+	t.Run("wire format", func(t *testing.T) {
+		data, err := xdr.Marshal(&NarrowInts{U8: 1, I8: -1, I: -2, Opaque: []uint8{9}, Hash: [4]uint8{1, 2, 3, 4}})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		want := []byte{
+			0, 0, 0, 1, // U8
+			0, 0, 0, 0, // U16
+			0xff, 0xff, 0xff, 0xff, // I8
+			0, 0, 0, 0, // I16
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe, // I
+		}
+		if !bytes.HasPrefix(data, want) {
+			t.Errorf("Expected prefix %x, got %x", want, data)
+		}
+		opaque := []byte{
+			0, 0, 0, 1, 9, 0, 0, 0, // Opaque as variable-length opaque
+			1, 2, 3, 4, // Hash as fixed-length opaque
+			0, 0, 0, 0, // Counts
+		}
+		if !bytes.HasSuffix(data, opaque) {
+			t.Errorf("Expected suffix %x, got %x", opaque, data)
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		enc := xdr.NewEncoder(make([]byte, 8))
+		if err := enc.EncodeUint32(256); err != nil {
+			t.Fatal(err)
+		}
+		var decoded NarrowInts
+		if err := xdr.Unmarshal(enc.Bytes(), &decoded); !errors.Is(err, xdr.ErrOverflow) {
+			t.Errorf("Expected ErrOverflow for a uint8 of 256, got %v", err)
+		}
+	})
+}
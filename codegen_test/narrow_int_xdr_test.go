@@ -0,0 +1,236 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: narrow_int_test.go
+// Generated 1 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *NarrowInts) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint8(v.U8); err != nil {
+		return fmt.Errorf("failed to encode U8: %w", err)
+	}
+
+	if err := enc.EncodeUint16(v.U16); err != nil {
+		return fmt.Errorf("failed to encode U16: %w", err)
+	}
+
+	if err := enc.EncodeInt8(v.I8); err != nil {
+		return fmt.Errorf("failed to encode I8: %w", err)
+	}
+
+	if err := enc.EncodeInt16(v.I16); err != nil {
+		return fmt.Errorf("failed to encode I16: %w", err)
+	}
+
+	if err := enc.EncodeInt(v.I); err != nil {
+		return fmt.Errorf("failed to encode I: %w", err)
+	}
+
+	if err := enc.EncodeUint(v.U); err != nil {
+		return fmt.Errorf("failed to encode U: %w", err)
+	}
+
+	if err := enc.EncodeUint8(uint8(v.B)); err != nil {
+		return fmt.Errorf("failed to encode B: %w", err)
+	}
+
+	if err := enc.EncodeUint16(uint16(v.Mode)); err != nil {
+		return fmt.Errorf("failed to encode Mode: %w", err)
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Ports))); err != nil {
+		return fmt.Errorf("failed to encode Ports length: %w", err)
+	}
+	for _, elem := range v.Ports {
+
+		if err := enc.EncodeUint16(elem); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Modes))); err != nil {
+		return fmt.Errorf("failed to encode Modes length: %w", err)
+	}
+	for _, elem := range v.Modes {
+
+		if err := enc.EncodeUint16(uint16(elem)); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	for _, elem := range v.Deltas {
+
+		if err := enc.EncodeInt8(int8(elem)); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	if err := enc.EncodeBytes([]byte(v.Opaque)); err != nil {
+		return fmt.Errorf("failed to encode Opaque: %w", err)
+	}
+
+	if err := enc.EncodeFixedBytes(v.Hash[:]); err != nil {
+		return fmt.Errorf("failed to encode Hash: %w", err)
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Counts))); err != nil {
+		return fmt.Errorf("failed to encode Counts length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Counts) {
+		if err := enc.EncodeUint16(key); err != nil {
+			return fmt.Errorf("failed to encode Counts key: %w", err)
+		}
+
+		if err := enc.EncodeInt(v.Counts[key]); err != nil {
+			return fmt.Errorf("failed to encode Counts value: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *NarrowInts) Decode(dec *xdr.Decoder) error {
+
+	tempU8, err := dec.DecodeUint8()
+	if err != nil {
+		return fmt.Errorf("failed to decode U8: %w", err)
+	}
+	v.U8 = tempU8
+
+	tempU16, err := dec.DecodeUint16()
+	if err != nil {
+		return fmt.Errorf("failed to decode U16: %w", err)
+	}
+	v.U16 = tempU16
+
+	tempI8, err := dec.DecodeInt8()
+	if err != nil {
+		return fmt.Errorf("failed to decode I8: %w", err)
+	}
+	v.I8 = tempI8
+
+	tempI16, err := dec.DecodeInt16()
+	if err != nil {
+		return fmt.Errorf("failed to decode I16: %w", err)
+	}
+	v.I16 = tempI16
+
+	tempI, err := dec.DecodeInt()
+	if err != nil {
+		return fmt.Errorf("failed to decode I: %w", err)
+	}
+	v.I = tempI
+
+	tempU, err := dec.DecodeUint()
+	if err != nil {
+		return fmt.Errorf("failed to decode U: %w", err)
+	}
+	v.U = tempU
+
+	tempB, err := dec.DecodeUint8()
+	if err != nil {
+		return fmt.Errorf("failed to decode B: %w", err)
+	}
+	v.B = byte(tempB)
+
+	tempMode, err := dec.DecodeUint16()
+	if err != nil {
+		return fmt.Errorf("failed to decode Mode: %w", err)
+	}
+	v.Mode = NarrowMode(tempMode)
+
+	PortsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Ports length: %w", err)
+	}
+	v.Ports = make([]uint16, PortsLen)
+	for i := range v.Ports {
+
+		val, err := dec.DecodeUint16()
+		if err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+		v.Ports[i] = val
+
+	}
+
+	ModesLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Modes length: %w", err)
+	}
+	v.Modes = make([]NarrowMode, ModesLen)
+	for i := range v.Modes {
+
+		val, err := dec.DecodeUint16()
+		if err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+		v.Modes[i] = NarrowMode(val)
+
+	}
+
+	for i := range v.Deltas {
+
+		val, err := dec.DecodeInt8()
+		if err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+		v.Deltas[i] = val
+
+	}
+
+	tempOpaque, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Opaque: %w", err)
+	}
+	v.Opaque = []uint8(tempOpaque)
+
+	if err := dec.DecodeFixedBytesInto(v.Hash[:]); err != nil {
+		return fmt.Errorf("failed to decode Hash: %w", err)
+	}
+
+	CountsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Counts length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(CountsLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Counts: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Counts = make(map[uint16]int, CountsLen)
+	for range CountsLen {
+		key, err := dec.DecodeUint16()
+		if err != nil {
+			return fmt.Errorf("failed to decode Counts key: %w", err)
+		}
+		if _, exists := v.Counts[key]; exists {
+			return fmt.Errorf("failed to decode Counts key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		value, err := dec.DecodeInt()
+		if err != nil {
+			return fmt.Errorf("failed to decode Counts value: %w", err)
+		}
+		v.Counts[key] = value
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*NarrowInts)(nil)
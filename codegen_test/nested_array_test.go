@@ -0,0 +1,141 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestNestedArrays tests arrays of arrays in any combination of variable and fixed
+// dimensions, and per-dimension length bounds with xdr:"max=A|B".
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// +xdr:generate
+type NestPoint struct {
+	X int32
+	Y int32
+}
+
+// NestDigest is a fixed-size opaque element
+type NestDigest [4]byte
+
+// NestRow is an alias of a variable-length array
+type NestRow []uint16
+
+// +xdr:generate
+type NestedArrays struct {
+	Matrix  [][]uint32
+	Grid    [2][3]int32
+	Names   [2][]string
+	Paths   [][]NestPoint
+	Refs    [][2]*NestPoint
+	Digests [][]NestDigest
+	Blobs   [][]byte
+	Table   []NestRow
+	Cube    [2][][2]int8
+}
+
+// +xdr:generate
+type BoundedArrays struct {
+	Tags   []string   `xdr:"max=2"`
+	Matrix [][]uint32 `xdr:"max=2|3"`
+	Cube   [2][]int8  `xdr:"max=|1"`
+}
+
+func TestNestedArrays(t *testing.T) {
+	value := &NestedArrays{
+		Matrix:  [][]uint32{{1, 2, 3}, {}, {4}},
+		Grid:    [2][3]int32{{1, -2, 3}, {-4, 5, -6}},
+		Names:   [2][]string{{"a", "bc"}, {"def"}},
+		Paths:   [][]NestPoint{{{X: 1, Y: 2}}, {{X: 3, Y: 4}, {X: 5, Y: 6}}},
+		Refs:    [][2]*NestPoint{{{X: 7}, {Y: 8}}},
+		Digests: [][]NestDigest{{{1, 2, 3, 4}}, {{5, 6, 7, 8}, {9, 10, 11, 12}}},
+		Blobs:   [][]byte{[]byte("xy"), {}},
+		Table:   []NestRow{{1, 2}, {3}},
+		Cube:    [2][][2]int8{{{1, 2}}, {{-1, -2}, {3, 4}}},
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := xdr.Marshal(value)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded NestedArrays
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		again, err := xdr.Marshal(&decoded)
+		if err != nil {
+			t.Fatalf("Marshal() of decoded value failed: %v", err)
+		}
+		if !bytes.Equal(data, again) {
+			t.Errorf("Expected decoded value to encode identically:\n%x\n%x", data, again)
+		}
+		if decoded.Matrix[2][0] != 4 || decoded.Grid[1][2] != -6 || decoded.Names[1][0] != "def" ||
+			decoded.Paths[1][1].Y != 6 || decoded.Refs[0][1].Y != 8 || decoded.Digests[1][1][3] != 12 ||
+			string(decoded.Blobs[0]) != "xy" || decoded.Table[0][1] != 2 || decoded.Cube[1][1][0] != 3 {
+			t.Errorf("Expected %+v, got %+v", value, decoded)
+		}
+	})
+
+	t.Run("wire format", func(t *testing.T) {
+		data, err := xdr.Marshal(&NestedArrays{Matrix: [][]uint32{{7}, {}}})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		want := []byte{
+			0, 0, 0, 2, // Matrix length
+			0, 0, 0, 1, 0, 0, 0, 7, // Matrix[0]
+			0, 0, 0, 0, // Matrix[1]
+		}
+		if !bytes.HasPrefix(data, want) {
+			t.Errorf("Expected prefix %x, got %x", want, data)
+		}
+	})
+}
+
+func TestBoundedArrays(t *testing.T) {
+	t.Run("within bounds", func(t *testing.T) {
+		value := &BoundedArrays{Tags: []string{"a", "b"}, Matrix: [][]uint32{{1, 2, 3}, {4}}, Cube: [2][]int8{{1}, {}}}
+		data, err := xdr.Marshal(value)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded BoundedArrays
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if len(decoded.Tags) != 2 || decoded.Matrix[0][2] != 3 || decoded.Cube[0][0] != 1 {
+			t.Errorf("Expected %+v, got %+v", value, decoded)
+		}
+	})
+
+	t.Run("encode exceeds bound", func(t *testing.T) {
+		for name, value := range map[string]*BoundedArrays{
+			"outer": {Tags: []string{"a", "b", "c"}},
+			"inner": {Matrix: [][]uint32{{1, 2, 3, 4}}},
+			"fixed": {Cube: [2][]int8{{}, {1, 2}}},
+		} {
+			if _, err := xdr.Marshal(value); !errors.Is(err, xdr.ErrInvalidData) {
+				t.Errorf("%s: expected ErrInvalidData, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("decode exceeds bound", func(t *testing.T) {
+		data := []byte{
+			0, 0, 0, 0, // Tags
+			0, 0, 0, 1, // Matrix length
+			0, 0, 0, 4, // Matrix[0] length above max=3
+		}
+		var decoded BoundedArrays
+		if err := xdr.Unmarshal(data, &decoded); !errors.Is(err, xdr.ErrInvalidData) {
+			t.Errorf("Expected ErrInvalidData, got %v", err)
+		}
+	})
+}
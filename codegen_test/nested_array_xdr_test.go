@@ -0,0 +1,447 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: nested_array_test.go
+// Generated 3 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *NestPoint) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeInt32(v.X); err != nil {
+		return fmt.Errorf("failed to encode X: %w", err)
+	}
+
+	if err := enc.EncodeInt32(v.Y); err != nil {
+		return fmt.Errorf("failed to encode Y: %w", err)
+	}
+
+	return nil
+}
+
+func (v *NestPoint) Decode(dec *xdr.Decoder) error {
+
+	tempX, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode X: %w", err)
+	}
+	v.X = tempX
+
+	tempY, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Y: %w", err)
+	}
+	v.Y = tempY
+
+	return nil
+}
+
+var _ xdr.Codec = (*NestPoint)(nil)
+
+func (v *NestedArrays) Encode(enc *xdr.Encoder) error {
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Matrix))); err != nil {
+		return fmt.Errorf("failed to encode Matrix length: %w", err)
+	}
+	for i := range v.Matrix {
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Matrix[i]))); err != nil {
+			return fmt.Errorf("failed to encode Matrix length: %w", err)
+		}
+		for j := range v.Matrix[i] {
+			if err := enc.EncodeUint32(v.Matrix[i][j]); err != nil {
+				return fmt.Errorf("failed to encode Matrix element: %w", err)
+			}
+		}
+	}
+
+	for i := range v.Grid {
+		for j := range v.Grid[i] {
+			if err := enc.EncodeInt32(v.Grid[i][j]); err != nil {
+				return fmt.Errorf("failed to encode Grid element: %w", err)
+			}
+		}
+	}
+
+	for i := range v.Names {
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Names[i]))); err != nil {
+			return fmt.Errorf("failed to encode Names length: %w", err)
+		}
+		for j := range v.Names[i] {
+			if err := enc.EncodeString(v.Names[i][j]); err != nil {
+				return fmt.Errorf("failed to encode Names element: %w", err)
+			}
+		}
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Paths))); err != nil {
+		return fmt.Errorf("failed to encode Paths length: %w", err)
+	}
+	for i := range v.Paths {
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Paths[i]))); err != nil {
+			return fmt.Errorf("failed to encode Paths length: %w", err)
+		}
+		for j := range v.Paths[i] {
+			if err := v.Paths[i][j].Encode(enc); err != nil {
+				return fmt.Errorf("failed to encode Paths element: %w", err)
+			}
+		}
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Refs))); err != nil {
+		return fmt.Errorf("failed to encode Refs length: %w", err)
+	}
+	for i := range v.Refs {
+		for j := range v.Refs[i] {
+			if err := v.Refs[i][j].Encode(enc); err != nil {
+				return fmt.Errorf("failed to encode Refs element: %w", err)
+			}
+		}
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Digests))); err != nil {
+		return fmt.Errorf("failed to encode Digests length: %w", err)
+	}
+	for i := range v.Digests {
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Digests[i]))); err != nil {
+			return fmt.Errorf("failed to encode Digests length: %w", err)
+		}
+		for j := range v.Digests[i] {
+			if err := enc.EncodeFixedBytes(v.Digests[i][j][:]); err != nil {
+				return fmt.Errorf("failed to encode Digests element: %w", err)
+			}
+		}
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Blobs))); err != nil {
+		return fmt.Errorf("failed to encode Blobs length: %w", err)
+	}
+	for _, elem := range v.Blobs {
+
+		if err := enc.EncodeBytes(elem); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Table))); err != nil {
+		return fmt.Errorf("failed to encode Table length: %w", err)
+	}
+	for i := range v.Table {
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Table[i]))); err != nil {
+			return fmt.Errorf("failed to encode Table length: %w", err)
+		}
+		for j := range v.Table[i] {
+			if err := enc.EncodeUint16(v.Table[i][j]); err != nil {
+				return fmt.Errorf("failed to encode Table element: %w", err)
+			}
+		}
+	}
+
+	for i := range v.Cube {
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Cube[i]))); err != nil {
+			return fmt.Errorf("failed to encode Cube length: %w", err)
+		}
+		for j := range v.Cube[i] {
+			for k := range v.Cube[i][j] {
+				if err := enc.EncodeInt8(v.Cube[i][j][k]); err != nil {
+					return fmt.Errorf("failed to encode Cube element: %w", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *NestedArrays) Decode(dec *xdr.Decoder) error {
+
+	MatrixLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Matrix length: %w", err)
+	}
+	v.Matrix = make([][]uint32, MatrixLen)
+	for i := range v.Matrix {
+		MatrixLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Matrix length: %w", err)
+		}
+		v.Matrix[i] = make([]uint32, MatrixLen1)
+		for j := range v.Matrix[i] {
+			val, err := dec.DecodeUint32()
+			if err != nil {
+				return fmt.Errorf("failed to decode Matrix element: %w", err)
+			}
+			v.Matrix[i][j] = val
+		}
+	}
+
+	for i := range v.Grid {
+		for j := range v.Grid[i] {
+			val, err := dec.DecodeInt32()
+			if err != nil {
+				return fmt.Errorf("failed to decode Grid element: %w", err)
+			}
+			v.Grid[i][j] = val
+		}
+	}
+
+	for i := range v.Names {
+		NamesLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Names length: %w", err)
+		}
+		v.Names[i] = make([]string, NamesLen1)
+		for j := range v.Names[i] {
+			val, err := dec.DecodeString()
+			if err != nil {
+				return fmt.Errorf("failed to decode Names element: %w", err)
+			}
+			v.Names[i][j] = val
+		}
+	}
+
+	PathsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Paths length: %w", err)
+	}
+	v.Paths = make([][]NestPoint, PathsLen)
+	for i := range v.Paths {
+		PathsLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Paths length: %w", err)
+		}
+		v.Paths[i] = make([]NestPoint, PathsLen1)
+		for j := range v.Paths[i] {
+			if err := v.Paths[i][j].Decode(dec); err != nil {
+				return fmt.Errorf("failed to decode Paths element: %w", err)
+			}
+		}
+	}
+
+	RefsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Refs length: %w", err)
+	}
+	v.Refs = make([][2]*NestPoint, RefsLen)
+	for i := range v.Refs {
+		for j := range v.Refs[i] {
+			v.Refs[i][j] = &NestPoint{}
+			if err := v.Refs[i][j].Decode(dec); err != nil {
+				return fmt.Errorf("failed to decode Refs element: %w", err)
+			}
+		}
+	}
+
+	DigestsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Digests length: %w", err)
+	}
+	v.Digests = make([][]NestDigest, DigestsLen)
+	for i := range v.Digests {
+		DigestsLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Digests length: %w", err)
+		}
+		v.Digests[i] = make([]NestDigest, DigestsLen1)
+		for j := range v.Digests[i] {
+			if err := dec.DecodeFixedBytesInto(v.Digests[i][j][:]); err != nil {
+				return fmt.Errorf("failed to decode Digests element: %w", err)
+			}
+		}
+	}
+
+	BlobsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Blobs length: %w", err)
+	}
+	v.Blobs = make([][]byte, BlobsLen)
+	for i := range v.Blobs {
+
+		val, err := dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+		v.Blobs[i] = val
+
+	}
+
+	TableLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Table length: %w", err)
+	}
+	v.Table = make([]NestRow, TableLen)
+	for i := range v.Table {
+		TableLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Table length: %w", err)
+		}
+		v.Table[i] = make(NestRow, TableLen1)
+		for j := range v.Table[i] {
+			val, err := dec.DecodeUint16()
+			if err != nil {
+				return fmt.Errorf("failed to decode Table element: %w", err)
+			}
+			v.Table[i][j] = val
+		}
+	}
+
+	for i := range v.Cube {
+		CubeLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Cube length: %w", err)
+		}
+		v.Cube[i] = make([][2]int8, CubeLen1)
+		for j := range v.Cube[i] {
+			for k := range v.Cube[i][j] {
+				val, err := dec.DecodeInt8()
+				if err != nil {
+					return fmt.Errorf("failed to decode Cube element: %w", err)
+				}
+				v.Cube[i][j][k] = val
+			}
+		}
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*NestedArrays)(nil)
+
+func (v *BoundedArrays) Encode(enc *xdr.Encoder) error {
+
+	if len(v.Tags) > 2 {
+		return fmt.Errorf("failed to encode Tags: length %d exceeds maximum 2: %w", len(v.Tags), xdr.ErrInvalidData)
+	}
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Tags))); err != nil {
+		return fmt.Errorf("failed to encode Tags length: %w", err)
+	}
+	for i := range v.Tags {
+		if err := enc.EncodeString(v.Tags[i]); err != nil {
+			return fmt.Errorf("failed to encode Tags element: %w", err)
+		}
+	}
+
+	if len(v.Matrix) > 2 {
+		return fmt.Errorf("failed to encode Matrix: length %d exceeds maximum 2: %w", len(v.Matrix), xdr.ErrInvalidData)
+	}
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Matrix))); err != nil {
+		return fmt.Errorf("failed to encode Matrix length: %w", err)
+	}
+	for i := range v.Matrix {
+		if len(v.Matrix[i]) > 3 {
+			return fmt.Errorf("failed to encode Matrix: length %d exceeds maximum 3: %w", len(v.Matrix[i]), xdr.ErrInvalidData)
+		}
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Matrix[i]))); err != nil {
+			return fmt.Errorf("failed to encode Matrix length: %w", err)
+		}
+		for j := range v.Matrix[i] {
+			if err := enc.EncodeUint32(v.Matrix[i][j]); err != nil {
+				return fmt.Errorf("failed to encode Matrix element: %w", err)
+			}
+		}
+	}
+
+	for i := range v.Cube {
+		if len(v.Cube[i]) > 1 {
+			return fmt.Errorf("failed to encode Cube: length %d exceeds maximum 1: %w", len(v.Cube[i]), xdr.ErrInvalidData)
+		}
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Cube[i]))); err != nil {
+			return fmt.Errorf("failed to encode Cube length: %w", err)
+		}
+		for j := range v.Cube[i] {
+			if err := enc.EncodeInt8(v.Cube[i][j]); err != nil {
+				return fmt.Errorf("failed to encode Cube element: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *BoundedArrays) Decode(dec *xdr.Decoder) error {
+
+	TagsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Tags length: %w", err)
+	}
+	if TagsLen > 2 {
+		return fmt.Errorf("failed to decode Tags: length %d exceeds maximum 2: %w", TagsLen, xdr.ErrInvalidData)
+	}
+	v.Tags = make([]string, TagsLen)
+	for i := range v.Tags {
+		val, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Tags element: %w", err)
+		}
+		v.Tags[i] = val
+	}
+
+	MatrixLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Matrix length: %w", err)
+	}
+	if MatrixLen > 2 {
+		return fmt.Errorf("failed to decode Matrix: length %d exceeds maximum 2: %w", MatrixLen, xdr.ErrInvalidData)
+	}
+	v.Matrix = make([][]uint32, MatrixLen)
+	for i := range v.Matrix {
+		MatrixLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Matrix length: %w", err)
+		}
+		if MatrixLen1 > 3 {
+			return fmt.Errorf("failed to decode Matrix: length %d exceeds maximum 3: %w", MatrixLen1, xdr.ErrInvalidData)
+		}
+		v.Matrix[i] = make([]uint32, MatrixLen1)
+		for j := range v.Matrix[i] {
+			val, err := dec.DecodeUint32()
+			if err != nil {
+				return fmt.Errorf("failed to decode Matrix element: %w", err)
+			}
+			v.Matrix[i][j] = val
+		}
+	}
+
+	for i := range v.Cube {
+		CubeLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Cube length: %w", err)
+		}
+		if CubeLen1 > 1 {
+			return fmt.Errorf("failed to decode Cube: length %d exceeds maximum 1: %w", CubeLen1, xdr.ErrInvalidData)
+		}
+		v.Cube[i] = make([]int8, CubeLen1)
+		for j := range v.Cube[i] {
+			val, err := dec.DecodeInt8()
+			if err != nil {
+				return fmt.Errorf("failed to decode Cube element: %w", err)
+			}
+			v.Cube[i][j] = val
+		}
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*BoundedArrays)(nil)
@@ -0,0 +1,107 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestOpaqueFields tests struct fields tagged xdr:"opaque", encoded as length-prefixed
+// opaque data that readers without the type can skip.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+// +xdr:generate
+type OpqAttrs struct {
+	Mode uint32
+	Name string
+}
+
+// +xdr:generate
+type OpqCall struct {
+	Proc uint32
+	Args OpqAttrs  `xdr:"opaque"`
+	Cred *OpqAttrs `xdr:"opaque"`
+	Tail uint32
+}
+
+// OpqRelay is how an intermediary sees OpqCall: the bodies stay opaque bytes
+// +xdr:generate
+type OpqRelay struct {
+	Proc uint32
+	Args []byte
+	Cred []byte
+	Tail uint32
+}
+
+func TestOpaqueFields(t *testing.T) {
+	call := &OpqCall{
+		Proc: 3,
+		Args: OpqAttrs{Mode: 0o644, Name: "a"},
+		Cred: &OpqAttrs{Mode: 1, Name: "root"},
+		Tail: 9,
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := xdr.Marshal(call)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded OpqCall
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Args != call.Args || decoded.Cred == nil || *decoded.Cred != *call.Cred || decoded.Tail != call.Tail {
+			t.Errorf("Expected %+v, got %+v", call, decoded)
+		}
+	})
+
+	t.Run("skippable", func(t *testing.T) {
+		data, err := xdr.Marshal(call)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var relay OpqRelay
+		if err := xdr.Unmarshal(data, &relay); err != nil {
+			t.Fatalf("Unmarshal() as opaque bytes failed: %v", err)
+		}
+		args, err := xdr.Marshal(&call.Args)
+		if err != nil {
+			t.Fatalf("Marshal() args failed: %v", err)
+		}
+		if !bytes.Equal(relay.Args, args) || relay.Tail != 9 {
+			t.Errorf("Expected opaque args %x and tail 9, got %x and %d", args, relay.Args, relay.Tail)
+		}
+
+		// Re-encoding the relayed bytes reproduces the original message
+		forwarded, err := xdr.Marshal(&relay)
+		if err != nil {
+			t.Fatalf("Marshal() relay failed: %v", err)
+		}
+		if !bytes.Equal(forwarded, data) {
+			t.Errorf("Expected relay to forward %x, got %x", data, forwarded)
+		}
+	})
+
+	t.Run("trailing bytes", func(t *testing.T) {
+		// Args declares 12 bytes but its encoding only takes 8
+		data := []byte{
+			0, 0, 0, 3,
+			0, 0, 0, 12, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0,
+		}
+		var decoded OpqCall
+		if err := xdr.Unmarshal(data, &decoded); !errors.Is(err, xdr.ErrInvalidData) {
+			t.Errorf("Expected ErrInvalidData, got %v", err)
+		}
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		if _, err := xdr.Marshal(&OpqCall{}); err == nil {
+			t.Error("Expected error for nil opaque pointer")
+		}
+	})
+}
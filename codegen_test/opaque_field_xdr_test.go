@@ -0,0 +1,149 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: opaque_field_test.go
+// Generated 3 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *OpqAttrs) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Mode); err != nil {
+		return fmt.Errorf("failed to encode Mode: %w", err)
+	}
+
+	if err := enc.EncodeString(v.Name); err != nil {
+		return fmt.Errorf("failed to encode Name: %w", err)
+	}
+
+	return nil
+}
+
+func (v *OpqAttrs) Decode(dec *xdr.Decoder) error {
+
+	tempMode, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Mode: %w", err)
+	}
+	v.Mode = tempMode
+
+	tempName, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	v.Name = tempName
+
+	return nil
+}
+
+var _ xdr.Codec = (*OpqAttrs)(nil)
+
+func (v *OpqCall) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Proc); err != nil {
+		return fmt.Errorf("failed to encode Proc: %w", err)
+	}
+
+	if err := enc.EncodeOpaque(&v.Args); err != nil {
+		return fmt.Errorf("failed to encode Args: %w", err)
+	}
+
+	if v.Cred == nil {
+		return fmt.Errorf("pointer field Cred is nil")
+	}
+	if err := enc.EncodeOpaque(v.Cred); err != nil {
+		return fmt.Errorf("failed to encode Cred: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Tail); err != nil {
+		return fmt.Errorf("failed to encode Tail: %w", err)
+	}
+
+	return nil
+}
+
+func (v *OpqCall) Decode(dec *xdr.Decoder) error {
+
+	tempProc, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Proc: %w", err)
+	}
+	v.Proc = tempProc
+
+	if err := dec.DecodeOpaque(&v.Args); err != nil {
+		return fmt.Errorf("failed to decode Args: %w", err)
+	}
+
+	// Allocate pointer field before decoding
+	v.Cred = &OpqAttrs{}
+	if err := dec.DecodeOpaque(v.Cred); err != nil {
+		return fmt.Errorf("failed to decode Cred: %w", err)
+	}
+
+	tempTail, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Tail: %w", err)
+	}
+	v.Tail = tempTail
+
+	return nil
+}
+
+var _ xdr.Codec = (*OpqCall)(nil)
+
+func (v *OpqRelay) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Proc); err != nil {
+		return fmt.Errorf("failed to encode Proc: %w", err)
+	}
+
+	if err := enc.EncodeBytes(v.Args); err != nil {
+		return fmt.Errorf("failed to encode Args: %w", err)
+	}
+
+	if err := enc.EncodeBytes(v.Cred); err != nil {
+		return fmt.Errorf("failed to encode Cred: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Tail); err != nil {
+		return fmt.Errorf("failed to encode Tail: %w", err)
+	}
+
+	return nil
+}
+
+func (v *OpqRelay) Decode(dec *xdr.Decoder) error {
+
+	tempProc, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Proc: %w", err)
+	}
+	v.Proc = tempProc
+
+	tempArgs, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Args: %w", err)
+	}
+	v.Args = tempArgs
+
+	tempCred, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Cred: %w", err)
+	}
+	v.Cred = tempCred
+
+	tempTail, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Tail: %w", err)
+	}
+	v.Tail = tempTail
+
+	return nil
+}
+
+var _ xdr.Codec = (*OpqRelay)(nil)
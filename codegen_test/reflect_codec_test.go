@@ -0,0 +1,232 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestReflectCodec tests that xdr.MarshalReflect, walking a type without generated
+// methods, produces the same bytes as the generated code and decodes them back.
+
+//go:generate ../bin/xdrgen $GOFILE
+type ReflKind int32
+
+const (
+	ReflKindNone ReflKind = 0
+	ReflKindText ReflKind = 1
+	ReflKindLink ReflKind = 2
+)
+
+// ReflAuth has no Codec of its own and is only ever inlined
+type ReflAuth struct {
+	UID uint32
+	GID uint32
+}
+
+// +xdr:generate
+type ReflAttrs struct {
+	Mode uint16
+	Size uint64
+}
+
+// +xdr:generate
+type ReflRecord struct {
+	_        uint32 `xdr:"const=0x52454600"`
+	Length   uint32 `xdr:"len=Names|Body"`
+	ReflAuth `xdr:"inline"`
+	Small    int8
+	Count    int
+	Kind     ReflKind
+	Body     []byte `xdr:"union=Kind,void=0|2,arms=1"`
+	Digest   [6]byte
+	Names    []string    `xdr:"max=4"`
+	Grid     [2][]uint32 `xdr:"max=|3"`
+	Options  map[string]uint32
+	Members  map[uint32]struct{}
+	Attrs    *ReflAttrs
+	Wrapped  ReflAttrs `xdr:"opaque"`
+	Created  time.Time `xdr:"time=nfs"`
+	TTL      time.Duration
+	_        [3]byte `xdr:"reserved=3"`
+	CRC      uint32  `xdr:"crc32=Kind|Body|Attrs"`
+	Local    string  `xdr:"-"`
+}
+
+// +xdr:payload,union=ReflRecord,field=Body,discriminant=ReflKindText
+type ReflText struct {
+	Text string
+}
+
+// +xdr:arm,union=ReflStrict,field=Body,discriminant=ReflKindText,type=string
+// +xdr:arm,union=ReflCaptured,field=Body,discriminant=ReflKindText,type=string
+
+// +xdr:generate
+type ReflStrict struct {
+	Kind  ReflKind
+	Body  []byte `xdr:"union=Kind,void=0,arms=1,unknown=reject"`
+	Trail uint32
+}
+
+// +xdr:generate
+type ReflCaptured struct {
+	Kind  ReflKind
+	Body  []byte `xdr:"union=Kind,void=0|2,arms=1,unknown=capture"`
+	Trail uint32
+}
+
+// +xdr:generate
+type ReflEntry struct {
+	FileID uint64
+	Next   *ReflEntry `xdr:"list"`
+}
+
+// +xdr:generate
+type ReflDirList struct {
+	Entries *ReflEntry `xdr:"list"`
+	EOF     bool
+}
+
+// The plain types have the same fields and tags but none of the generated methods
+type (
+	reflPlainRecord   ReflRecord
+	reflPlainDirList  ReflDirList
+	reflPlainStrict   ReflStrict
+	reflPlainCaptured ReflCaptured
+)
+
+func TestReflectCodec(t *testing.T) {
+	text, err := xdr.Marshal(&ReflText{Text: "hello"})
+	if err != nil {
+		t.Fatalf("Marshal() of payload failed: %v", err)
+	}
+	record := &ReflRecord{
+		ReflAuth: ReflAuth{UID: 1000, GID: 100},
+		Small:    -3,
+		Count:    1 << 40,
+		Kind:     ReflKindText,
+		Body:     text,
+		Digest:   [6]byte{1, 2, 3, 4, 5, 6},
+		Names:    []string{"a", "b"},
+		Grid:     [2][]uint32{{1, 2}, {3}},
+		Options:  map[string]uint32{"rsize": 4096, "wsize": 8192},
+		Members:  map[uint32]struct{}{7: {}, 3: {}},
+		Attrs:    &ReflAttrs{Mode: 0o644, Size: 12},
+		Wrapped:  ReflAttrs{Mode: 0o755, Size: 34},
+		Created:  time.Unix(1700000000, 5).UTC(),
+		TTL:      time.Minute,
+	}
+
+	t.Run("record matches generated code", func(t *testing.T) {
+		want, err := xdr.Marshal(record)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		got, err := xdr.MarshalReflect((*reflPlainRecord)(record))
+		if err != nil {
+			t.Fatalf("MarshalReflect() failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("MarshalReflect() = %x, generated %x", got, want)
+		}
+
+		var decoded reflPlainRecord
+		if err := xdr.UnmarshalReflect(want, &decoded); err != nil {
+			t.Fatalf("UnmarshalReflect() failed: %v", err)
+		}
+		again, err := xdr.Marshal((*ReflRecord)(&decoded))
+		if err != nil {
+			t.Fatalf("Marshal() of decoded record failed: %v", err)
+		}
+		if !bytes.Equal(again, want) {
+			t.Errorf("Decoded record re-encodes to %x, want %x", again, want)
+		}
+	})
+
+	t.Run("void arm matches generated code", func(t *testing.T) {
+		void := *record
+		void.Kind, void.Body = ReflKindNone, nil
+		want, err := xdr.Marshal(&void)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		got, err := xdr.MarshalReflect((*reflPlainRecord)(&void))
+		if err != nil {
+			t.Fatalf("MarshalReflect() failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("MarshalReflect() = %x, generated %x", got, want)
+		}
+	})
+
+	t.Run("list matches generated code", func(t *testing.T) {
+		list := &ReflDirList{Entries: &ReflEntry{FileID: 1, Next: &ReflEntry{FileID: 2}}, EOF: true}
+		want, err := xdr.Marshal(list)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		got, err := xdr.MarshalReflect((*reflPlainDirList)(list))
+		if err != nil {
+			t.Fatalf("MarshalReflect() failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("MarshalReflect() = %x, generated %x", got, want)
+		}
+	})
+
+	t.Run("rejected kinds match generated code", func(t *testing.T) {
+		for _, kind := range []ReflKind{ReflKindLink, 9} {
+			strict := &ReflStrict{Kind: kind, Trail: 7}
+			if _, err := xdr.Marshal(strict); !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+				t.Errorf("Marshal() of kind %d = %v, want ErrUnknownDiscriminant", kind, err)
+			}
+			if _, err := xdr.MarshalReflect((*reflPlainStrict)(strict)); !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+				t.Errorf("MarshalReflect() of kind %d = %v, want ErrUnknownDiscriminant", kind, err)
+			}
+		}
+
+		strict := &ReflStrict{Trail: 7}
+		if err := strict.SetBodyReflKindText("hi"); err != nil {
+			t.Fatalf("SetBodyReflKindText() failed: %v", err)
+		}
+		want, err := xdr.Marshal(strict)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		got, err := xdr.MarshalReflect((*reflPlainStrict)(strict))
+		if err != nil {
+			t.Fatalf("MarshalReflect() failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("MarshalReflect() = %x, generated %x", got, want)
+		}
+	})
+
+	t.Run("captured kinds match generated code", func(t *testing.T) {
+		captured := &ReflCaptured{Kind: 9, Body: []byte{0xab, 0xcd}, Trail: 7}
+		want, err := xdr.Marshal(captured)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		got, err := xdr.MarshalReflect((*reflPlainCaptured)(captured))
+		if err != nil {
+			t.Fatalf("MarshalReflect() failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("MarshalReflect() = %x, generated %x", got, want)
+		}
+
+		var decoded reflPlainCaptured
+		if err := xdr.UnmarshalReflect(want, &decoded); err != nil {
+			t.Fatalf("UnmarshalReflect() failed: %v", err)
+		}
+		if !bytes.Equal(decoded.Body, captured.Body) || decoded.Trail != 7 {
+			t.Errorf("Expected body abcd and trail 7, got %x and %d", decoded.Body, decoded.Trail)
+		}
+	})
+}
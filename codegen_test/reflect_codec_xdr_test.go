@@ -0,0 +1,798 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: reflect_codec_test.go
+// Generated 9 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *ReflAttrs) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint16(v.Mode); err != nil {
+		return fmt.Errorf("failed to encode Mode: %w", err)
+	}
+
+	if err := enc.EncodeUint64(v.Size); err != nil {
+		return fmt.Errorf("failed to encode Size: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ReflAttrs) Decode(dec *xdr.Decoder) error {
+
+	tempMode, err := dec.DecodeUint16()
+	if err != nil {
+		return fmt.Errorf("failed to decode Mode: %w", err)
+	}
+	v.Mode = tempMode
+
+	tempSize, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Size: %w", err)
+	}
+	v.Size = tempSize
+
+	return nil
+}
+
+var _ xdr.Codec = (*ReflAttrs)(nil)
+
+func (v *ReflRecord) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(0x52454600); err != nil {
+		return fmt.Errorf("failed to encode ReflRecord constant: %w", err)
+	}
+
+	posLength := enc.Len()
+	if err := enc.EncodeUint32(0); err != nil {
+		return fmt.Errorf("failed to encode Length: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.UID); err != nil {
+		return fmt.Errorf("failed to encode UID: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.GID); err != nil {
+		return fmt.Errorf("failed to encode GID: %w", err)
+	}
+
+	if err := enc.EncodeInt8(v.Small); err != nil {
+		return fmt.Errorf("failed to encode Small: %w", err)
+	}
+
+	if err := enc.EncodeInt(v.Count); err != nil {
+		return fmt.Errorf("failed to encode Count: %w", err)
+	}
+
+	startKind := enc.Len()
+	if err := enc.EncodeInt32(int32(v.Kind)); err != nil {
+		return fmt.Errorf("failed to encode Kind: %w", err)
+	}
+	endKind := enc.Len()
+
+	startBody := enc.Len()
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case ReflKindText:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case 0:
+		// void case - no data
+
+	case 2:
+		// void case - no data
+
+	default:
+		// unknown key - encode nothing
+
+	}
+	endBody := enc.Len()
+
+	if err := enc.EncodeFixedBytes(v.Digest[:]); err != nil {
+		return fmt.Errorf("failed to encode Digest: %w", err)
+	}
+
+	startNames := enc.Len()
+	if len(v.Names) > 4 {
+		return fmt.Errorf("failed to encode Names: length %d exceeds maximum 4: %w", len(v.Names), xdr.ErrInvalidData)
+	}
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Names))); err != nil {
+		return fmt.Errorf("failed to encode Names length: %w", err)
+	}
+	for i := range v.Names {
+		if err := enc.EncodeString(v.Names[i]); err != nil {
+			return fmt.Errorf("failed to encode Names element: %w", err)
+		}
+	}
+	endNames := enc.Len()
+
+	for i := range v.Grid {
+		if len(v.Grid[i]) > 3 {
+			return fmt.Errorf("failed to encode Grid: length %d exceeds maximum 3: %w", len(v.Grid[i]), xdr.ErrInvalidData)
+		}
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Grid[i]))); err != nil {
+			return fmt.Errorf("failed to encode Grid length: %w", err)
+		}
+		for j := range v.Grid[i] {
+			if err := enc.EncodeUint32(v.Grid[i][j]); err != nil {
+				return fmt.Errorf("failed to encode Grid element: %w", err)
+			}
+		}
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Options))); err != nil {
+		return fmt.Errorf("failed to encode Options length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Options) {
+		if err := enc.EncodeString(key); err != nil {
+			return fmt.Errorf("failed to encode Options key: %w", err)
+		}
+
+		if err := enc.EncodeUint32(v.Options[key]); err != nil {
+			return fmt.Errorf("failed to encode Options value: %w", err)
+		}
+
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Members))); err != nil {
+		return fmt.Errorf("failed to encode Members length: %w", err)
+	}
+	// Pairs are written in ascending key order so the encoding is deterministic
+	for _, key := range xdr.SortedKeys(v.Members) {
+		if err := enc.EncodeUint32(key); err != nil {
+			return fmt.Errorf("failed to encode Members key: %w", err)
+		}
+
+	}
+
+	startAttrs := enc.Len()
+
+	if v.Attrs == nil {
+		return fmt.Errorf("pointer field Attrs is nil")
+	}
+
+	if err := v.Attrs.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode Attrs: %w", err)
+	}
+
+	endAttrs := enc.Len()
+
+	if err := enc.EncodeOpaque(&v.Wrapped); err != nil {
+		return fmt.Errorf("failed to encode Wrapped: %w", err)
+	}
+
+	if err := enc.EncodeTimeNFS(v.Created); err != nil {
+		return fmt.Errorf("failed to encode Created: %w", err)
+	}
+
+	if err := enc.EncodeDurationUnixNano(v.TTL); err != nil {
+		return fmt.Errorf("failed to encode TTL: %w", err)
+	}
+
+	if err := enc.EncodeReserved(3); err != nil {
+		return fmt.Errorf("failed to encode ReflRecord reserved area: %w", err)
+	}
+
+	posCRC := enc.Len()
+	if err := enc.EncodeUint32(0); err != nil {
+		return fmt.Errorf("failed to encode CRC: %w", err)
+	}
+
+	// Length covers Body and Names, filled in now that they are encoded
+	if err := enc.PatchUint32(posLength, xdr.ByteLength(enc.Bytes()[startBody:endBody], enc.Bytes()[startNames:endNames])); err != nil {
+		return fmt.Errorf("failed to encode Length: %w", err)
+	}
+
+	// CRC covers Kind, Body and Attrs, filled in now that they are encoded
+	if err := enc.PatchUint32(posCRC, xdr.ChecksumCRC32(enc.Bytes()[startKind:endKind], enc.Bytes()[startBody:endBody], enc.Bytes()[startAttrs:endAttrs])); err != nil {
+		return fmt.Errorf("failed to encode CRC: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ReflRecord) Decode(dec *xdr.Decoder) error {
+
+	blank0, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode ReflRecord constant: %w", err)
+	}
+	if blank0 != 0x52454600 {
+		return fmt.Errorf("%w: ReflRecord constant is %#x, expected 0x52454600", xdr.ErrInvalidData, blank0)
+	}
+
+	tempLength, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Length: %w", err)
+	}
+	v.Length = tempLength
+
+	tempUID, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode UID: %w", err)
+	}
+	v.UID = tempUID
+
+	tempGID, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode GID: %w", err)
+	}
+	v.GID = tempGID
+
+	tempSmall, err := dec.DecodeInt8()
+	if err != nil {
+		return fmt.Errorf("failed to decode Small: %w", err)
+	}
+	v.Small = tempSmall
+
+	tempCount, err := dec.DecodeInt()
+	if err != nil {
+		return fmt.Errorf("failed to decode Count: %w", err)
+	}
+	v.Count = tempCount
+
+	startKind := dec.Position()
+	tempKind, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Kind: %w", err)
+	}
+	v.Kind = ReflKind(tempKind)
+	endKind := dec.Position()
+
+	startBody := dec.Position()
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case ReflKindText:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case 0:
+		// void case - no data
+
+	case 2:
+		// void case - no data
+
+	default:
+		// unknown key - decode nothing
+
+	}
+	endBody := dec.Position()
+
+	if err := dec.DecodeFixedBytesInto(v.Digest[:]); err != nil {
+		return fmt.Errorf("failed to decode Digest: %w", err)
+	}
+
+	startNames := dec.Position()
+	NamesLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Names length: %w", err)
+	}
+	if NamesLen > 4 {
+		return fmt.Errorf("failed to decode Names: length %d exceeds maximum 4: %w", NamesLen, xdr.ErrInvalidData)
+	}
+	v.Names = make([]string, NamesLen)
+	for i := range v.Names {
+		val, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Names element: %w", err)
+		}
+		v.Names[i] = val
+	}
+	endNames := dec.Position()
+
+	for i := range v.Grid {
+		GridLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Grid length: %w", err)
+		}
+		if GridLen1 > 3 {
+			return fmt.Errorf("failed to decode Grid: length %d exceeds maximum 3: %w", GridLen1, xdr.ErrInvalidData)
+		}
+		v.Grid[i] = make([]uint32, GridLen1)
+		for j := range v.Grid[i] {
+			val, err := dec.DecodeUint32()
+			if err != nil {
+				return fmt.Errorf("failed to decode Grid element: %w", err)
+			}
+			v.Grid[i][j] = val
+		}
+	}
+
+	OptionsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Options length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(OptionsLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Options: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Options = make(map[string]uint32, OptionsLen)
+	for range OptionsLen {
+		key, err := dec.DecodeString()
+		if err != nil {
+			return fmt.Errorf("failed to decode Options key: %w", err)
+		}
+		if _, exists := v.Options[key]; exists {
+			return fmt.Errorf("failed to decode Options key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		value, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Options value: %w", err)
+		}
+		v.Options[key] = value
+
+	}
+
+	MembersLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Members length: %w", err)
+	}
+	// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+	if int(MembersLen) > dec.Remaining()/4 {
+		return fmt.Errorf("failed to decode Members: %w", xdr.ErrUnexpectedEOF)
+	}
+	v.Members = make(map[uint32]struct{}, MembersLen)
+	for range MembersLen {
+		key, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Members key: %w", err)
+		}
+		if _, exists := v.Members[key]; exists {
+			return fmt.Errorf("failed to decode Members key %v: %w", key, xdr.ErrDuplicateKey)
+		}
+
+		v.Members[key] = struct{}{}
+
+	}
+
+	startAttrs := dec.Position()
+
+	// Allocate pointer field before decoding
+	v.Attrs = &ReflAttrs{}
+
+	if err := v.Attrs.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode Attrs: %w", err)
+	}
+
+	endAttrs := dec.Position()
+
+	if err := dec.DecodeOpaque(&v.Wrapped); err != nil {
+		return fmt.Errorf("failed to decode Wrapped: %w", err)
+	}
+
+	tempCreated, err := dec.DecodeTimeNFS()
+	if err != nil {
+		return fmt.Errorf("failed to decode Created: %w", err)
+	}
+	v.Created = tempCreated
+
+	tempTTL, err := dec.DecodeDurationUnixNano()
+	if err != nil {
+		return fmt.Errorf("failed to decode TTL: %w", err)
+	}
+	v.TTL = tempTTL
+
+	if err := dec.SkipReserved(3); err != nil {
+		return fmt.Errorf("failed to decode ReflRecord reserved area: %w", err)
+	}
+
+	tempCRC, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode CRC: %w", err)
+	}
+	v.CRC = tempCRC
+
+	// Length covers Body and Names
+	if sum := xdr.ByteLength(dec.GetSlice(startBody, endBody), dec.GetSlice(startNames, endNames)); sum != tempLength {
+		return fmt.Errorf("%w: Length is %d, computed %d over Body and Names", xdr.ErrChecksumMismatch, tempLength, sum)
+	}
+
+	// CRC covers Kind, Body and Attrs
+	if sum := xdr.ChecksumCRC32(dec.GetSlice(startKind, endKind), dec.GetSlice(startBody, endBody), dec.GetSlice(startAttrs, endAttrs)); sum != tempCRC {
+		return fmt.Errorf("%w: CRC is %#x, computed %#x over Kind, Body and Attrs", xdr.ErrChecksumMismatch, tempCRC, sum)
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ReflRecord)(nil)
+
+func (v *ReflText) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Text); err != nil {
+		return fmt.Errorf("failed to encode Text: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ReflText) Decode(dec *xdr.Decoder) error {
+
+	tempText, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Text: %w", err)
+	}
+	v.Text = tempText
+
+	return nil
+}
+
+// EncodeToUnion encodes ReflText directly to union format
+func (p *ReflText) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeInt32(int32(ReflKindText)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*ReflText)(nil)
+
+func (v *ReflStrict) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeInt32(int32(v.Kind)); err != nil {
+		return fmt.Errorf("failed to encode Kind: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case ReflKindText:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case 0:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Kind=%v", xdr.ErrUnknownDiscriminant, v.Kind)
+
+	}
+
+	if err := enc.EncodeUint32(v.Trail); err != nil {
+		return fmt.Errorf("failed to encode Trail: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ReflStrict) Decode(dec *xdr.Decoder) error {
+
+	tempKind, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Kind: %w", err)
+	}
+	v.Kind = ReflKind(tempKind)
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case ReflKindText:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case 0:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Kind=%v", xdr.ErrUnknownDiscriminant, v.Kind)
+
+	}
+
+	tempTrail, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Trail: %w", err)
+	}
+	v.Trail = tempTrail
+
+	return nil
+}
+
+var _ xdr.Codec = (*ReflStrict)(nil)
+
+// reflStrictBodyReflKindTextArm wraps the ReflKindText arm of ReflStrict
+type reflStrictBodyReflKindTextArm struct {
+	Value string
+}
+
+// BodyReflKindText returns the ReflKindText arm of ReflStrict
+func (v *ReflStrict) BodyReflKindText() (string, error) {
+	var arm reflStrictBodyReflKindTextArm
+	if v.Kind != ReflKindText {
+		return arm.Value, fmt.Errorf("%w: Kind=%v, want ReflKindText", xdr.ErrArmNotSelected, v.Kind)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode ReflKindText arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetBodyReflKindText sets ReflStrict to the ReflKindText arm with the given value
+func (v *ReflStrict) SetBodyReflKindText(val string) error {
+	data, err := xdr.Marshal(&reflStrictBodyReflKindTextArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode ReflKindText arm: %w", err)
+	}
+	v.Kind = ReflKindText
+	v.Body = data
+	return nil
+}
+
+func (v *reflStrictBodyReflKindTextArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *reflStrictBodyReflKindTextArm) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+var _ xdr.Codec = (*reflStrictBodyReflKindTextArm)(nil)
+
+func (v *ReflCaptured) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeInt32(int32(v.Kind)); err != nil {
+		return fmt.Errorf("failed to encode Kind: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case ReflKindText:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case 0:
+		// void case - no data
+
+	case 2:
+		// void case - no data
+
+	default:
+		// unknown key - encode the captured arm
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	}
+
+	if err := enc.EncodeUint32(v.Trail); err != nil {
+		return fmt.Errorf("failed to encode Trail: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ReflCaptured) Decode(dec *xdr.Decoder) error {
+
+	tempKind, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Kind: %w", err)
+	}
+	v.Kind = ReflKind(tempKind)
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case ReflKindText:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case 0:
+		// void case - no data
+
+	case 2:
+		// void case - no data
+
+	default:
+		// unknown key - capture the opaque arm
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	}
+
+	tempTrail, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Trail: %w", err)
+	}
+	v.Trail = tempTrail
+
+	return nil
+}
+
+var _ xdr.Codec = (*ReflCaptured)(nil)
+
+// reflCapturedBodyReflKindTextArm wraps the ReflKindText arm of ReflCaptured
+type reflCapturedBodyReflKindTextArm struct {
+	Value string
+}
+
+// BodyReflKindText returns the ReflKindText arm of ReflCaptured
+func (v *ReflCaptured) BodyReflKindText() (string, error) {
+	var arm reflCapturedBodyReflKindTextArm
+	if v.Kind != ReflKindText {
+		return arm.Value, fmt.Errorf("%w: Kind=%v, want ReflKindText", xdr.ErrArmNotSelected, v.Kind)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode ReflKindText arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetBodyReflKindText sets ReflCaptured to the ReflKindText arm with the given value
+func (v *ReflCaptured) SetBodyReflKindText(val string) error {
+	data, err := xdr.Marshal(&reflCapturedBodyReflKindTextArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode ReflKindText arm: %w", err)
+	}
+	v.Kind = ReflKindText
+	v.Body = data
+	return nil
+}
+
+func (v *reflCapturedBodyReflKindTextArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *reflCapturedBodyReflKindTextArm) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+var _ xdr.Codec = (*reflCapturedBodyReflKindTextArm)(nil)
+
+func (v *ReflEntry) Encode(enc *xdr.Encoder) error {
+
+	// Walk the Next chain iteratively; v is rebound to each node in turn
+	var seen map[*ReflEntry]struct{}
+	if enc.CycleCheck() {
+		seen = make(map[*ReflEntry]struct{})
+	}
+	for v := v; v != nil; v = v.Next {
+		if seen != nil {
+			if _, ok := seen[v]; ok {
+				return fmt.Errorf("failed to encode ReflEntry: %w", xdr.ErrEncodingLoop)
+			}
+			seen[v] = struct{}{}
+		}
+
+		if err := enc.EncodeUint64(v.FileID); err != nil {
+			return fmt.Errorf("failed to encode FileID: %w", err)
+		}
+
+		if err := enc.EncodeBool(v.Next != nil); err != nil {
+			return fmt.Errorf("failed to encode Next: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (v *ReflEntry) Decode(dec *xdr.Decoder) error {
+
+	// Decode the Next chain iteratively; v is rebound to each node in turn
+	for v := v; v != nil; v = v.Next {
+
+		tempFileID, err := dec.DecodeUint64()
+		if err != nil {
+			return fmt.Errorf("failed to decode FileID: %w", err)
+		}
+		v.FileID = tempFileID
+
+		more, err := dec.DecodeBool()
+		if err != nil {
+			return fmt.Errorf("failed to decode Next: %w", err)
+		}
+		v.Next = nil
+		if more {
+			v.Next = &ReflEntry{}
+		}
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ReflEntry)(nil)
+
+func (v *ReflDirList) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeBool(v.Entries != nil); err != nil {
+		return fmt.Errorf("failed to encode Entries: %w", err)
+	}
+	if v.Entries != nil {
+		if err := v.Entries.Encode(enc); err != nil {
+			return fmt.Errorf("failed to encode Entries: %w", err)
+		}
+	}
+
+	if err := enc.EncodeBool(v.EOF); err != nil {
+		return fmt.Errorf("failed to encode EOF: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ReflDirList) Decode(dec *xdr.Decoder) error {
+
+	EntriesPresent, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode Entries: %w", err)
+	}
+	v.Entries = nil
+	if EntriesPresent {
+		v.Entries = &ReflEntry{}
+		if err := v.Entries.Decode(dec); err != nil {
+			return fmt.Errorf("failed to decode Entries: %w", err)
+		}
+	}
+
+	tempEOF, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode EOF: %w", err)
+	}
+	v.EOF = tempEOF
+
+	return nil
+}
+
+var _ xdr.Codec = (*ReflDirList)(nil)
@@ -0,0 +1,128 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestSignedAndBoolDiscriminants tests unions keyed on int32 enums (including negative
+// values) and on bool, as used by "present/absent" style results in .x specs.
+
+//go:generate ../bin/xdrgen $GOFILE
+type SignedStat int32
+
+const (
+	SignedStatOK      SignedStat = 0
+	SignedStatNoEnt   SignedStat = -2
+	SignedStatIOError SignedStat = -5
+)
+
+// +xdr:union,key=Stat,unknown=reject
+type SignedResult struct {
+	Stat SignedStat // discriminant
+	Body []byte     // auto-detected as union payload
+}
+
+// +xdr:payload,union=SignedResult,discriminant=SignedStatOK
+type SignedOKBody struct {
+	Count uint32
+}
+
+// +xdr:payload,union=SignedResult,discriminant=SignedStatIOError
+type SignedIOErrorBody struct {
+	Reason string
+}
+
+// +xdr:union,key=Present
+type OptionalAttr struct {
+	Present bool   // discriminant
+	Attr    []byte // auto-detected as union payload
+}
+
+// +xdr:payload,union=OptionalAttr,discriminant=true
+type OptionalAttrValue struct {
+	Mode uint32
+}
+
+func TestSignedAndBoolDiscriminants(t *testing.T) {
+	t.Run("negative discriminant encodes as int32", func(t *testing.T) {
+		result, err := (&SignedIOErrorBody{Reason: "disk"}).ToUnion()
+		if err != nil {
+			t.Fatalf("ToUnion() failed: %v", err)
+		}
+		data, err := xdr.Marshal(result)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if string(data[:4]) != "\xff\xff\xff\xfb" {
+			t.Errorf("Expected -5 as first word, got %x", data[:4])
+		}
+
+		var decoded SignedResult
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Stat != SignedStatIOError || string(decoded.Body) != string(result.Body) {
+			t.Errorf("Expected %v, got %v", result, decoded)
+		}
+	})
+
+	t.Run("negative void case", func(t *testing.T) {
+		data, err := xdr.Marshal(&SignedResult{Stat: SignedStatNoEnt})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if string(data) != "\xff\xff\xff\xfe" {
+			t.Errorf("Expected only -2 discriminant, got %x", data)
+		}
+	})
+
+	t.Run("unknown signed discriminant rejected", func(t *testing.T) {
+		var decoded SignedResult
+		err := xdr.Unmarshal([]byte{0xff, 0xff, 0xff, 0x9c}, &decoded)
+		if !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+			t.Errorf("Expected ErrUnknownDiscriminant, got %v", err)
+		}
+	})
+
+	t.Run("bool discriminant", func(t *testing.T) {
+		attr, err := (&OptionalAttrValue{Mode: 0o644}).ToUnion()
+		if err != nil {
+			t.Fatalf("ToUnion() failed: %v", err)
+		}
+		data, err := xdr.Marshal(attr)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+
+		var decoded OptionalAttr
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if !decoded.Present || string(decoded.Attr) != string(attr.Attr) {
+			t.Errorf("Expected %v, got %v", attr, decoded)
+		}
+
+		absent, err := xdr.Marshal(&OptionalAttr{Present: false})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if len(absent) != 4 {
+			t.Errorf("Expected 4 bytes for absent value, got %d", len(absent))
+		}
+	})
+
+	t.Run("EncodeToUnion uses discriminant type", func(t *testing.T) {
+		enc := xdr.NewEncoder(make([]byte, 64))
+		if err := (&OptionalAttrValue{Mode: 1}).EncodeToUnion(enc); err != nil {
+			t.Fatalf("EncodeToUnion() failed: %v", err)
+		}
+		if string(enc.Bytes()[:4]) != "\x00\x00\x00\x01" {
+			t.Errorf("Expected true discriminant, got %x", enc.Bytes()[:4])
+		}
+	})
+}
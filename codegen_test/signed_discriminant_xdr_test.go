@@ -0,0 +1,298 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: signed_discriminant_test.go
+// Generated 5 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *SignedResult) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeInt32(int32(v.Stat)); err != nil {
+		return fmt.Errorf("failed to encode Stat: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Stat {
+
+	case SignedStatIOError:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case SignedStatOK:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case SignedStatNoEnt:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Stat=%v", xdr.ErrUnknownDiscriminant, v.Stat)
+
+	}
+
+	return nil
+}
+
+func (v *SignedResult) Decode(dec *xdr.Decoder) error {
+
+	tempStat, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Stat: %w", err)
+	}
+	v.Stat = SignedStat(tempStat)
+
+	// Switch based on key for union field Body
+	switch v.Stat {
+
+	case SignedStatIOError:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case SignedStatOK:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case SignedStatNoEnt:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Stat=%v", xdr.ErrUnknownDiscriminant, v.Stat)
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*SignedResult)(nil)
+
+func (v *SignedOKBody) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Count); err != nil {
+		return fmt.Errorf("failed to encode Count: %w", err)
+	}
+
+	return nil
+}
+
+func (v *SignedOKBody) Decode(dec *xdr.Decoder) error {
+
+	tempCount, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Count: %w", err)
+	}
+	v.Count = tempCount
+
+	return nil
+}
+
+// ToUnion converts SignedOKBody to SignedResult
+func (p *SignedOKBody) ToUnion() (*SignedResult, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode SignedOKBody: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &SignedResult{
+		Stat: SignedStatOK,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes SignedOKBody directly to union format
+func (p *SignedOKBody) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeInt32(int32(SignedStatOK)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*SignedOKBody)(nil)
+
+func (v *SignedIOErrorBody) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(v.Reason); err != nil {
+		return fmt.Errorf("failed to encode Reason: %w", err)
+	}
+
+	return nil
+}
+
+func (v *SignedIOErrorBody) Decode(dec *xdr.Decoder) error {
+
+	tempReason, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Reason: %w", err)
+	}
+	v.Reason = tempReason
+
+	return nil
+}
+
+// ToUnion converts SignedIOErrorBody to SignedResult
+func (p *SignedIOErrorBody) ToUnion() (*SignedResult, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode SignedIOErrorBody: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &SignedResult{
+		Stat: SignedStatIOError,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes SignedIOErrorBody directly to union format
+func (p *SignedIOErrorBody) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeInt32(int32(SignedStatIOError)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*SignedIOErrorBody)(nil)
+
+func (v *OptionalAttr) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeBool(v.Present); err != nil {
+		return fmt.Errorf("failed to encode Present: %w", err)
+	}
+
+	// Switch based on key for union field Attr
+	switch v.Present {
+
+	case true:
+		if err := enc.EncodeBytes(v.Attr); err != nil {
+			return fmt.Errorf("failed to encode Attr: %w", err)
+		}
+
+	case false:
+		// void case - no data
+
+	default:
+		// unknown key - encode nothing
+
+	}
+
+	return nil
+}
+
+func (v *OptionalAttr) Decode(dec *xdr.Decoder) error {
+
+	tempPresent, err := dec.DecodeBool()
+	if err != nil {
+		return fmt.Errorf("failed to decode Present: %w", err)
+	}
+	v.Present = tempPresent
+
+	// Switch based on key for union field Attr
+	switch v.Present {
+
+	case true:
+		var err error
+		v.Attr, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Attr: %w", err)
+		}
+
+	case false:
+		// void case - no data
+
+	default:
+		// unknown key - decode nothing
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*OptionalAttr)(nil)
+
+func (v *OptionalAttrValue) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Mode); err != nil {
+		return fmt.Errorf("failed to encode Mode: %w", err)
+	}
+
+	return nil
+}
+
+func (v *OptionalAttrValue) Decode(dec *xdr.Decoder) error {
+
+	tempMode, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Mode: %w", err)
+	}
+	v.Mode = tempMode
+
+	return nil
+}
+
+// ToUnion converts OptionalAttrValue to OptionalAttr
+func (p *OptionalAttrValue) ToUnion() (*OptionalAttr, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode OptionalAttrValue: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &OptionalAttr{
+		Present: true,
+		Attr:    data,
+	}, nil
+}
+
+// EncodeToUnion encodes OptionalAttrValue directly to union format
+func (p *OptionalAttrValue) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeBool(bool(true)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*OptionalAttrValue)(nil)
@@ -0,0 +1,133 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestTimeFields tests time.Time and time.Duration fields with the nfs, timespec
+// and unixnano wire layouts selected via xdr:"time=layout".
+
+//go:generate ../bin/xdrgen $GOFILE
+type TimeStamp time.Time
+
+// +xdr:generate
+type TimeAttr struct {
+	Atime time.Time     `xdr:"time=nfs"`
+	Mtime time.Time     // timespec by default
+	Ctime time.Time     `xdr:"time=unixnano"`
+	Birth TimeStamp     `xdr:"time=nfs"`
+	TTL   time.Duration // unixnano by default
+	Grace time.Duration `xdr:"time=nfs"`
+	Skew  time.Duration `xdr:"time=timespec"`
+}
+
+// +xdr:generate
+type TimeLog struct {
+	Stamps []time.Time       `xdr:"time=nfs"`
+	Window [2]time.Duration  // unixnano by default
+	Births []TimeStamp       `xdr:"max=4"`
+	Slots  [][]time.Duration `xdr:"time=nfs"`
+}
+
+// timeLogPlain has the fields of TimeLog without its generated methods
+type timeLogPlain TimeLog
+
+func TestTimeFields(t *testing.T) {
+	stamp := time.Date(2024, time.March, 9, 12, 30, 45, 500, time.UTC)
+	attr := &TimeAttr{
+		Atime: stamp,
+		Mtime: stamp.Add(time.Hour),
+		Ctime: stamp.Add(2 * time.Hour),
+		Birth: TimeStamp(stamp.Add(-time.Hour)),
+		TTL:   90 * time.Second,
+		Grace: 1500 * time.Millisecond,
+		Skew:  -250 * time.Millisecond,
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := xdr.Marshal(attr)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		// nfs 8 + timespec 12 + unixnano 8 + nfs 8 + unixnano 8 + nfs 8 + timespec 12
+		if len(data) != 64 {
+			t.Errorf("Expected 64 bytes, got %d", len(data))
+		}
+
+		var decoded TimeAttr
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if !decoded.Atime.Equal(attr.Atime) || !decoded.Mtime.Equal(attr.Mtime) || !decoded.Ctime.Equal(attr.Ctime) {
+			t.Errorf("Time mismatch: got %v %v %v", decoded.Atime, decoded.Mtime, decoded.Ctime)
+		}
+		if !time.Time(decoded.Birth).Equal(time.Time(attr.Birth)) {
+			t.Errorf("Expected birth %v, got %v", time.Time(attr.Birth), time.Time(decoded.Birth))
+		}
+		if decoded.TTL != attr.TTL || decoded.Grace != attr.Grace || decoded.Skew != attr.Skew {
+			t.Errorf("Duration mismatch: got %v %v %v", decoded.TTL, decoded.Grace, decoded.Skew)
+		}
+	})
+
+	t.Run("nfs layout bytes", func(t *testing.T) {
+		data, err := xdr.Marshal(attr)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		dec := xdr.NewDecoder(data)
+		sec, _ := dec.DecodeUint32()
+		nsec, _ := dec.DecodeUint32()
+		if int64(sec) != stamp.Unix() || nsec != 500 {
+			t.Errorf("Expected nfstime3 {%d, 500}, got {%d, %d}", stamp.Unix(), sec, nsec)
+		}
+	})
+
+	t.Run("arrays of time values", func(t *testing.T) {
+		log := &TimeLog{
+			Stamps: []time.Time{stamp, stamp.Add(time.Second)},
+			Window: [2]time.Duration{time.Second, time.Minute},
+			Births: []TimeStamp{TimeStamp(stamp)},
+			Slots:  [][]time.Duration{{time.Millisecond}, {}},
+		}
+		data, err := xdr.Marshal(log)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		// 4+2*8 nfs stamps, 2*8 unixnano window, 4+12 timespec birth, 4+4+8+4 nfs slots
+		if len(data) != 72 {
+			t.Errorf("Expected 72 bytes, got %d", len(data))
+		}
+
+		var decoded TimeLog
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if len(decoded.Stamps) != 2 || !decoded.Stamps[1].Equal(log.Stamps[1]) || decoded.Window != log.Window {
+			t.Errorf("Expected %v %v, got %v %v", log.Stamps, log.Window, decoded.Stamps, decoded.Window)
+		}
+		if !time.Time(decoded.Births[0]).Equal(stamp) || decoded.Slots[0][0] != time.Millisecond {
+			t.Errorf("Expected %v %v, got %v %v", stamp, log.Slots, time.Time(decoded.Births[0]), decoded.Slots)
+		}
+
+		reflected, err := xdr.MarshalReflect((*timeLogPlain)(log))
+		if err != nil {
+			t.Fatalf("MarshalReflect() failed: %v", err)
+		}
+		if string(reflected) != string(data) {
+			t.Errorf("MarshalReflect() = %x, generated %x", reflected, data)
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := xdr.Marshal(&TimeAttr{Atime: time.Date(1960, time.January, 1, 0, 0, 0, 0, time.UTC), Ctime: stamp})
+		if !errors.Is(err, xdr.ErrOverflow) {
+			t.Errorf("Expected ErrOverflow for pre-epoch nfs time, got %v", err)
+		}
+	})
+}
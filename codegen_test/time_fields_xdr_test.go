@@ -0,0 +1,210 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: time_fields_test.go
+// Generated 2 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+
+	"time"
+)
+
+func (v *TimeAttr) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeTimeNFS(v.Atime); err != nil {
+		return fmt.Errorf("failed to encode Atime: %w", err)
+	}
+
+	if err := enc.EncodeTimeTimespec(v.Mtime); err != nil {
+		return fmt.Errorf("failed to encode Mtime: %w", err)
+	}
+
+	if err := enc.EncodeTimeUnixNano(v.Ctime); err != nil {
+		return fmt.Errorf("failed to encode Ctime: %w", err)
+	}
+
+	if err := enc.EncodeTimeNFS(time.Time(v.Birth)); err != nil {
+		return fmt.Errorf("failed to encode Birth: %w", err)
+	}
+
+	if err := enc.EncodeDurationUnixNano(v.TTL); err != nil {
+		return fmt.Errorf("failed to encode TTL: %w", err)
+	}
+
+	if err := enc.EncodeDurationNFS(v.Grace); err != nil {
+		return fmt.Errorf("failed to encode Grace: %w", err)
+	}
+
+	if err := enc.EncodeDurationTimespec(v.Skew); err != nil {
+		return fmt.Errorf("failed to encode Skew: %w", err)
+	}
+
+	return nil
+}
+
+func (v *TimeAttr) Decode(dec *xdr.Decoder) error {
+
+	tempAtime, err := dec.DecodeTimeNFS()
+	if err != nil {
+		return fmt.Errorf("failed to decode Atime: %w", err)
+	}
+	v.Atime = tempAtime
+
+	tempMtime, err := dec.DecodeTimeTimespec()
+	if err != nil {
+		return fmt.Errorf("failed to decode Mtime: %w", err)
+	}
+	v.Mtime = tempMtime
+
+	tempCtime, err := dec.DecodeTimeUnixNano()
+	if err != nil {
+		return fmt.Errorf("failed to decode Ctime: %w", err)
+	}
+	v.Ctime = tempCtime
+
+	tempBirth, err := dec.DecodeTimeNFS()
+	if err != nil {
+		return fmt.Errorf("failed to decode Birth: %w", err)
+	}
+	v.Birth = TimeStamp(tempBirth)
+
+	tempTTL, err := dec.DecodeDurationUnixNano()
+	if err != nil {
+		return fmt.Errorf("failed to decode TTL: %w", err)
+	}
+	v.TTL = tempTTL
+
+	tempGrace, err := dec.DecodeDurationNFS()
+	if err != nil {
+		return fmt.Errorf("failed to decode Grace: %w", err)
+	}
+	v.Grace = tempGrace
+
+	tempSkew, err := dec.DecodeDurationTimespec()
+	if err != nil {
+		return fmt.Errorf("failed to decode Skew: %w", err)
+	}
+	v.Skew = tempSkew
+
+	return nil
+}
+
+var _ xdr.Codec = (*TimeAttr)(nil)
+
+func (v *TimeLog) Encode(enc *xdr.Encoder) error {
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Stamps))); err != nil {
+		return fmt.Errorf("failed to encode Stamps length: %w", err)
+	}
+	for i := range v.Stamps {
+		if err := enc.EncodeTimeNFS(v.Stamps[i]); err != nil {
+			return fmt.Errorf("failed to encode Stamps element: %w", err)
+		}
+	}
+
+	for i := range v.Window {
+		if err := enc.EncodeDurationUnixNano(v.Window[i]); err != nil {
+			return fmt.Errorf("failed to encode Window element: %w", err)
+		}
+	}
+
+	if len(v.Births) > 4 {
+		return fmt.Errorf("failed to encode Births: length %d exceeds maximum 4: %w", len(v.Births), xdr.ErrInvalidData)
+	}
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Births))); err != nil {
+		return fmt.Errorf("failed to encode Births length: %w", err)
+	}
+	for i := range v.Births {
+		if err := enc.EncodeTimeTimespec(time.Time(v.Births[i])); err != nil {
+			return fmt.Errorf("failed to encode Births element: %w", err)
+		}
+	}
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Slots))); err != nil {
+		return fmt.Errorf("failed to encode Slots length: %w", err)
+	}
+	for i := range v.Slots {
+		// #nosec G115
+		if err := enc.EncodeUint32(uint32(len(v.Slots[i]))); err != nil {
+			return fmt.Errorf("failed to encode Slots length: %w", err)
+		}
+		for j := range v.Slots[i] {
+			if err := enc.EncodeDurationNFS(v.Slots[i][j]); err != nil {
+				return fmt.Errorf("failed to encode Slots element: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *TimeLog) Decode(dec *xdr.Decoder) error {
+
+	StampsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Stamps length: %w", err)
+	}
+	v.Stamps = make([]time.Time, StampsLen)
+	for i := range v.Stamps {
+		val, err := dec.DecodeTimeNFS()
+		if err != nil {
+			return fmt.Errorf("failed to decode Stamps element: %w", err)
+		}
+		v.Stamps[i] = val
+	}
+
+	for i := range v.Window {
+		val, err := dec.DecodeDurationUnixNano()
+		if err != nil {
+			return fmt.Errorf("failed to decode Window element: %w", err)
+		}
+		v.Window[i] = val
+	}
+
+	BirthsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Births length: %w", err)
+	}
+	if BirthsLen > 4 {
+		return fmt.Errorf("failed to decode Births: length %d exceeds maximum 4: %w", BirthsLen, xdr.ErrInvalidData)
+	}
+	v.Births = make([]TimeStamp, BirthsLen)
+	for i := range v.Births {
+		val, err := dec.DecodeTimeTimespec()
+		if err != nil {
+			return fmt.Errorf("failed to decode Births element: %w", err)
+		}
+		v.Births[i] = TimeStamp(val)
+	}
+
+	SlotsLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Slots length: %w", err)
+	}
+	v.Slots = make([][]time.Duration, SlotsLen)
+	for i := range v.Slots {
+		SlotsLen1, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode Slots length: %w", err)
+		}
+		v.Slots[i] = make([]time.Duration, SlotsLen1)
+		for j := range v.Slots[i] {
+			val, err := dec.DecodeDurationNFS()
+			if err != nil {
+				return fmt.Errorf("failed to decode Slots element: %w", err)
+			}
+			v.Slots[i][j] = val
+		}
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*TimeLog)(nil)
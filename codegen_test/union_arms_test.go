@@ -0,0 +1,120 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestUnionArms tests unions whose arms are primitives, aliases or arrays declared
+// with +xdr:arm instead of one-field payload structs.
+
+//go:generate ../bin/xdrgen $GOFILE
+type ArmStat uint32
+
+const (
+	ArmStatOK      ArmStat = 0
+	ArmStatData    ArmStat = 1
+	ArmStatHandle  ArmStat = 2
+	ArmStatName    ArmStat = 3
+	ArmStatList    ArmStat = 4
+	ArmStatNoSpace ArmStat = 5
+)
+
+type ArmFilename string
+
+// +xdr:arm,union=ArmResult,discriminant=ArmStatOK,type=uint64
+// +xdr:arm,union=ArmResult,discriminant=ArmStatData,type=[]byte
+// +xdr:arm,union=ArmResult,discriminant=ArmStatHandle,type=[8]byte
+// +xdr:arm,union=ArmResult,discriminant=ArmStatName,type=ArmFilename
+// +xdr:arm,union=ArmResult,discriminant=ArmStatList,type=[]uint32
+
+// +xdr:union,key=Stat,unknown=reject
+type ArmResult struct {
+	Stat ArmStat // discriminant
+	Body []byte  // auto-detected as union payload
+}
+
+func TestUnionArms(t *testing.T) {
+	roundTrip := func(t *testing.T, result *ArmResult) *ArmResult {
+		t.Helper()
+		data, err := xdr.Marshal(result)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		var decoded ArmResult
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		return &decoded
+	}
+
+	t.Run("uint64 arm", func(t *testing.T) {
+		var result ArmResult
+		if err := result.SetArmStatOK(1 << 40); err != nil {
+			t.Fatalf("SetArmStatOK() failed: %v", err)
+		}
+		size, err := roundTrip(t, &result).ArmStatOK()
+		if err != nil || size != 1<<40 {
+			t.Errorf("Expected %d, got %d (%v)", uint64(1<<40), size, err)
+		}
+	})
+
+	t.Run("opaque arm", func(t *testing.T) {
+		var result ArmResult
+		if err := result.SetArmStatData([]byte("hello")); err != nil {
+			t.Fatalf("SetArmStatData() failed: %v", err)
+		}
+		data, err := roundTrip(t, &result).ArmStatData()
+		if err != nil || string(data) != "hello" {
+			t.Errorf("Expected hello, got %q (%v)", data, err)
+		}
+	})
+
+	t.Run("fixed array arm", func(t *testing.T) {
+		var result ArmResult
+		handle := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+		if err := result.SetArmStatHandle(handle); err != nil {
+			t.Fatalf("SetArmStatHandle() failed: %v", err)
+		}
+		got, err := roundTrip(t, &result).ArmStatHandle()
+		if err != nil || got != handle {
+			t.Errorf("Expected %v, got %v (%v)", handle, got, err)
+		}
+	})
+
+	t.Run("alias arm", func(t *testing.T) {
+		var result ArmResult
+		if err := result.SetArmStatName("notes.txt"); err != nil {
+			t.Fatalf("SetArmStatName() failed: %v", err)
+		}
+		name, err := roundTrip(t, &result).ArmStatName()
+		if err != nil || name != "notes.txt" {
+			t.Errorf("Expected notes.txt, got %q (%v)", name, err)
+		}
+	})
+
+	t.Run("slice arm", func(t *testing.T) {
+		var result ArmResult
+		if err := result.SetArmStatList([]uint32{3, 1, 4}); err != nil {
+			t.Fatalf("SetArmStatList() failed: %v", err)
+		}
+		list, err := roundTrip(t, &result).ArmStatList()
+		if err != nil || len(list) != 3 || list[2] != 4 {
+			t.Errorf("Expected [3 1 4], got %v (%v)", list, err)
+		}
+	})
+
+	t.Run("void case and wrong arm", func(t *testing.T) {
+		result := roundTrip(t, &ArmResult{Stat: ArmStatNoSpace})
+		if result.Body != nil {
+			t.Errorf("Expected no body for void case, got %v", result.Body)
+		}
+		if _, err := result.ArmStatOK(); !errors.Is(err, xdr.ErrArmNotSelected) {
+			t.Errorf("Expected ErrArmNotSelected, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,381 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: union_arms_test.go
+// Generated 6 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *ArmResult) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(uint32(v.Stat)); err != nil {
+		return fmt.Errorf("failed to encode Stat: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Stat {
+
+	case ArmStatData:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case ArmStatHandle:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case ArmStatList:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case ArmStatName:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case ArmStatOK:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case ArmStatNoSpace:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Stat=%v", xdr.ErrUnknownDiscriminant, v.Stat)
+
+	}
+
+	return nil
+}
+
+func (v *ArmResult) Decode(dec *xdr.Decoder) error {
+
+	tempStat, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Stat: %w", err)
+	}
+	v.Stat = ArmStat(tempStat)
+
+	// Switch based on key for union field Body
+	switch v.Stat {
+
+	case ArmStatData:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case ArmStatHandle:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case ArmStatList:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case ArmStatName:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case ArmStatOK:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case ArmStatNoSpace:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Stat=%v", xdr.ErrUnknownDiscriminant, v.Stat)
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ArmResult)(nil)
+
+// armResultArmStatDataArm wraps the ArmStatData arm of ArmResult
+type armResultArmStatDataArm struct {
+	Value []byte
+}
+
+// ArmStatData returns the ArmStatData arm of ArmResult
+func (v *ArmResult) ArmStatData() ([]byte, error) {
+	var arm armResultArmStatDataArm
+	if v.Stat != ArmStatData {
+		return arm.Value, fmt.Errorf("%w: Stat=%v, want ArmStatData", xdr.ErrArmNotSelected, v.Stat)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode ArmStatData arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetArmStatData sets ArmResult to the ArmStatData arm with the given value
+func (v *ArmResult) SetArmStatData(val []byte) error {
+	data, err := xdr.Marshal(&armResultArmStatDataArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode ArmStatData arm: %w", err)
+	}
+	v.Stat = ArmStatData
+	v.Body = data
+	return nil
+}
+
+func (v *armResultArmStatDataArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeBytes(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *armResultArmStatDataArm) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+var _ xdr.Codec = (*armResultArmStatDataArm)(nil)
+
+// armResultArmStatHandleArm wraps the ArmStatHandle arm of ArmResult
+type armResultArmStatHandleArm struct {
+	Value [8]byte
+}
+
+// ArmStatHandle returns the ArmStatHandle arm of ArmResult
+func (v *ArmResult) ArmStatHandle() ([8]byte, error) {
+	var arm armResultArmStatHandleArm
+	if v.Stat != ArmStatHandle {
+		return arm.Value, fmt.Errorf("%w: Stat=%v, want ArmStatHandle", xdr.ErrArmNotSelected, v.Stat)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode ArmStatHandle arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetArmStatHandle sets ArmResult to the ArmStatHandle arm with the given value
+func (v *ArmResult) SetArmStatHandle(val [8]byte) error {
+	data, err := xdr.Marshal(&armResultArmStatHandleArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode ArmStatHandle arm: %w", err)
+	}
+	v.Stat = ArmStatHandle
+	v.Body = data
+	return nil
+}
+
+func (v *armResultArmStatHandleArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeFixedBytes(v.Value[:]); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *armResultArmStatHandleArm) Decode(dec *xdr.Decoder) error {
+
+	if err := dec.DecodeFixedBytesInto(v.Value[:]); err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*armResultArmStatHandleArm)(nil)
+
+// armResultArmStatListArm wraps the ArmStatList arm of ArmResult
+type armResultArmStatListArm struct {
+	Value []uint32
+}
+
+// ArmStatList returns the ArmStatList arm of ArmResult
+func (v *ArmResult) ArmStatList() ([]uint32, error) {
+	var arm armResultArmStatListArm
+	if v.Stat != ArmStatList {
+		return arm.Value, fmt.Errorf("%w: Stat=%v, want ArmStatList", xdr.ErrArmNotSelected, v.Stat)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode ArmStatList arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetArmStatList sets ArmResult to the ArmStatList arm with the given value
+func (v *ArmResult) SetArmStatList(val []uint32) error {
+	data, err := xdr.Marshal(&armResultArmStatListArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode ArmStatList arm: %w", err)
+	}
+	v.Stat = ArmStatList
+	v.Body = data
+	return nil
+}
+
+func (v *armResultArmStatListArm) Encode(enc *xdr.Encoder) error {
+
+	// #nosec G115
+	if err := enc.EncodeUint32(uint32(len(v.Value))); err != nil {
+		return fmt.Errorf("failed to encode Value length: %w", err)
+	}
+	for _, elem := range v.Value {
+
+		if err := enc.EncodeUint32(elem); err != nil {
+			return fmt.Errorf("failed to encode element: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *armResultArmStatListArm) Decode(dec *xdr.Decoder) error {
+
+	ValueLen, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value length: %w", err)
+	}
+	v.Value = make([]uint32, ValueLen)
+	for i := range v.Value {
+
+		val, err := dec.DecodeUint32()
+		if err != nil {
+			return fmt.Errorf("failed to decode element: %w", err)
+		}
+		v.Value[i] = val
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*armResultArmStatListArm)(nil)
+
+// armResultArmStatNameArm wraps the ArmStatName arm of ArmResult
+type armResultArmStatNameArm struct {
+	Value ArmFilename
+}
+
+// ArmStatName returns the ArmStatName arm of ArmResult
+func (v *ArmResult) ArmStatName() (ArmFilename, error) {
+	var arm armResultArmStatNameArm
+	if v.Stat != ArmStatName {
+		return arm.Value, fmt.Errorf("%w: Stat=%v, want ArmStatName", xdr.ErrArmNotSelected, v.Stat)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode ArmStatName arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetArmStatName sets ArmResult to the ArmStatName arm with the given value
+func (v *ArmResult) SetArmStatName(val ArmFilename) error {
+	data, err := xdr.Marshal(&armResultArmStatNameArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode ArmStatName arm: %w", err)
+	}
+	v.Stat = ArmStatName
+	v.Body = data
+	return nil
+}
+
+func (v *armResultArmStatNameArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeString(string(v.Value)); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *armResultArmStatNameArm) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeString()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = ArmFilename(tempValue)
+
+	return nil
+}
+
+var _ xdr.Codec = (*armResultArmStatNameArm)(nil)
+
+// armResultArmStatOKArm wraps the ArmStatOK arm of ArmResult
+type armResultArmStatOKArm struct {
+	Value uint64
+}
+
+// ArmStatOK returns the ArmStatOK arm of ArmResult
+func (v *ArmResult) ArmStatOK() (uint64, error) {
+	var arm armResultArmStatOKArm
+	if v.Stat != ArmStatOK {
+		return arm.Value, fmt.Errorf("%w: Stat=%v, want ArmStatOK", xdr.ErrArmNotSelected, v.Stat)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode ArmStatOK arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetArmStatOK sets ArmResult to the ArmStatOK arm with the given value
+func (v *ArmResult) SetArmStatOK(val uint64) error {
+	data, err := xdr.Marshal(&armResultArmStatOKArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode ArmStatOK arm: %w", err)
+	}
+	v.Stat = ArmStatOK
+	v.Body = data
+	return nil
+}
+
+func (v *armResultArmStatOKArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *armResultArmStatOKArm) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+var _ xdr.Codec = (*armResultArmStatOKArm)(nil)
@@ -25,6 +25,9 @@ func (v *TestResult) Encode(enc *xdr.Encoder) error {
 			return fmt.Errorf("failed to encode Data: %w", err)
 		}
 
+	case TestStatusError:
+		// void case - no data
+
 	default:
 		// unknown key - encode nothing
 
@@ -51,6 +54,9 @@ func (v *TestResult) Decode(dec *xdr.Decoder) error {
 			return fmt.Errorf("failed to decode Data: %w", err)
 		}
 
+	case TestStatusError:
+		// void case - no data
+
 	default:
 		// unknown key - decode nothing
 
@@ -129,6 +135,9 @@ func (v *TestMessage) Encode(enc *xdr.Encoder) error {
 			return fmt.Errorf("failed to encode Payload: %w", err)
 		}
 
+	case TestMsgTypeVoid:
+		// void case - no data
+
 	default:
 		// unknown key - encode nothing
 
@@ -155,6 +164,9 @@ func (v *TestMessage) Decode(dec *xdr.Decoder) error {
 			return fmt.Errorf("failed to decode Payload: %w", err)
 		}
 
+	case TestMsgTypeVoid:
+		// void case - no data
+
 	default:
 		// unknown key - decode nothing
 
@@ -233,6 +245,9 @@ func (v *TestOperation) Encode(enc *xdr.Encoder) error {
 			return fmt.Errorf("failed to encode Data: %w", err)
 		}
 
+	case TestOpWrite:
+		// void case - no data
+
 	default:
 		// unknown key - encode nothing
 
@@ -259,6 +274,9 @@ func (v *TestOperation) Decode(dec *xdr.Decoder) error {
 			return fmt.Errorf("failed to decode Data: %w", err)
 		}
 
+	case TestOpWrite:
+		// void case - no data
+
 	default:
 		// unknown key - decode nothing
 
@@ -0,0 +1,128 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestUnionMux tests the typed xdr.Mux generated for a union container with
+// +xdr:union,mux: payload, arm and void cases each get a Handle method.
+
+//go:generate ../bin/xdrgen $GOFILE
+
+type MuxOp int32
+
+const (
+	MuxOpNull  MuxOp = 0
+	MuxOpRead  MuxOp = 1
+	MuxOpSize  MuxOp = 2
+	MuxOpWrite MuxOp = 3
+	MuxOpFsync MuxOp = -1
+)
+
+// +xdr:arm,union=MuxRequest,discriminant=MuxOpSize,type=uint64
+
+// +xdr:union,key=Op,mux
+type MuxRequest struct {
+	Op   MuxOp
+	Body []byte
+}
+
+// +xdr:payload,union=MuxRequest,discriminant=MuxOpRead
+type MuxReadArgs struct {
+	Offset uint64
+	Count  uint32
+}
+
+// +xdr:payload,union=MuxRequest,discriminant=MuxOpWrite
+type MuxWriteArgs struct {
+	Offset uint64
+	Data   []byte
+}
+
+func TestUnionMux(t *testing.T) {
+	enc := xdr.NewEncoder(make([]byte, 256))
+	read, err := (&MuxReadArgs{Offset: 8, Count: 512}).ToUnion()
+	if err != nil {
+		t.Fatalf("ToUnion() failed: %v", err)
+	}
+	var size MuxRequest
+	if err := size.SetMuxOpSize(4096); err != nil {
+		t.Fatalf("SetMuxOpSize() failed: %v", err)
+	}
+	write, err := (&MuxWriteArgs{Data: []byte("abc")}).ToUnion()
+	if err != nil {
+		t.Fatalf("ToUnion() failed: %v", err)
+	}
+	for _, req := range []*MuxRequest{{Op: MuxOpNull}, read, &size, write, {Op: MuxOpFsync}} {
+		if err := req.Encode(enc); err != nil {
+			t.Fatalf("Encode() failed: %v", err)
+		}
+	}
+
+	var got []string
+	mux := NewMuxRequestMux()
+	mux.HandleMuxOpNull(func() error {
+		got = append(got, "null")
+		return nil
+	})
+	mux.HandleMuxOpRead(func(args *MuxReadArgs) error {
+		if args.Offset != 8 || args.Count != 512 {
+			t.Errorf("Expected read at 8 of 512, got %+v", args)
+		}
+		got = append(got, "read")
+		return nil
+	})
+	mux.HandleMuxOpSize(func(size uint64) error {
+		if size != 4096 {
+			t.Errorf("Expected size 4096, got %d", size)
+		}
+		got = append(got, "size")
+		return nil
+	})
+	mux.HandleMuxOpFsync(func() error {
+		got = append(got, "fsync")
+		return nil
+	})
+	mux.HandleUnknown(func(op MuxOp, dec *xdr.Decoder) error {
+		// Unhandled requests still carry their opaque payload
+		if _, err := dec.DecodeBytes(); err != nil {
+			return err
+		}
+		got = append(got, "unhandled")
+		return nil
+	})
+
+	dec := xdr.NewDecoder(enc.Bytes())
+	for dec.Remaining() > 0 {
+		if err := mux.Dispatch(dec); err != nil {
+			t.Fatalf("Dispatch() failed: %v", err)
+		}
+	}
+	want := []string{"null", "read", "size", "unhandled", "fsync"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, got)
+			break
+		}
+	}
+
+	t.Run("handler error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		mux := NewMuxRequestMux()
+		mux.HandleMuxOpNull(func() error { return errStop })
+		if err := mux.Dispatch(xdr.NewDecoder([]byte{0, 0, 0, 0})); !errors.Is(err, errStop) {
+			t.Errorf("Expected the handler's error, got %v", err)
+		}
+		if err := mux.Dispatch(xdr.NewDecoder([]byte{0, 0, 0, 3})); !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+			t.Errorf("Expected ErrUnknownDiscriminant without a handler, got %v", err)
+		}
+	})
+}
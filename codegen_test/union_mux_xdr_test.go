@@ -0,0 +1,314 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: union_mux_test.go
+// Generated 4 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *MuxRequest) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeInt32(int32(v.Op)); err != nil {
+		return fmt.Errorf("failed to encode Op: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Op {
+
+	case MuxOpRead:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case MuxOpSize:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case MuxOpWrite:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case MuxOpFsync:
+		// void case - no data
+
+	case MuxOpNull:
+		// void case - no data
+
+	default:
+		// unknown key - encode nothing
+
+	}
+
+	return nil
+}
+
+func (v *MuxRequest) Decode(dec *xdr.Decoder) error {
+
+	tempOp, err := dec.DecodeInt32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Op: %w", err)
+	}
+	v.Op = MuxOp(tempOp)
+
+	// Switch based on key for union field Body
+	switch v.Op {
+
+	case MuxOpRead:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case MuxOpSize:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case MuxOpWrite:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case MuxOpFsync:
+		// void case - no data
+
+	case MuxOpNull:
+		// void case - no data
+
+	default:
+		// unknown key - decode nothing
+
+	}
+
+	return nil
+}
+
+// MuxRequestMux dispatches MuxRequest messages to a handler per discriminant
+// Discriminants without a handler go to the HandleUnknown fallback
+type MuxRequestMux struct {
+	*xdr.Mux[MuxOp]
+}
+
+// NewMuxRequestMux returns a MuxRequestMux with no handlers
+func NewMuxRequestMux() *MuxRequestMux {
+	return &MuxRequestMux{Mux: xdr.NewMux[MuxOp]()}
+}
+
+// HandleMuxOpFsync registers the handler for MuxOpFsync, which carries no payload
+func (m *MuxRequestMux) HandleMuxOpFsync(handler func() error) {
+	xdr.HandleVoid(m.Mux, MuxOpFsync, handler)
+}
+
+// HandleMuxOpNull registers the handler for MuxOpNull, which carries no payload
+func (m *MuxRequestMux) HandleMuxOpNull(handler func() error) {
+	xdr.HandleVoid(m.Mux, MuxOpNull, handler)
+}
+
+// HandleMuxOpRead registers the handler for MuxOpRead, which carries a MuxReadArgs
+func (m *MuxRequestMux) HandleMuxOpRead(handler func(*MuxReadArgs) error) {
+	xdr.Handle(m.Mux, MuxOpRead, handler)
+}
+
+// HandleMuxOpSize registers the handler for MuxOpSize, which carries a uint64
+func (m *MuxRequestMux) HandleMuxOpSize(handler func(uint64) error) {
+	xdr.Handle(m.Mux, MuxOpSize, func(arm *muxRequestMuxOpSizeArm) error {
+		return handler(arm.Value)
+	})
+}
+
+// HandleMuxOpWrite registers the handler for MuxOpWrite, which carries a MuxWriteArgs
+func (m *MuxRequestMux) HandleMuxOpWrite(handler func(*MuxWriteArgs) error) {
+	xdr.Handle(m.Mux, MuxOpWrite, handler)
+}
+
+var _ xdr.Codec = (*MuxRequest)(nil)
+
+// muxRequestMuxOpSizeArm wraps the MuxOpSize arm of MuxRequest
+type muxRequestMuxOpSizeArm struct {
+	Value uint64
+}
+
+// MuxOpSize returns the MuxOpSize arm of MuxRequest
+func (v *MuxRequest) MuxOpSize() (uint64, error) {
+	var arm muxRequestMuxOpSizeArm
+	if v.Op != MuxOpSize {
+		return arm.Value, fmt.Errorf("%w: Op=%v, want MuxOpSize", xdr.ErrArmNotSelected, v.Op)
+	}
+	if err := xdr.Unmarshal(v.Body, &arm); err != nil {
+		return arm.Value, fmt.Errorf("failed to decode MuxOpSize arm: %w", err)
+	}
+	return arm.Value, nil
+}
+
+// SetMuxOpSize sets MuxRequest to the MuxOpSize arm with the given value
+func (v *MuxRequest) SetMuxOpSize(val uint64) error {
+	data, err := xdr.Marshal(&muxRequestMuxOpSizeArm{Value: val})
+	if err != nil {
+		return fmt.Errorf("failed to encode MuxOpSize arm: %w", err)
+	}
+	v.Op = MuxOpSize
+	v.Body = data
+	return nil
+}
+
+func (v *muxRequestMuxOpSizeArm) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *muxRequestMuxOpSizeArm) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+var _ xdr.Codec = (*muxRequestMuxOpSizeArm)(nil)
+
+func (v *MuxReadArgs) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Offset); err != nil {
+		return fmt.Errorf("failed to encode Offset: %w", err)
+	}
+
+	if err := enc.EncodeUint32(v.Count); err != nil {
+		return fmt.Errorf("failed to encode Count: %w", err)
+	}
+
+	return nil
+}
+
+func (v *MuxReadArgs) Decode(dec *xdr.Decoder) error {
+
+	tempOffset, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Offset: %w", err)
+	}
+	v.Offset = tempOffset
+
+	tempCount, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Count: %w", err)
+	}
+	v.Count = tempCount
+
+	return nil
+}
+
+// ToUnion converts MuxReadArgs to MuxRequest
+func (p *MuxReadArgs) ToUnion() (*MuxRequest, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode MuxReadArgs: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &MuxRequest{
+		Op:   MuxOpRead,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes MuxReadArgs directly to union format
+func (p *MuxReadArgs) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeInt32(int32(MuxOpRead)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*MuxReadArgs)(nil)
+
+func (v *MuxWriteArgs) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint64(v.Offset); err != nil {
+		return fmt.Errorf("failed to encode Offset: %w", err)
+	}
+
+	if err := enc.EncodeBytes(v.Data); err != nil {
+		return fmt.Errorf("failed to encode Data: %w", err)
+	}
+
+	return nil
+}
+
+func (v *MuxWriteArgs) Decode(dec *xdr.Decoder) error {
+
+	tempOffset, err := dec.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("failed to decode Offset: %w", err)
+	}
+	v.Offset = tempOffset
+
+	tempData, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Data: %w", err)
+	}
+	v.Data = tempData
+
+	return nil
+}
+
+// ToUnion converts MuxWriteArgs to MuxRequest
+func (p *MuxWriteArgs) ToUnion() (*MuxRequest, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode MuxWriteArgs: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &MuxRequest{
+		Op:   MuxOpWrite,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes MuxWriteArgs directly to union format
+func (p *MuxWriteArgs) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeInt32(int32(MuxOpWrite)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*MuxWriteArgs)(nil)
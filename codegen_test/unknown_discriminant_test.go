@@ -0,0 +1,161 @@
+//go:build ignore
+
+package codegen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tempusfrangit/go-xdr"
+)
+
+// TestUnknownDiscriminantPolicies tests the void, reject and capture policies
+// for discriminants that have no payload, void case or default.
+
+//go:generate ../bin/xdrgen $GOFILE
+type UnknownKind uint32
+
+const (
+	UnknownKindData UnknownKind = 1
+	UnknownKindPing UnknownKind = 2
+)
+
+// +xdr:union,key=Kind,unknown=void
+type UnknownVoidFrame struct {
+	Kind UnknownKind // discriminant
+	Body []byte      // auto-detected as union payload
+}
+
+// +xdr:union,key=Kind,unknown=reject
+type UnknownRejectFrame struct {
+	Kind UnknownKind // discriminant
+	Body []byte      // auto-detected as union payload
+}
+
+// +xdr:union,key=Kind,unknown=capture
+type UnknownCaptureFrame struct {
+	Kind UnknownKind // discriminant
+	Body []byte      // auto-detected as union payload
+}
+
+// +xdr:payload,union=UnknownVoidFrame,discriminant=UnknownKindData
+type UnknownVoidData struct {
+	Value uint32
+}
+
+// +xdr:payload,union=UnknownRejectFrame,discriminant=UnknownKindData
+type UnknownRejectData struct {
+	Value uint32
+}
+
+// +xdr:payload,union=UnknownCaptureFrame,discriminant=UnknownKindData
+type UnknownCaptureData struct {
+	Value uint32
+}
+
+// unknownFrameBytes is an encoded frame with discriminant 99 and a four byte opaque arm
+var unknownFrameBytes = []byte{
+	0x00, 0x00, 0x00, 0x63,
+	0x00, 0x00, 0x00, 0x04,
+	0xde, 0xad, 0xbe, 0xef,
+}
+
+func TestUnknownDiscriminantPolicies(t *testing.T) {
+	t.Run("void ignores unknown payload", func(t *testing.T) {
+		var frame UnknownVoidFrame
+		if err := xdr.Unmarshal(unknownFrameBytes, &frame); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if frame.Kind != 99 || frame.Body != nil {
+			t.Errorf("Expected Kind=99 with no body, got %v %v", frame.Kind, frame.Body)
+		}
+	})
+
+	t.Run("void cases still round-trip", func(t *testing.T) {
+		for _, frame := range []xdr.Codec{
+			&UnknownVoidFrame{Kind: UnknownKindPing},
+			&UnknownRejectFrame{Kind: UnknownKindPing},
+			&UnknownCaptureFrame{Kind: UnknownKindPing},
+		} {
+			data, err := xdr.Marshal(frame)
+			if err != nil {
+				t.Fatalf("Marshal() failed: %v", err)
+			}
+			if len(data) != 4 {
+				t.Errorf("Expected 4 bytes for void case, got %d", len(data))
+			}
+			if err := xdr.Unmarshal(data, frame); err != nil {
+				t.Fatalf("Unmarshal() failed: %v", err)
+			}
+		}
+	})
+
+	t.Run("reject errors on decode and encode", func(t *testing.T) {
+		var frame UnknownRejectFrame
+		err := xdr.Unmarshal(unknownFrameBytes, &frame)
+		if !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+			t.Errorf("Expected ErrUnknownDiscriminant on decode, got %v", err)
+		}
+
+		_, err = xdr.Marshal(&UnknownRejectFrame{Kind: 99})
+		if !errors.Is(err, xdr.ErrUnknownDiscriminant) {
+			t.Errorf("Expected ErrUnknownDiscriminant on encode, got %v", err)
+		}
+	})
+
+	t.Run("capture round-trips unknown payload", func(t *testing.T) {
+		var frame UnknownCaptureFrame
+		if err := xdr.Unmarshal(unknownFrameBytes, &frame); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if frame.Kind != 99 || string(frame.Body) != "\xde\xad\xbe\xef" {
+			t.Fatalf("Expected Kind=99 with the captured arm, got %v %x", frame.Kind, frame.Body)
+		}
+
+		data, err := xdr.Marshal(&frame)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		if string(data) != string(unknownFrameBytes) {
+			t.Errorf("Expected %x, got %x", unknownFrameBytes, data)
+		}
+	})
+
+	t.Run("capture leaves following frames in the stream", func(t *testing.T) {
+		ping, err := xdr.Marshal(&UnknownCaptureFrame{Kind: UnknownKindPing})
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+		dec := xdr.NewDecoder(append(append([]byte{}, unknownFrameBytes...), ping...))
+
+		var first, second UnknownCaptureFrame
+		if err := first.Decode(dec); err != nil {
+			t.Fatalf("Decode() of captured frame failed: %v", err)
+		}
+		if err := second.Decode(dec); err != nil {
+			t.Fatalf("Decode() of following frame failed: %v", err)
+		}
+		if second.Kind != UnknownKindPing || dec.Remaining() != 0 {
+			t.Errorf("Expected the ping frame to follow, got %v with %d bytes left", second.Kind, dec.Remaining())
+		}
+	})
+
+	t.Run("known payload unaffected by policy", func(t *testing.T) {
+		frame, err := (&UnknownCaptureData{Value: 7}).ToUnion()
+		if err != nil {
+			t.Fatalf("ToUnion() failed: %v", err)
+		}
+		data, err := xdr.Marshal(frame)
+		if err != nil {
+			t.Fatalf("Marshal() failed: %v", err)
+		}
+
+		var decoded UnknownCaptureFrame
+		if err := xdr.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal() failed: %v", err)
+		}
+		if decoded.Kind != UnknownKindData || string(decoded.Body) != string(frame.Body) {
+			t.Errorf("Expected %v, got %v", frame, decoded)
+		}
+	})
+}
@@ -0,0 +1,350 @@
+//go:build ignore
+
+// Code generated by xdrgen. DO NOT EDIT.
+// Source: unknown_discriminant_test.go
+// Generated 6 XDR types
+
+package codegen_test
+
+import (
+	"fmt"
+	"github.com/tempusfrangit/go-xdr"
+)
+
+func (v *UnknownVoidFrame) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(uint32(v.Kind)); err != nil {
+		return fmt.Errorf("failed to encode Kind: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case UnknownKindData:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case UnknownKindPing:
+		// void case - no data
+
+	default:
+		// unknown key - encode nothing
+
+	}
+
+	return nil
+}
+
+func (v *UnknownVoidFrame) Decode(dec *xdr.Decoder) error {
+
+	tempKind, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Kind: %w", err)
+	}
+	v.Kind = UnknownKind(tempKind)
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case UnknownKindData:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case UnknownKindPing:
+		// void case - no data
+
+	default:
+		// unknown key - decode nothing
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*UnknownVoidFrame)(nil)
+
+func (v *UnknownRejectFrame) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(uint32(v.Kind)); err != nil {
+		return fmt.Errorf("failed to encode Kind: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case UnknownKindData:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case UnknownKindPing:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Kind=%v", xdr.ErrUnknownDiscriminant, v.Kind)
+
+	}
+
+	return nil
+}
+
+func (v *UnknownRejectFrame) Decode(dec *xdr.Decoder) error {
+
+	tempKind, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Kind: %w", err)
+	}
+	v.Kind = UnknownKind(tempKind)
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case UnknownKindData:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case UnknownKindPing:
+		// void case - no data
+
+	default:
+		return fmt.Errorf("%w: Kind=%v", xdr.ErrUnknownDiscriminant, v.Kind)
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*UnknownRejectFrame)(nil)
+
+func (v *UnknownCaptureFrame) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(uint32(v.Kind)); err != nil {
+		return fmt.Errorf("failed to encode Kind: %w", err)
+	}
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case UnknownKindData:
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	case UnknownKindPing:
+		// void case - no data
+
+	default:
+		// unknown key - encode the captured arm
+		if err := enc.EncodeBytes(v.Body); err != nil {
+			return fmt.Errorf("failed to encode Body: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+func (v *UnknownCaptureFrame) Decode(dec *xdr.Decoder) error {
+
+	tempKind, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Kind: %w", err)
+	}
+	v.Kind = UnknownKind(tempKind)
+
+	// Switch based on key for union field Body
+	switch v.Kind {
+
+	case UnknownKindData:
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	case UnknownKindPing:
+		// void case - no data
+
+	default:
+		// unknown key - capture the opaque arm
+		var err error
+		v.Body, err = dec.DecodeBytes()
+		if err != nil {
+			return fmt.Errorf("failed to decode Body: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*UnknownCaptureFrame)(nil)
+
+func (v *UnknownVoidData) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *UnknownVoidData) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+// ToUnion converts UnknownVoidData to UnknownVoidFrame
+func (p *UnknownVoidData) ToUnion() (*UnknownVoidFrame, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode UnknownVoidData: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &UnknownVoidFrame{
+		Kind: UnknownKindData,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes UnknownVoidData directly to union format
+func (p *UnknownVoidData) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeUint32(uint32(UnknownKindData)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*UnknownVoidData)(nil)
+
+func (v *UnknownRejectData) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *UnknownRejectData) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+// ToUnion converts UnknownRejectData to UnknownRejectFrame
+func (p *UnknownRejectData) ToUnion() (*UnknownRejectFrame, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode UnknownRejectData: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &UnknownRejectFrame{
+		Kind: UnknownKindData,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes UnknownRejectData directly to union format
+func (p *UnknownRejectData) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeUint32(uint32(UnknownKindData)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*UnknownRejectData)(nil)
+
+func (v *UnknownCaptureData) Encode(enc *xdr.Encoder) error {
+
+	if err := enc.EncodeUint32(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+
+	return nil
+}
+
+func (v *UnknownCaptureData) Decode(dec *xdr.Decoder) error {
+
+	tempValue, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+
+	return nil
+}
+
+// ToUnion converts UnknownCaptureData to UnknownCaptureFrame
+func (p *UnknownCaptureData) ToUnion() (*UnknownCaptureFrame, error) {
+	buf := make([]byte, 1024) // Initial buffer size
+	enc := xdr.NewEncoder(buf)
+	if err := p.Encode(enc); err != nil {
+		return nil, fmt.Errorf("failed to encode UnknownCaptureData: %w", err)
+	}
+	data := enc.Bytes()
+
+	return &UnknownCaptureFrame{
+		Kind: UnknownKindData,
+		Body: data,
+	}, nil
+}
+
+// EncodeToUnion encodes UnknownCaptureData directly to union format
+func (p *UnknownCaptureData) EncodeToUnion(enc *xdr.Encoder) error {
+
+	// Encode discriminant
+	if err := enc.EncodeUint32(uint32(UnknownKindData)); err != nil {
+		return fmt.Errorf("failed to encode discriminant: %w", err)
+	}
+
+	// Encode payload
+	if err := p.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return nil
+
+}
+
+var _ xdr.Codec = (*UnknownCaptureData)(nil)
@@ -0,0 +1,158 @@
+package xdr
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Envelopes make messages self-describing so heterogeneous messages can share a stream:
+// each is written as EnvelopeMagic, the type ID and schema version its type was
+// registered with, and the message as variable-length opaque data. Readers allocate the
+// registered type for the ID, so producers and consumers only need to agree on IDs.
+
+// EnvelopeMagic starts every envelope ("XDRE")
+const EnvelopeMagic uint32 = 0x58445245
+
+// Envelope is an encoded message with its type ID and schema version
+type Envelope struct {
+	TypeID  uint32
+	Version uint32
+	Body    []byte
+}
+
+// Encode encodes the envelope header followed by the body as opaque data
+func (e *Envelope) Encode(enc *Encoder) error {
+	if err := enc.EncodeUint32(EnvelopeMagic); err != nil {
+		return err
+	}
+	if err := enc.EncodeUint32(e.TypeID); err != nil {
+		return err
+	}
+	if err := enc.EncodeUint32(e.Version); err != nil {
+		return err
+	}
+	return enc.EncodeBytes(e.Body)
+}
+
+// Decode decodes an envelope without decoding its body
+func (e *Envelope) Decode(dec *Decoder) error {
+	magic, err := dec.DecodeUint32()
+	if err != nil {
+		return err
+	}
+	if magic != EnvelopeMagic {
+		return fmt.Errorf("%w: envelope magic is %#x, expected %#x", ErrInvalidData, magic, EnvelopeMagic)
+	}
+	if e.TypeID, err = dec.DecodeUint32(); err != nil {
+		return err
+	}
+	if e.Version, err = dec.DecodeUint32(); err != nil {
+		return err
+	}
+	e.Body, err = dec.DecodeBytes()
+	return err
+}
+
+var _ Codec = (*Envelope)(nil)
+
+// envelopes holds the types registered with Register and the schema version of each
+var envelopes = struct {
+	types    *TypeRegistry
+	mu       sync.RWMutex
+	versions map[uint32]uint32
+}{
+	types:    NewTypeRegistry(),
+	versions: make(map[uint32]uint32),
+}
+
+// Register registers the message type *T under a type ID for MarshalAny and UnmarshalAny
+// version is the schema version written with T; envelopes with a newer version are rejected
+func Register[T any, PT interface {
+	*T
+	Codec
+}](id, version uint32) error {
+	if err := envelopes.types.Register(id, func() Codec { return PT(new(T)) }); err != nil {
+		return err
+	}
+	envelopes.mu.Lock()
+	envelopes.versions[id] = version
+	envelopes.mu.Unlock()
+	return nil
+}
+
+// MustRegister is like Register but panics on error, for use in init functions
+func MustRegister[T any, PT interface {
+	*T
+	Codec
+}](id, version uint32) {
+	if err := Register[T, PT](id, version); err != nil {
+		panic(err)
+	}
+}
+
+// EncodeAny encodes v in an envelope with the type ID and version of its registered type
+func EncodeAny(enc *Encoder, v Codec) error {
+	id, err := envelopes.types.TypeID(v)
+	if err != nil {
+		return err
+	}
+	envelopes.mu.RLock()
+	version := envelopes.versions[id]
+	envelopes.mu.RUnlock()
+
+	if err := enc.EncodeUint32(EnvelopeMagic); err != nil {
+		return err
+	}
+	if err := enc.EncodeUint32(id); err != nil {
+		return err
+	}
+	if err := enc.EncodeUint32(version); err != nil {
+		return err
+	}
+	return enc.EncodeOpaque(v)
+}
+
+// DecodeAny decodes an envelope into a new value of the type registered for its type ID
+// The whole envelope is consumed even when its type is unknown, so a stream can skip it
+func DecodeAny(dec *Decoder) (Codec, error) {
+	var envelope Envelope
+	if err := envelope.Decode(dec); err != nil {
+		return nil, err
+	}
+	v, err := envelopes.types.New(envelope.TypeID)
+	if err != nil {
+		return nil, err
+	}
+	envelopes.mu.RLock()
+	version := envelopes.versions[envelope.TypeID]
+	envelopes.mu.RUnlock()
+	if envelope.Version > version {
+		return nil, fmt.Errorf("%w: %v version %d is newer than %d", ErrInvalidData, reflect.TypeOf(v), envelope.Version, version)
+	}
+	if err := dec.decodeWithin(envelope.Body, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// MarshalAny encodes v in an envelope
+func MarshalAny(v Codec) ([]byte, error) {
+	buf := make([]byte, 512)
+	enc := NewEncoder(buf)
+	if err := EncodeAny(enc, v); err != nil {
+		return nil, fmt.Errorf("XDR encoding failed: %w", err)
+	}
+	result := make([]byte, len(enc.Bytes()))
+	copy(result, enc.Bytes())
+	return result, nil
+}
+
+// UnmarshalAny decodes an envelope into a new value of its registered type
+func UnmarshalAny(data []byte) (Codec, error) {
+	v, err := DecodeAny(NewDecoder(data))
+	if err != nil {
+		return nil, fmt.Errorf("XDR decoding failed: %w", err)
+	}
+	return v, nil
+}
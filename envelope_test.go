@@ -0,0 +1,90 @@
+package xdr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	MustRegister[TestType](1001, 2)
+	MustRegister[NestedStruct](1002, 1)
+}
+
+func TestEnvelope(t *testing.T) {
+	data, err := MarshalAny(&TestType{ID: 7, Name: "ab"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x58, 0x44, 0x52, 0x45,
+		0, 0, 0x03, 0xe9,
+		0, 0, 0, 2,
+		0, 0, 0, 12,
+		0, 0, 0, 7, 0, 0, 0, 2, 'a', 'b', 0, 0,
+	}, data, "Envelopes should be magic, type ID, version and opaque body")
+
+	v, err := UnmarshalAny(data)
+	require.NoError(t, err)
+	assert.Equal(t, &TestType{ID: 7, Name: "ab"}, v)
+
+	var envelope Envelope
+	require.NoError(t, Unmarshal(data, &envelope))
+	assert.Equal(t, uint32(1001), envelope.TypeID)
+	assert.Equal(t, uint32(2), envelope.Version)
+	assert.Len(t, envelope.Body, 12)
+	reencoded, err := Marshal(&envelope)
+	require.NoError(t, err)
+	assert.Equal(t, data, reencoded)
+}
+
+func TestEnvelopeStream(t *testing.T) {
+	enc := NewEncoder(make([]byte, 256))
+	require.NoError(t, EncodeAny(enc, &TestType{ID: 1, Name: "first"}))
+	require.NoError(t, (&Envelope{TypeID: 9999, Version: 1, Body: []byte{1, 2, 3, 4}}).Encode(enc))
+	require.NoError(t, EncodeAny(enc, &NestedStruct{Inner: TestType{ID: 2}, Count: 3}))
+
+	dec := NewDecoder(enc.Bytes())
+	v, err := DecodeAny(dec)
+	require.NoError(t, err)
+	assert.Equal(t, &TestType{ID: 1, Name: "first"}, v)
+
+	_, err = DecodeAny(dec)
+	assert.ErrorIs(t, err, ErrUnregisteredType, "Unknown types should fail after consuming the envelope")
+
+	v, err = DecodeAny(dec)
+	require.NoError(t, err)
+	assert.Equal(t, &NestedStruct{Inner: TestType{ID: 2}, Count: 3}, v)
+	assert.Equal(t, 0, dec.Remaining())
+}
+
+func TestEnvelopeErrors(t *testing.T) {
+	t.Run("registration", func(t *testing.T) {
+		assert.Error(t, Register[TestType](1003, 1), "Types should be registered once")
+		assert.Error(t, Register[Envelope](1001, 1), "IDs should be unique")
+	})
+
+	t.Run("unregistered type", func(t *testing.T) {
+		_, err := MarshalAny(&Envelope{})
+		assert.ErrorIs(t, err, ErrUnregisteredType)
+	})
+
+	t.Run("bad magic", func(t *testing.T) {
+		_, err := UnmarshalAny([]byte{0, 0, 0, 0, 0, 0, 0x03, 0xe9, 0, 0, 0, 1, 0, 0, 0, 0})
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+
+	t.Run("newer version", func(t *testing.T) {
+		data, err := MarshalAny(&NestedStruct{})
+		require.NoError(t, err)
+		data[11] = 2
+		_, err = UnmarshalAny(data)
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+
+	t.Run("body not consumed", func(t *testing.T) {
+		data, err := Marshal(&Envelope{TypeID: 1002, Version: 1, Body: make([]byte, 32)})
+		require.NoError(t, err)
+		_, err = UnmarshalAny(data)
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+}
@@ -18,6 +18,15 @@ func (v *OperationResult) Encode(enc *xdr.Encoder) error {
 	// Switch based on key for union field Data
 	switch v.Status {
 
+	case StatusError:
+		// void case - no data
+
+	case StatusPending:
+		// void case - no data
+
+	case StatusSuccess:
+		// void case - no data
+
 	default:
 		// unknown key - encode nothing
 
@@ -37,6 +46,15 @@ func (v *OperationResult) Decode(dec *xdr.Decoder) error {
 	// Switch based on key for union field Data
 	switch v.Status {
 
+	case StatusError:
+		// void case - no data
+
+	case StatusPending:
+		// void case - no data
+
+	case StatusSuccess:
+		// void case - no data
+
 	default:
 		// unknown key - decode nothing
 
@@ -88,8 +106,11 @@ func (v *NetworkMessage) Encode(enc *xdr.Encoder) error {
 			return fmt.Errorf("failed to encode Payload: %w", err)
 		}
 
+	case MessageTypeVoid:
+		// void case - no data
+
 	default:
-		// unknown key - encode nothing
+		// void case - no data
 
 	}
 
@@ -121,8 +142,11 @@ func (v *NetworkMessage) Decode(dec *xdr.Decoder) error {
 			return fmt.Errorf("failed to decode Payload: %w", err)
 		}
 
+	case MessageTypeVoid:
+		// void case - no data
+
 	default:
-		// unknown key - decode nothing
+		// void case - no data
 
 	}
 
@@ -268,6 +292,15 @@ func (v *FileOperation) Encode(enc *xdr.Encoder) error {
 	// Switch based on key for union field Result
 	switch v.OpType {
 
+	case OpTypeDelete:
+		// void case - no data
+
+	case OpTypeRead:
+		// void case - no data
+
+	case OpTypeWrite:
+		// void case - no data
+
 	default:
 		// unknown key - encode nothing
 
@@ -287,6 +320,15 @@ func (v *FileOperation) Decode(dec *xdr.Decoder) error {
 	// Switch based on key for union field Result
 	switch v.OpType {
 
+	case OpTypeDelete:
+		// void case - no data
+
+	case OpTypeRead:
+		// void case - no data
+
+	case OpTypeWrite:
+		// void case - no data
+
 	default:
 		// unknown key - decode nothing
 
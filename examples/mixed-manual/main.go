@@ -10,8 +10,8 @@ import (
 func main() {
 	fmt.Println("=== Mixed Manual XDR Encoding/Decoding Example ===")
 
-	// Example 1: Structure with manual XDR implementation
-	fmt.Println("\n1. Custom XDR implementation...")
+	// Example 1: Generated structure with a constant magic number
+	fmt.Println("\n1. Generated magic number...")
 
 	custom := &CustomMessage{
 		Header: MessageHeader{
@@ -24,7 +24,7 @@ func main() {
 
 	fmt.Printf("Original custom message: %+v\n", custom)
 
-	// Marshal using custom implementation
+	// Marshal writes 0xDEADBEEF between header and body
 	data, err := xdr.Marshal(custom)
 	if err != nil {
 		log.Fatal(err)
@@ -32,7 +32,7 @@ func main() {
 
 	fmt.Printf("Marshaled (%d bytes): %x\n", len(data), data)
 
-	// Unmarshal using custom implementation
+	// Unmarshal fails unless the magic number matches
 	var decoded CustomMessage
 	err = xdr.Unmarshal(data, &decoded)
 	if err != nil {
@@ -71,12 +71,11 @@ func main() {
 
 	fmt.Printf("Unmarshaled mixed: %+v\n", decodedMixed)
 
-	// Example 3: Manual override with validation
-	fmt.Println("\n3. Manual override with validation...")
+	// Example 3: Generated checksum validation
+	fmt.Println("\n3. Generated checksum validation...")
 
 	validated := &ValidatedData{
-		Value:    42,
-		Checksum: 0, // Will be calculated during marshaling
+		Value: 42, // Checksum is calculated during marshaling
 	}
 
 	fmt.Printf("Original validated data: %+v\n", validated)
@@ -3,9 +3,7 @@ package main
 //go:generate ../../bin/xdrgen $GOFILE
 
 import (
-	"encoding/binary"
 	"fmt"
-	"hash/crc32"
 
 	"github.com/tempusfrangit/go-xdr"
 )
@@ -18,59 +16,13 @@ type MessageHeader struct {
 	Timestamp uint32
 }
 
-// CustomMessage demonstrates fully manual XDR implementation
+// CustomMessage is generated: the magic number between header and body comes from
+// its const tag and is verified on decode
+// +xdr:generate
 type CustomMessage struct {
-	Header MessageHeader `xdr:"-"`
-	Body   []byte        `xdr:"-"`
-}
-
-// Encode implements custom XDR marshaling
-func (c *CustomMessage) Encode(enc *xdr.Encoder) error {
-	// Manual encoding with custom logic
-	if err := enc.EncodeUint32(c.Header.Version); err != nil {
-		return err
-	}
-	if err := enc.EncodeUint32(c.Header.MessageID); err != nil {
-		return err
-	}
-	if err := enc.EncodeUint32(c.Header.Timestamp); err != nil {
-		return err
-	}
-
-	// Custom encoding: prefix body with magic number
-	if err := enc.EncodeUint32(0xDEADBEEF); err != nil {
-		return err
-	}
-
-	return enc.EncodeBytes(c.Body)
-}
-
-// Decode implements custom XDR unmarshaling
-func (c *CustomMessage) Decode(dec *xdr.Decoder) error {
-	var err error
-
-	// Manual decoding
-	if c.Header.Version, err = dec.DecodeUint32(); err != nil {
-		return err
-	}
-	if c.Header.MessageID, err = dec.DecodeUint32(); err != nil {
-		return err
-	}
-	if c.Header.Timestamp, err = dec.DecodeUint32(); err != nil {
-		return err
-	}
-
-	// Check for magic number
-	magic, err := dec.DecodeUint32()
-	if err != nil {
-		return err
-	}
-	if magic != 0xDEADBEEF {
-		return fmt.Errorf("invalid magic number: 0x%08x", magic)
-	}
-
-	c.Body, err = dec.DecodeBytes()
-	return err
+	Header MessageHeader
+	_      uint32 `xdr:"const=0xDEADBEEF"`
+	Body   []byte
 }
 
 // MixedStruct combines auto-generated and manual XDR
@@ -128,47 +80,12 @@ func (m *MixedStruct) Decode(dec *xdr.Decoder) error {
 	return m.Header.Decode(dec)
 }
 
-// ValidatedData demonstrates manual override with validation
+// ValidatedData is generated: Checksum is a CRC32 of the encoded Value, computed on
+// encode and verified on decode
+// +xdr:generate
 type ValidatedData struct {
-	Value    uint32 `xdr:"-"`
-	Checksum uint32 `xdr:"-"`
-}
-
-// Encode implements marshaling with checksum calculation
-func (v *ValidatedData) Encode(enc *xdr.Encoder) error {
-	// Calculate checksum before marshaling
-	data := make([]byte, 4)
-	binary.BigEndian.PutUint32(data, v.Value)
-	v.Checksum = crc32.ChecksumIEEE(data)
-
-	if err := enc.EncodeUint32(v.Value); err != nil {
-		return err
-	}
-	return enc.EncodeUint32(v.Checksum)
-}
-
-// Decode implements unmarshaling with checksum validation
-func (v *ValidatedData) Decode(dec *xdr.Decoder) error {
-	var err error
-
-	if v.Value, err = dec.DecodeUint32(); err != nil {
-		return err
-	}
-	if v.Checksum, err = dec.DecodeUint32(); err != nil {
-		return err
-	}
-
-	// Validate checksum
-	data := make([]byte, 4)
-	binary.BigEndian.PutUint32(data, v.Value)
-	expectedChecksum := crc32.ChecksumIEEE(data)
-
-	if v.Checksum != expectedChecksum {
-		return fmt.Errorf("checksum validation failed: expected 0x%08x, got 0x%08x",
-			expectedChecksum, v.Checksum)
-	}
-
-	return nil
+	Value    uint32
+	Checksum uint32 `xdr:"crc32=Value"`
 }
 
 // TimestampMessage demonstrates custom encoding for special types
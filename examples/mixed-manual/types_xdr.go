@@ -1,6 +1,6 @@
 // Code generated by xdrgen. DO NOT EDIT.
 // Source: types.go
-// Generated 1 XDR types
+// Generated 3 XDR types
 
 package main
 
@@ -50,3 +50,92 @@ func (v *MessageHeader) Decode(dec *xdr.Decoder) error {
 }
 
 var _ xdr.Codec = (*MessageHeader)(nil)
+
+func (v *CustomMessage) Encode(enc *xdr.Encoder) error {
+
+	if err := v.Header.Encode(enc); err != nil {
+		return fmt.Errorf("failed to encode Header: %w", err)
+	}
+
+	if err := enc.EncodeUint32(0xDEADBEEF); err != nil {
+		return fmt.Errorf("failed to encode CustomMessage constant: %w", err)
+	}
+
+	if err := enc.EncodeBytes(v.Body); err != nil {
+		return fmt.Errorf("failed to encode Body: %w", err)
+	}
+
+	return nil
+}
+
+func (v *CustomMessage) Decode(dec *xdr.Decoder) error {
+
+	if err := v.Header.Decode(dec); err != nil {
+		return fmt.Errorf("failed to decode Header: %w", err)
+	}
+
+	blank1, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode CustomMessage constant: %w", err)
+	}
+	if blank1 != 0xDEADBEEF {
+		return fmt.Errorf("%w: CustomMessage constant is %#x, expected 0xDEADBEEF", xdr.ErrInvalidData, blank1)
+	}
+
+	tempBody, err := dec.DecodeBytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode Body: %w", err)
+	}
+	v.Body = tempBody
+
+	return nil
+}
+
+var _ xdr.Codec = (*CustomMessage)(nil)
+
+func (v *ValidatedData) Encode(enc *xdr.Encoder) error {
+
+	startValue := enc.Len()
+	if err := enc.EncodeUint32(v.Value); err != nil {
+		return fmt.Errorf("failed to encode Value: %w", err)
+	}
+	endValue := enc.Len()
+
+	posChecksum := enc.Len()
+	if err := enc.EncodeUint32(0); err != nil {
+		return fmt.Errorf("failed to encode Checksum: %w", err)
+	}
+
+	// Checksum covers Value, filled in now that it is encoded
+	if err := enc.PatchUint32(posChecksum, xdr.ChecksumCRC32(enc.Bytes()[startValue:endValue])); err != nil {
+		return fmt.Errorf("failed to encode Checksum: %w", err)
+	}
+
+	return nil
+}
+
+func (v *ValidatedData) Decode(dec *xdr.Decoder) error {
+
+	startValue := dec.Position()
+	tempValue, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Value: %w", err)
+	}
+	v.Value = tempValue
+	endValue := dec.Position()
+
+	tempChecksum, err := dec.DecodeUint32()
+	if err != nil {
+		return fmt.Errorf("failed to decode Checksum: %w", err)
+	}
+	v.Checksum = tempChecksum
+
+	// Checksum covers Value
+	if sum := xdr.ChecksumCRC32(dec.GetSlice(startValue, endValue)); sum != tempChecksum {
+		return fmt.Errorf("%w: Checksum is %#x, computed %#x over Value", xdr.ErrChecksumMismatch, tempChecksum, sum)
+	}
+
+	return nil
+}
+
+var _ xdr.Codec = (*ValidatedData)(nil)
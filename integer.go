@@ -0,0 +1,118 @@
+package xdr
+
+import "math"
+
+// XDR has no integers narrower than 32 bits: uint8, uint16, int8 and int16 are widened
+// to an unsigned or signed int, and int and uint to a hyper. Decoding checks that the
+// wire value fits the Go type and returns ErrOverflow when it does not.
+
+// EncodeUint8 encodes a uint8 as a 32-bit unsigned integer
+func (e *Encoder) EncodeUint8(v uint8) error {
+	return e.EncodeUint32(uint32(v))
+}
+
+// EncodeUint16 encodes a uint16 as a 32-bit unsigned integer
+func (e *Encoder) EncodeUint16(v uint16) error {
+	return e.EncodeUint32(uint32(v))
+}
+
+// EncodeInt8 encodes an int8 as a 32-bit signed integer
+func (e *Encoder) EncodeInt8(v int8) error {
+	return e.EncodeInt32(int32(v))
+}
+
+// EncodeInt16 encodes an int16 as a 32-bit signed integer
+func (e *Encoder) EncodeInt16(v int16) error {
+	return e.EncodeInt32(int32(v))
+}
+
+// EncodeInt encodes an int as a 64-bit signed integer
+func (e *Encoder) EncodeInt(v int) error {
+	return e.EncodeInt64(int64(v))
+}
+
+// EncodeUint encodes a uint as a 64-bit unsigned integer
+func (e *Encoder) EncodeUint(v uint) error {
+	// #nosec G115
+	return e.EncodeUint64(uint64(v))
+}
+
+// DecodeUint8 decodes a 32-bit unsigned integer into a uint8
+func (d *Decoder) DecodeUint8() (uint8, error) {
+	v, err := d.DecodeUint32()
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint8 {
+		return 0, ErrOverflow
+	}
+	// #nosec G115
+	return uint8(v), nil
+}
+
+// DecodeUint16 decodes a 32-bit unsigned integer into a uint16
+func (d *Decoder) DecodeUint16() (uint16, error) {
+	v, err := d.DecodeUint32()
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint16 {
+		return 0, ErrOverflow
+	}
+	// #nosec G115
+	return uint16(v), nil
+}
+
+// DecodeInt8 decodes a 32-bit signed integer into an int8
+func (d *Decoder) DecodeInt8() (int8, error) {
+	v, err := d.DecodeInt32()
+	if err != nil {
+		return 0, err
+	}
+	if v < math.MinInt8 || v > math.MaxInt8 {
+		return 0, ErrOverflow
+	}
+	// #nosec G115
+	return int8(v), nil
+}
+
+// DecodeInt16 decodes a 32-bit signed integer into an int16
+func (d *Decoder) DecodeInt16() (int16, error) {
+	v, err := d.DecodeInt32()
+	if err != nil {
+		return 0, err
+	}
+	if v < math.MinInt16 || v > math.MaxInt16 {
+		return 0, ErrOverflow
+	}
+	// #nosec G115
+	return int16(v), nil
+}
+
+// DecodeInt decodes a 64-bit signed integer into an int
+// Values outside the 32-bit range overflow on 32-bit platforms
+func (d *Decoder) DecodeInt() (int, error) {
+	v, err := d.DecodeInt64()
+	if err != nil {
+		return 0, err
+	}
+	if v < math.MinInt || v > math.MaxInt {
+		return 0, ErrOverflow
+	}
+	// #nosec G115
+	return int(v), nil
+}
+
+// DecodeUint decodes a 64-bit unsigned integer into a uint
+// Values outside the 32-bit range overflow on 32-bit platforms
+func (d *Decoder) DecodeUint() (uint, error) {
+	v, err := d.DecodeUint64()
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint {
+		return 0, ErrOverflow
+	}
+	// #nosec G115
+	return uint(v), nil
+}
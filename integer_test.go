@@ -0,0 +1,69 @@
+package xdr
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNarrowIntegers(t *testing.T) {
+	enc := NewEncoder(make([]byte, 64))
+	require.NoError(t, enc.EncodeUint8(math.MaxUint8))
+	require.NoError(t, enc.EncodeUint16(math.MaxUint16))
+	require.NoError(t, enc.EncodeInt8(math.MinInt8))
+	require.NoError(t, enc.EncodeInt16(-2))
+	require.NoError(t, enc.EncodeInt(math.MinInt32))
+	require.NoError(t, enc.EncodeUint(math.MaxUint32))
+	assert.Equal(t, []byte{
+		0, 0, 0, 0xff,
+		0, 0, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0x80,
+		0xff, 0xff, 0xff, 0xfe,
+		0xff, 0xff, 0xff, 0xff, 0x80, 0, 0, 0,
+		0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff,
+	}, enc.Bytes(), "Narrow integers should widen to int/unsigned int and int/uint to hyper")
+
+	dec := NewDecoder(enc.Bytes())
+	u8, err := dec.DecodeUint8()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(math.MaxUint8), u8)
+	u16, err := dec.DecodeUint16()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(math.MaxUint16), u16)
+	i8, err := dec.DecodeInt8()
+	require.NoError(t, err)
+	assert.Equal(t, int8(math.MinInt8), i8)
+	i16, err := dec.DecodeInt16()
+	require.NoError(t, err)
+	assert.Equal(t, int16(-2), i16)
+	i, err := dec.DecodeInt()
+	require.NoError(t, err)
+	assert.Equal(t, math.MinInt32, i)
+	u, err := dec.DecodeUint()
+	require.NoError(t, err)
+	assert.Equal(t, uint(math.MaxUint32), u)
+}
+
+func TestNarrowIntegerOverflow(t *testing.T) {
+	wire := func(encode func(*Encoder) error) *Decoder {
+		enc := NewEncoder(make([]byte, 8))
+		require.NoError(t, encode(enc))
+		return NewDecoder(enc.Bytes())
+	}
+
+	_, err := wire(func(e *Encoder) error { return e.EncodeUint32(math.MaxUint8 + 1) }).DecodeUint8()
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = wire(func(e *Encoder) error { return e.EncodeUint32(math.MaxUint16 + 1) }).DecodeUint16()
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = wire(func(e *Encoder) error { return e.EncodeInt32(math.MaxInt8 + 1) }).DecodeInt8()
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = wire(func(e *Encoder) error { return e.EncodeInt32(math.MinInt8 - 1) }).DecodeInt8()
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = wire(func(e *Encoder) error { return e.EncodeInt32(math.MinInt16 - 1) }).DecodeInt16()
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = NewDecoder([]byte{0, 0, 1}).DecodeUint16()
+	assert.ErrorIs(t, err, ErrUnexpectedEOF)
+}
@@ -0,0 +1,94 @@
+package xdr
+
+import "fmt"
+
+// A Mux reads a message's 32-bit discriminant and hands the payload that follows to the
+// handler registered for it. Payloads are variable-length opaque data by default, the way
+// generated union containers encode them, or follow the discriminant directly with SetInline.
+
+// Discriminant is the constraint on Mux discriminant types
+type Discriminant interface {
+	~int32 | ~uint32
+}
+
+// Mux dispatches messages to handlers by their discriminant
+type Mux[K Discriminant] struct {
+	handlers map[K]func(dec *Decoder) error
+	unknown  func(d K, dec *Decoder) error
+	inline   bool
+}
+
+// NewMux returns a Mux with no handlers
+func NewMux[K Discriminant]() *Mux[K] {
+	return &Mux[K]{handlers: make(map[K]func(dec *Decoder) error)}
+}
+
+// SetInline sets whether payloads follow the discriminant directly instead of as opaque data
+func (m *Mux[K]) SetInline(inline bool) {
+	m.inline = inline
+}
+
+// HandleUnknown sets the handler for discriminants without one
+// It is called with the decoder positioned after the discriminant
+func (m *Mux[K]) HandleUnknown(handler func(d K, dec *Decoder) error) {
+	m.unknown = handler
+}
+
+// Handle registers the handler for messages with discriminant d, which carry a T
+// The payload is decoded into a new T before the handler is called
+func Handle[K Discriminant, T any, PT interface {
+	*T
+	Codec
+}](m *Mux[K], d K, handler func(payload PT) error) {
+	m.register(d, func(dec *Decoder) error {
+		payload := PT(new(T))
+		var err error
+		if m.inline {
+			err = payload.Decode(dec)
+		} else {
+			err = dec.DecodeOpaque(payload)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode %T payload: %w", payload, err)
+		}
+		return handler(payload)
+	})
+}
+
+// HandleVoid registers the handler for messages with discriminant d, which carry no payload
+func HandleVoid[K Discriminant](m *Mux[K], d K, handler func() error) {
+	m.register(d, func(*Decoder) error {
+		return handler()
+	})
+}
+
+// register adds the handler for d, panicking if d already has one
+func (m *Mux[K]) register(d K, handler func(dec *Decoder) error) {
+	if _, exists := m.handlers[d]; exists {
+		panic(fmt.Sprintf("xdr: multiple handlers for discriminant %v", d))
+	}
+	m.handlers[d] = handler
+}
+
+// Dispatch decodes the next message and calls its handler
+// Without a handler or fallback for the discriminant it fails with ErrUnknownDiscriminant
+// and leaves the decoder positioned at the discriminant
+func (m *Mux[K]) Dispatch(dec *Decoder) error {
+	raw, err := dec.PeekUint32()
+	if err != nil {
+		return err
+	}
+	// #nosec G115 -- int32 discriminants are encoded as their two's complement
+	d := K(raw)
+	handler, ok := m.handlers[d]
+	if !ok && m.unknown == nil {
+		return fmt.Errorf("%w: %v", ErrUnknownDiscriminant, d)
+	}
+	if _, err := dec.DecodeUint32(); err != nil {
+		return err
+	}
+	if !ok {
+		return m.unknown(d, dec)
+	}
+	return handler(dec)
+}
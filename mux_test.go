@@ -0,0 +1,94 @@
+package xdr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMsgType int32
+
+const (
+	testMsgPing  testMsgType = 1
+	testMsgData  testMsgType = 2
+	testMsgError testMsgType = -1
+)
+
+func TestMux(t *testing.T) {
+	var got []string
+	mux := NewMux[testMsgType]()
+	HandleVoid(mux, testMsgPing, func() error {
+		got = append(got, "ping")
+		return nil
+	})
+	Handle(mux, testMsgData, func(payload *TestType) error {
+		got = append(got, payload.Name)
+		return nil
+	})
+	Handle(mux, testMsgError, func(payload *NestedStruct) error {
+		got = append(got, "error")
+		return nil
+	})
+
+	enc := NewEncoder(make([]byte, 128))
+	require.NoError(t, enc.EncodeInt32(int32(testMsgPing)))
+	require.NoError(t, enc.EncodeInt32(int32(testMsgData)))
+	require.NoError(t, enc.EncodeOpaque(&TestType{ID: 1, Name: "data"}))
+	require.NoError(t, enc.EncodeInt32(int32(testMsgError)))
+	require.NoError(t, enc.EncodeOpaque(&NestedStruct{}))
+
+	dec := NewDecoder(enc.Bytes())
+	for dec.Remaining() > 0 {
+		require.NoError(t, mux.Dispatch(dec))
+	}
+	assert.Equal(t, []string{"ping", "data", "error"}, got)
+}
+
+func TestMuxInline(t *testing.T) {
+	var got *TestType
+	mux := NewMux[uint32]()
+	mux.SetInline(true)
+	Handle(mux, 7, func(payload *TestType) error {
+		got = payload
+		return nil
+	})
+
+	enc := NewEncoder(make([]byte, 64))
+	require.NoError(t, enc.EncodeUint32(7))
+	require.NoError(t, (&TestType{ID: 3, Name: "x"}).Encode(enc))
+	dec := NewDecoder(enc.Bytes())
+	require.NoError(t, mux.Dispatch(dec))
+	assert.Equal(t, &TestType{ID: 3, Name: "x"}, got)
+	assert.Equal(t, 0, dec.Remaining())
+}
+
+func TestMuxUnknown(t *testing.T) {
+	mux := NewMux[uint32]()
+	data := []byte{0, 0, 0, 9, 0, 0, 0, 2, 0xaa, 0xbb, 0, 0}
+
+	dec := NewDecoder(data)
+	assert.ErrorIs(t, mux.Dispatch(dec), ErrUnknownDiscriminant)
+	assert.Equal(t, 0, dec.Position(), "Unhandled messages should not be consumed")
+
+	var unknown uint32
+	mux.HandleUnknown(func(d uint32, dec *Decoder) error {
+		unknown = d
+		_, err := dec.DecodeBytes()
+		return err
+	})
+	require.NoError(t, mux.Dispatch(dec))
+	assert.Equal(t, uint32(9), unknown)
+	assert.Equal(t, 0, dec.Remaining())
+
+	assert.ErrorIs(t, mux.Dispatch(NewDecoder(nil)), ErrUnexpectedEOF)
+}
+
+func TestMuxErrors(t *testing.T) {
+	mux := NewMux[uint32]()
+	Handle(mux, 1, func(*TestType) error { return nil })
+	assert.Panics(t, func() { HandleVoid(mux, 1, func() error { return nil }) }, "Discriminants should have one handler")
+
+	err := mux.Dispatch(NewDecoder([]byte{0, 0, 0, 1, 0, 0, 0, 8, 0, 0, 0, 1}))
+	assert.ErrorIs(t, err, ErrUnexpectedEOF, "Truncated payloads should fail to decode")
+}
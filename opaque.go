@@ -0,0 +1,79 @@
+package xdr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Opaque-encapsulated values are written as variable-length opaque data holding their
+// own encoding, so a reader that does not know the type can skip them by length.
+
+// EncodeOpaque encodes v as variable-length opaque data
+// The length is written ahead of v and filled in once v has been encoded
+func (e *Encoder) EncodeOpaque(v Codec) error {
+	start := e.pos
+	if start+4 > len(e.buf) {
+		return ErrBufferTooSmall
+	}
+	e.pos += 4
+	if err := v.Encode(e); err != nil {
+		e.pos = start
+		return err
+	}
+
+	length := e.pos - start - 4
+	if uint64(length) > math.MaxUint32 {
+		e.pos = start
+		return ErrInvalidData
+	}
+	// #nosec G115
+	binary.BigEndian.PutUint32(e.buf[start:], uint32(length))
+
+	// XDR items are always aligned, but a hand-written Codec might not be
+	padLen := (4 - (length % 4)) % 4
+	if e.pos+padLen > len(e.buf) {
+		e.pos = start
+		return ErrBufferTooSmall
+	}
+	clear(e.buf[e.pos : e.pos+padLen])
+	e.pos += padLen
+	return nil
+}
+
+// DecodeOpaque decodes variable-length opaque data into v through a decoder bounded
+// to its length, which v must consume exactly
+func (d *Decoder) DecodeOpaque(v Codec) error {
+	length, err := d.DecodeUint32()
+	if err != nil {
+		return err
+	}
+	if length > math.MaxInt32 {
+		return ErrInvalidData
+	}
+
+	n := int(length)
+	totalLen := n + (4-(n%4))%4
+	if d.pos+totalLen > len(d.buf) {
+		return ErrUnexpectedEOF
+	}
+
+	if err := d.decodeWithin(d.buf[d.pos:d.pos+n], v); err != nil {
+		return err
+	}
+	d.pos += totalLen
+	return nil
+}
+
+// decodeWithin decodes v from data through a decoder that inherits d's depth, requiring
+// v to consume all of data
+func (d *Decoder) decodeWithin(data []byte, v Codec) error {
+	sub := &Decoder{buf: data, depth: d.depth, maxDepth: d.maxDepth}
+	if err := v.Decode(sub); err != nil {
+		return err
+	}
+	if sub.Remaining() != 0 {
+		return fmt.Errorf("%w: %d of %d opaque bytes left undecoded", ErrInvalidData, sub.Remaining(), len(data))
+	}
+	return nil
+}
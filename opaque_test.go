@@ -0,0 +1,53 @@
+package xdr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpaque(t *testing.T) {
+	enc := NewEncoder(make([]byte, 64))
+	require.NoError(t, enc.EncodeOpaque(&TestType{ID: 7, Name: "ab"}))
+	require.NoError(t, enc.EncodeUint32(9))
+	assert.Equal(t, []byte{
+		0, 0, 0, 12,
+		0, 0, 0, 7, 0, 0, 0, 2, 'a', 'b', 0, 0,
+		0, 0, 0, 9,
+	}, enc.Bytes(), "Opaque values should be prefixed with their encoded length")
+
+	dec := NewDecoder(enc.Bytes())
+	var decoded TestType
+	require.NoError(t, dec.DecodeOpaque(&decoded))
+	assert.Equal(t, TestType{ID: 7, Name: "ab"}, decoded)
+	v, err := dec.DecodeUint32()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(9), v)
+}
+
+func TestOpaqueErrors(t *testing.T) {
+	t.Run("buffer too small", func(t *testing.T) {
+		enc := NewEncoder(make([]byte, 8))
+		assert.ErrorIs(t, enc.EncodeOpaque(&TestType{ID: 1, Name: "x"}), ErrBufferTooSmall)
+		assert.Equal(t, 0, enc.Len(), "A failed encode should not leave a partial length")
+	})
+
+	t.Run("trailing bytes", func(t *testing.T) {
+		data := []byte{0, 0, 0, 12, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0}
+		var decoded TestType
+		assert.ErrorIs(t, NewDecoder(data).DecodeOpaque(&decoded), ErrInvalidData)
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		// The value cannot read past its declared length into the rest of the buffer
+		data := []byte{0, 0, 0, 4, 0, 0, 0, 1, 0, 0, 0, 1, 'x', 0, 0, 0}
+		var decoded TestType
+		assert.ErrorIs(t, NewDecoder(data).DecodeOpaque(&decoded), ErrUnexpectedEOF)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		var decoded TestType
+		assert.ErrorIs(t, NewDecoder([]byte{0, 0, 0, 8, 0, 0, 0, 1}).DecodeOpaque(&decoded), ErrUnexpectedEOF)
+	})
+}
@@ -0,0 +1,1294 @@
+package xdr
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarshalReflect and UnmarshalReflect encode types without generated methods by walking
+// them with reflection. They follow the wire rules and xdr struct tags of xdrgen, so a type
+// encodes to the same bytes either way. Values whose pointer implements Codec, including
+// generated types, are encoded with their own methods. The walk for each type is planned
+// once and cached.
+//
+// Union payload fields use the embedded union tag xdr:"union=Key,void=A|B,arms=C|D" since
+// reflection cannot see directives. The void and arm discriminants must be literals; any
+// other discriminant follows default= and unknown= as in the generated switch. Without
+// arms=, every discriminant not listed as void carries a payload.
+
+// reflectPlan encodes and decodes values of one type
+type reflectPlan struct {
+	encode func(enc *Encoder, v reflect.Value) error
+	decode func(dec *Decoder, v reflect.Value) error // v is settable
+	sized  bool                                      // every value takes at least four bytes
+}
+
+// Plans for the types passed to MarshalReflect and UnmarshalReflect, and for the struct
+// types walked field by field. They differ for structs that implement Codec.
+var (
+	reflectPlans sync.Map // reflect.Type -> *reflectPlan
+	structPlans  sync.Map // reflect.Type -> *reflectPlan
+)
+
+var (
+	codecType    = reflect.TypeFor[Codec]()
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+// MarshalReflect encodes v, a value or a pointer to one, without requiring it to implement Codec
+func MarshalReflect(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+		return nil, fmt.Errorf("XDR encoding failed: %w: cannot encode %T", ErrInvalidData, v)
+	}
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	plan, err := reflectPlanFor(rv.Type())
+	if err != nil {
+		return nil, fmt.Errorf("XDR encoding failed: %w", err)
+	}
+
+	buf := make([]byte, 512)
+	enc := NewEncoder(buf)
+	if err := plan.encode(enc, rv); err != nil {
+		return nil, fmt.Errorf("XDR encoding failed: %w", err)
+	}
+
+	result := make([]byte, len(enc.Bytes()))
+	copy(result, enc.Bytes())
+	return result, nil
+}
+
+// UnmarshalReflect decodes data into v, which must be a non-nil pointer, without requiring
+// it to implement Codec
+func UnmarshalReflect(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("XDR decoding failed: %w: cannot decode into %T, need a non-nil pointer", ErrInvalidData, v)
+	}
+	plan, err := reflectPlanFor(rv.Elem().Type())
+	if err != nil {
+		return fmt.Errorf("XDR decoding failed: %w", err)
+	}
+
+	dec := NewDecoder(data)
+	if err := plan.decode(dec, rv.Elem()); err != nil {
+		return fmt.Errorf("XDR decoding failed: %w", err)
+	}
+	return nil
+}
+
+// reflectPlanFor returns the cached plan for t, building it on first use
+func reflectPlanFor(t reflect.Type) (*reflectPlan, error) {
+	if plan, ok := reflectPlans.Load(t); ok {
+		return plan.(*reflectPlan), nil
+	}
+	b := &planBuilder{structs: make(map[reflect.Type]*structCodec)}
+	plan, err := b.typePlan(t, fieldOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// Struct plans are only published once every plan they refer to is complete
+	for st, s := range b.structs {
+		structPlans.LoadOrStore(st, s.plan)
+	}
+	cached, _ := reflectPlans.LoadOrStore(t, plan)
+	return cached.(*reflectPlan), nil
+}
+
+// planBuilder builds the plans for one type and the types it reaches
+type planBuilder struct {
+	structs map[reflect.Type]*structCodec
+}
+
+// fieldOptions are the tag options that apply to a field's type rather than the field
+type fieldOptions struct {
+	bounds []uint32 // length bound per array dimension, outermost first, 0 for unbounded
+	layout string   // time layout, empty for the default
+}
+
+// isDimension reports whether t is an array dimension, as opposed to opaque data
+func isDimension(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8
+}
+
+// typePlan returns the plan for values of type t
+func (b *planBuilder) typePlan(t reflect.Type, opts fieldOptions) (*reflectPlan, error) {
+	if len(opts.bounds) > 0 && !isDimension(t) && t.Kind() != reflect.Pointer {
+		return nil, fmt.Errorf("%w: max bounds exceed the array dimensions at %s", ErrUnsupportedType, t)
+	}
+
+	switch {
+	case t == timeType || (t.Kind() == reflect.Struct && t.ConvertibleTo(timeType)):
+		return timePlan(t, opts.layout)
+	case t == durationType || (opts.layout != "" && t.Kind() == reflect.Int64):
+		return durationPlan(opts.layout)
+	case opts.layout != "" && !isDimension(t):
+		return nil, fmt.Errorf("%w: time layout on %s, which is not time.Time or time.Duration", ErrUnsupportedType, t)
+	case len(opts.bounds) == 0 && t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(codecType):
+		return codecPlan(), nil
+	}
+
+	if plan := scalarPlan(t.Kind()); plan != nil {
+		return plan, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return bytesPlan(), nil
+		}
+		return b.slicePlan(t, opts)
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return fixedBytesPlan(t), nil
+		}
+		return b.arrayPlan(t, opts)
+	case reflect.Map:
+		return b.mapPlan(t)
+	case reflect.Pointer:
+		return b.pointerPlan(t, opts)
+	case reflect.Struct:
+		return b.structPlan(t)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+}
+
+// scalarPlan returns the plan for booleans, integers and strings, or nil for other kinds
+// Narrow integers are widened to an int or unsigned int, int and uint to a hyper
+func scalarPlan(kind reflect.Kind) *reflectPlan {
+	switch kind {
+	case reflect.Bool:
+		return &reflectPlan{
+			encode: func(enc *Encoder, v reflect.Value) error { return enc.EncodeBool(v.Bool()) },
+			decode: func(dec *Decoder, v reflect.Value) error {
+				val, err := dec.DecodeBool()
+				if err != nil {
+					return err
+				}
+				v.SetBool(val)
+				return nil
+			},
+			sized: true,
+		}
+	case reflect.String:
+		return &reflectPlan{
+			encode: func(enc *Encoder, v reflect.Value) error { return enc.EncodeString(v.String()) },
+			decode: func(dec *Decoder, v reflect.Value) error {
+				val, err := dec.DecodeString()
+				if err != nil {
+					return err
+				}
+				v.SetString(val)
+				return nil
+			},
+			sized: true,
+		}
+	case reflect.Int8:
+		return signedPlan(func(enc *Encoder, x int64) error { return enc.EncodeInt8(int8(x)) },
+			func(dec *Decoder) (int64, error) { x, err := dec.DecodeInt8(); return int64(x), err })
+	case reflect.Int16:
+		return signedPlan(func(enc *Encoder, x int64) error { return enc.EncodeInt16(int16(x)) },
+			func(dec *Decoder) (int64, error) { x, err := dec.DecodeInt16(); return int64(x), err })
+	case reflect.Int32:
+		return signedPlan(func(enc *Encoder, x int64) error { return enc.EncodeInt32(int32(x)) },
+			func(dec *Decoder) (int64, error) { x, err := dec.DecodeInt32(); return int64(x), err })
+	case reflect.Int64, reflect.Int:
+		// int and uint are hypers; OverflowInt and OverflowUint catch values a 32-bit int cannot hold
+		return signedPlan((*Encoder).EncodeInt64, (*Decoder).DecodeInt64)
+	case reflect.Uint8:
+		return unsignedPlan(func(enc *Encoder, x uint64) error { return enc.EncodeUint8(uint8(x)) },
+			func(dec *Decoder) (uint64, error) { x, err := dec.DecodeUint8(); return uint64(x), err })
+	case reflect.Uint16:
+		return unsignedPlan(func(enc *Encoder, x uint64) error { return enc.EncodeUint16(uint16(x)) },
+			func(dec *Decoder) (uint64, error) { x, err := dec.DecodeUint16(); return uint64(x), err })
+	case reflect.Uint32:
+		return unsignedPlan(func(enc *Encoder, x uint64) error { return enc.EncodeUint32(uint32(x)) },
+			func(dec *Decoder) (uint64, error) { x, err := dec.DecodeUint32(); return uint64(x), err })
+	case reflect.Uint64, reflect.Uint:
+		return unsignedPlan((*Encoder).EncodeUint64, (*Decoder).DecodeUint64)
+	}
+	return nil
+}
+
+// signedPlan returns the plan for a signed integer kind
+func signedPlan(encode func(enc *Encoder, x int64) error, decode func(dec *Decoder) (int64, error)) *reflectPlan {
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error { return encode(enc, v.Int()) },
+		decode: func(dec *Decoder, v reflect.Value) error {
+			x, err := decode(dec)
+			if err != nil {
+				return err
+			}
+			if v.OverflowInt(x) {
+				return ErrOverflow
+			}
+			v.SetInt(x)
+			return nil
+		},
+		sized: true,
+	}
+}
+
+// unsignedPlan returns the plan for an unsigned integer kind
+func unsignedPlan(encode func(enc *Encoder, x uint64) error, decode func(dec *Decoder) (uint64, error)) *reflectPlan {
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error { return encode(enc, v.Uint()) },
+		decode: func(dec *Decoder, v reflect.Value) error {
+			x, err := decode(dec)
+			if err != nil {
+				return err
+			}
+			if v.OverflowUint(x) {
+				return ErrOverflow
+			}
+			v.SetUint(x)
+			return nil
+		},
+		sized: true,
+	}
+}
+
+// bytesPlan returns the plan for variable-length opaque data
+func bytesPlan() *reflectPlan {
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error { return enc.EncodeBytes(v.Bytes()) },
+		decode: func(dec *Decoder, v reflect.Value) error {
+			val, err := dec.DecodeBytes()
+			if err != nil {
+				return err
+			}
+			v.SetBytes(val)
+			return nil
+		},
+		sized: true,
+	}
+}
+
+// fixedBytesPlan returns the plan for fixed-length opaque data of array type t
+func fixedBytesPlan(t reflect.Type) *reflectPlan {
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error { return enc.EncodeFixedBytes(addressable(v).Bytes()) },
+		decode: func(dec *Decoder, v reflect.Value) error { return dec.DecodeFixedBytesInto(v.Bytes()) },
+		sized:  t.Len() > 0,
+	}
+}
+
+// codecPlan returns the plan for types whose pointer implements Codec
+func codecPlan() *reflectPlan {
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error {
+			return addressable(v).Addr().Interface().(Codec).Encode(enc)
+		},
+		decode: func(dec *Decoder, v reflect.Value) error {
+			return v.Addr().Interface().(Codec).Decode(dec)
+		},
+	}
+}
+
+// addressable returns v, or an addressable copy of v such as for a map value
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// timeLayouts maps the xdr:"time=layout" names to their runtime methods
+var timeLayouts = map[string]struct {
+	encodeTime     func(enc *Encoder, t time.Time) error
+	decodeTime     func(dec *Decoder) (time.Time, error)
+	encodeDuration func(enc *Encoder, d time.Duration) error
+	decodeDuration func(dec *Decoder) (time.Duration, error)
+}{
+	"nfs":      {(*Encoder).EncodeTimeNFS, (*Decoder).DecodeTimeNFS, (*Encoder).EncodeDurationNFS, (*Decoder).DecodeDurationNFS},
+	"timespec": {(*Encoder).EncodeTimeTimespec, (*Decoder).DecodeTimeTimespec, (*Encoder).EncodeDurationTimespec, (*Decoder).DecodeDurationTimespec},
+	"unixnano": {(*Encoder).EncodeTimeUnixNano, (*Decoder).DecodeTimeUnixNano, (*Encoder).EncodeDurationUnixNano, (*Decoder).DecodeDurationUnixNano},
+}
+
+// timePlan returns the plan for time.Time and types defined from it, timespec by default
+func timePlan(t reflect.Type, layout string) (*reflectPlan, error) {
+	if layout == "" {
+		layout = "timespec"
+	}
+	methods, ok := timeLayouts[layout]
+	if !ok {
+		return nil, fmt.Errorf("%w: time layout %q (must be nfs, timespec or unixnano)", ErrUnsupportedType, layout)
+	}
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error {
+			return methods.encodeTime(enc, v.Convert(timeType).Interface().(time.Time))
+		},
+		decode: func(dec *Decoder, v reflect.Value) error {
+			val, err := methods.decodeTime(dec)
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(val).Convert(t))
+			return nil
+		},
+		sized: true,
+	}, nil
+}
+
+// durationPlan returns the plan for time.Duration, unixnano by default
+// Types defined from time.Duration are only recognized with an explicit layout
+func durationPlan(layout string) (*reflectPlan, error) {
+	if layout == "" {
+		layout = "unixnano"
+	}
+	methods, ok := timeLayouts[layout]
+	if !ok {
+		return nil, fmt.Errorf("%w: time layout %q (must be nfs, timespec or unixnano)", ErrUnsupportedType, layout)
+	}
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error {
+			return methods.encodeDuration(enc, time.Duration(v.Int()))
+		},
+		decode: func(dec *Decoder, v reflect.Value) error {
+			val, err := methods.decodeDuration(dec)
+			if err != nil {
+				return err
+			}
+			v.SetInt(int64(val))
+			return nil
+		},
+		sized: true,
+	}, nil
+}
+
+// slicePlan returns the plan for a variable-length array: a count, then the elements
+func (b *planBuilder) slicePlan(t reflect.Type, opts fieldOptions) (*reflectPlan, error) {
+	maxLen := opts.bounds
+	var bound uint32
+	if len(maxLen) > 0 {
+		bound, opts.bounds = maxLen[0], maxLen[1:]
+	}
+	elem, err := b.typePlan(t.Elem(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error {
+			n := v.Len()
+			if bound > 0 && n > int(bound) {
+				return fmt.Errorf("length %d exceeds maximum %d: %w", n, bound, ErrInvalidData)
+			}
+			// #nosec G115
+			if err := enc.EncodeUint32(uint32(n)); err != nil {
+				return fmt.Errorf("failed to encode length: %w", err)
+			}
+			for i := range n {
+				if err := elem.encode(enc, v.Index(i)); err != nil {
+					return fmt.Errorf("failed to encode element: %w", err)
+				}
+			}
+			return nil
+		},
+		decode: func(dec *Decoder, v reflect.Value) error {
+			n, err := dec.DecodeUint32()
+			if err != nil {
+				return fmt.Errorf("failed to decode length: %w", err)
+			}
+			if bound > 0 && n > bound {
+				return fmt.Errorf("length %d exceeds maximum %d: %w", n, bound, ErrInvalidData)
+			}
+			// Elements of at least four bytes bound the allocation for hostile counts
+			if elem.sized && int(n) > dec.Remaining()/4 {
+				return ErrUnexpectedEOF
+			}
+			s := reflect.MakeSlice(t, int(n), int(n))
+			for i := range int(n) {
+				if err := elem.decode(dec, s.Index(i)); err != nil {
+					return fmt.Errorf("failed to decode element: %w", err)
+				}
+			}
+			v.Set(s)
+			return nil
+		},
+		sized: true,
+	}, nil
+}
+
+// arrayPlan returns the plan for a fixed-length array: the elements alone
+func (b *planBuilder) arrayPlan(t reflect.Type, opts fieldOptions) (*reflectPlan, error) {
+	if len(opts.bounds) > 0 {
+		if opts.bounds[0] != 0 {
+			return nil, fmt.Errorf("%w: max bound on fixed-length array %s", ErrUnsupportedType, t)
+		}
+		opts.bounds = opts.bounds[1:]
+	}
+	elem, err := b.typePlan(t.Elem(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error {
+			for i := range v.Len() {
+				if err := elem.encode(enc, v.Index(i)); err != nil {
+					return fmt.Errorf("failed to encode element: %w", err)
+				}
+			}
+			return nil
+		},
+		decode: func(dec *Decoder, v reflect.Value) error {
+			for i := range v.Len() {
+				if err := elem.decode(dec, v.Index(i)); err != nil {
+					return fmt.Errorf("failed to decode element: %w", err)
+				}
+			}
+			return nil
+		},
+		sized: t.Len() > 0 && elem.sized,
+	}, nil
+}
+
+// mapPlan returns the plan for a map: a count, then the pairs in ascending key order
+// A map to struct{} is a set and encodes its keys only
+func (b *planBuilder) mapPlan(t reflect.Type) (*reflectPlan, error) {
+	key := scalarPlan(t.Key().Kind())
+	if key == nil || t.Key().Kind() == reflect.Bool {
+		return nil, fmt.Errorf("%w: map key %s (must be a string or an integer)", ErrUnsupportedType, t.Key())
+	}
+	isSet := t.Elem().Kind() == reflect.Struct && t.Elem().NumField() == 0
+	var value *reflectPlan
+	if !isSet {
+		var err error
+		if value, err = b.typePlan(t.Elem(), fieldOptions{}); err != nil {
+			return nil, err
+		}
+	}
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error {
+			// #nosec G115
+			if err := enc.EncodeUint32(uint32(v.Len())); err != nil {
+				return fmt.Errorf("failed to encode length: %w", err)
+			}
+			keys := v.MapKeys()
+			slices.SortFunc(keys, compareKeys)
+			for _, k := range keys {
+				if err := key.encode(enc, k); err != nil {
+					return fmt.Errorf("failed to encode key: %w", err)
+				}
+				if isSet {
+					continue
+				}
+				if err := value.encode(enc, v.MapIndex(k)); err != nil {
+					return fmt.Errorf("failed to encode value: %w", err)
+				}
+			}
+			return nil
+		},
+		decode: func(dec *Decoder, v reflect.Value) error {
+			n, err := dec.DecodeUint32()
+			if err != nil {
+				return fmt.Errorf("failed to decode length: %w", err)
+			}
+			// Every pair takes at least four bytes, which bounds the allocation for hostile counts
+			if int(n) > dec.Remaining()/4 {
+				return ErrUnexpectedEOF
+			}
+			m := reflect.MakeMapWithSize(t, int(n))
+			for range n {
+				k := reflect.New(t.Key()).Elem()
+				if err := key.decode(dec, k); err != nil {
+					return fmt.Errorf("failed to decode key: %w", err)
+				}
+				if m.MapIndex(k).IsValid() {
+					return fmt.Errorf("failed to decode key %v: %w", k, ErrDuplicateKey)
+				}
+				val := reflect.New(t.Elem()).Elem()
+				if !isSet {
+					if err := value.decode(dec, val); err != nil {
+						return fmt.Errorf("failed to decode value: %w", err)
+					}
+				}
+				m.SetMapIndex(k, val)
+			}
+			v.Set(m)
+			return nil
+		},
+		sized: true,
+	}, nil
+}
+
+// compareKeys orders map keys the way SortedKeys does
+func compareKeys(a, b reflect.Value) int {
+	switch {
+	case a.CanInt():
+		return cmp.Compare(a.Int(), b.Int())
+	case a.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint())
+	}
+	return cmp.Compare(a.String(), b.String())
+}
+
+// pointerPlan returns the plan for a pointer, which encodes as its element
+// A nil pointer fails to encode; decoding allocates the element
+func (b *planBuilder) pointerPlan(t reflect.Type, opts fieldOptions) (*reflectPlan, error) {
+	elem, err := b.typePlan(t.Elem(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &reflectPlan{
+		encode: func(enc *Encoder, v reflect.Value) error {
+			if v.IsNil() {
+				return fmt.Errorf("%w: nil %s", ErrInvalidData, t)
+			}
+			return elem.encode(enc, v.Elem())
+		},
+		decode: func(dec *Decoder, v reflect.Value) error {
+			p := reflect.New(t.Elem())
+			if err := elem.decode(dec, p.Elem()); err != nil {
+				return err
+			}
+			v.Set(p)
+			return nil
+		},
+		sized: elem.sized,
+	}, nil
+}
+
+// reflectValue adapts a value and its plan to Codec, for opaque-encapsulated fields
+type reflectValue struct {
+	plan *reflectPlan
+	v    reflect.Value
+}
+
+// Encode encodes the value with its plan
+func (r reflectValue) Encode(enc *Encoder) error { return r.plan.encode(enc, r.v) }
+
+// Decode decodes into the value with its plan
+func (r reflectValue) Decode(dec *Decoder) error { return r.plan.decode(dec, r.v) }
+
+// structCodec encodes a struct type field by field
+type structCodec struct {
+	typ       reflect.Type
+	plan      *reflectPlan
+	fields    []reflectField
+	link      int  // struct field index of the xdr:"list" next pointer, -1 if none
+	checksums bool // some field is a checksum or length
+	recursive bool // the type can reach itself, so nesting depth is tracked
+}
+
+// reflectField encodes one struct field; encode and decode receive the whole struct
+type reflectField struct {
+	name     string
+	index    int // struct field index
+	label    string
+	encode   func(enc *Encoder, v reflect.Value) error
+	decode   func(dec *Decoder, v reflect.Value) error
+	sized    bool
+	checksum string // checksum or length algorithm, empty for ordinary fields
+	covers   []int  // positions in fields covered by the checksum, in wire order
+	coverFor string // covered field names for errors
+}
+
+// reflectChecksums maps checksum and length tags to the functions computing them
+var reflectChecksums = map[string]func(parts ...[]byte) uint32{
+	"crc32":   ChecksumCRC32,
+	"crc32c":  ChecksumCRC32C,
+	"adler32": ChecksumAdler32,
+	"len":     ByteLength,
+}
+
+// structPlan returns the plan walking the fields of struct type t, even if t implements Codec
+// A type reached again while its own plan is being built is recursive; its list link is
+// never planned since it is encoded iteratively
+func (b *planBuilder) structPlan(t reflect.Type) (*reflectPlan, error) {
+	if plan, ok := structPlans.Load(t); ok {
+		return plan.(*reflectPlan), nil
+	}
+	if s, ok := b.structs[t]; ok {
+		s.recursive = true
+		return s.plan, nil
+	}
+
+	s := &structCodec{typ: t, link: -1}
+	s.plan = &reflectPlan{encode: s.encode, decode: s.decode}
+	b.structs[t] = s
+	if err := b.buildFields(s); err != nil {
+		return nil, err
+	}
+	for _, f := range s.fields {
+		s.plan.sized = s.plan.sized || f.sized
+	}
+	s.plan.sized = s.plan.sized || s.link >= 0
+	return s.plan, nil
+}
+
+// parseTagOptions parses a comma-separated xdr tag: name=value,flag
+func parseTagOptions(tag string) map[string]string {
+	options := make(map[string]string)
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		options[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return options
+}
+
+// buildFields plans the fields of s in declaration order
+func (b *planBuilder) buildFields(s *structCodec) error {
+	t := s.typ
+	covers := make(map[int]string) // position in s.fields -> covered field names
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("xdr")
+		if tag == "-" {
+			continue
+		}
+		opts := parseTagOptions(tag)
+		if !sf.IsExported() && sf.Name != "_" {
+			return fmt.Errorf("%w: unexported field %s.%s (tag it xdr:\"-\")", ErrUnsupportedType, t, sf.Name)
+		}
+
+		f := reflectField{name: sf.Name, index: i, label: sf.Name}
+		var err error
+		switch {
+		case opts["reserved"] != "":
+			err = b.reservedField(&f, t, opts)
+		case opts["const"] != "":
+			err = b.constField(&f, sf, t, opts["const"])
+		case sf.Name == "_":
+			err = fmt.Errorf("%w: blank field in %s must be tagged xdr:\"const=V\" or xdr:\"reserved=N\"", ErrUnsupportedType, t)
+		case hasOption(opts, "union"):
+			err = b.unionField(&f, sf, s, opts)
+		case hasOption(opts, "list"):
+			if sf.Type.Kind() == reflect.Pointer && sf.Type.Elem() == t {
+				if i != t.NumField()-1 {
+					return fmt.Errorf("%w: list link %s.%s must be the last field", ErrUnsupportedType, t, sf.Name)
+				}
+				s.link = i
+				continue
+			}
+			err = b.listField(&f, sf)
+		case hasOption(opts, "opaque"):
+			err = b.opaqueField(&f, sf)
+		case sf.Anonymous && hasOption(opts, "inline"):
+			err = b.inlineField(&f, sf)
+		default:
+			err = b.valueField(&f, sf, opts)
+		}
+		if err != nil {
+			return err
+		}
+
+		for algorithm := range reflectChecksums {
+			if _, ok := opts[algorithm]; !ok {
+				continue
+			}
+			if f.checksum != "" || opts["const"] != "" || opts["reserved"] != "" || sf.Type.Kind() != reflect.Uint32 {
+				return fmt.Errorf("%w: checksum field %s.%s must be a plain uint32", ErrUnsupportedType, t, sf.Name)
+			}
+			f.checksum = algorithm
+			covers[len(s.fields)] = opts[algorithm]
+			s.checksums = true
+		}
+		s.fields = append(s.fields, f)
+	}
+	return resolveCovers(s, covers)
+}
+
+// hasOption reports whether a tag option is present, with or without a value
+func hasOption(opts map[string]string, name string) bool {
+	_, ok := opts[name]
+	return ok
+}
+
+// valueField plans an ordinary field, honoring max bounds and time layouts
+func (b *planBuilder) valueField(f *reflectField, sf reflect.StructField, opts map[string]string) error {
+	var fo fieldOptions
+	if bounds, ok := opts["max"]; ok {
+		for _, bound := range strings.Split(bounds, "|") {
+			bound = strings.TrimSpace(bound)
+			var n uint64
+			if bound != "" {
+				var err error
+				if n, err = strconv.ParseUint(bound, 10, 32); err != nil || n == 0 {
+					return fmt.Errorf("%w: field %s has invalid max bound %q", ErrUnsupportedType, sf.Name, bound)
+				}
+			}
+			fo.bounds = append(fo.bounds, uint32(n))
+		}
+		if !isDimension(sf.Type) {
+			return fmt.Errorf("%w: field %s has max bounds but %s is not an array", ErrUnsupportedType, sf.Name, sf.Type)
+		}
+	}
+	fo.layout = opts["time"]
+
+	plan, err := b.typePlan(sf.Type, fo)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", sf.Name, err)
+	}
+	i := f.index
+	f.encode = func(enc *Encoder, v reflect.Value) error { return plan.encode(enc, v.Field(i)) }
+	f.decode = func(dec *Decoder, v reflect.Value) error { return plan.decode(dec, v.Field(i)) }
+	f.sized = plan.sized
+	return nil
+}
+
+// reservedField plans a reserved area: zeros on encode, skipped or checked on decode
+func (b *planBuilder) reservedField(f *reflectField, t reflect.Type, opts map[string]string) error {
+	n, err := strconv.Atoi(opts["reserved"])
+	if err != nil || n <= 0 {
+		return fmt.Errorf("%w: field %s.%s has invalid reserved size %q", ErrUnsupportedType, t, f.name, opts["reserved"])
+	}
+	if opts["const"] != "" {
+		return fmt.Errorf("%w: field %s.%s cannot be both const and reserved", ErrUnsupportedType, t, f.name)
+	}
+	if f.name == "_" {
+		f.label = t.Name() + " reserved area"
+	}
+	strict := hasOption(opts, "strict")
+	f.encode = func(enc *Encoder, _ reflect.Value) error { return enc.EncodeReserved(n) }
+	f.decode = func(dec *Decoder, _ reflect.Value) error {
+		if strict {
+			return dec.DecodeReserved(n)
+		}
+		return dec.SkipReserved(n)
+	}
+	f.sized = true
+	return nil
+}
+
+// constField plans a constant: written from the tag and verified on decode
+func (b *planBuilder) constField(f *reflectField, sf reflect.StructField, t reflect.Type, value string) error {
+	plan := scalarPlan(sf.Type.Kind())
+	constant := reflect.New(sf.Type).Elem()
+	var err error
+	switch {
+	case plan == nil || sf.Type.Kind() == reflect.Bool || sf.Type.Kind() == reflect.String:
+		err = strconv.ErrSyntax
+	case constant.CanInt():
+		var n int64
+		if n, err = strconv.ParseInt(value, 0, sf.Type.Bits()); err == nil {
+			constant.SetInt(n)
+		}
+	default:
+		var n uint64
+		if n, err = strconv.ParseUint(value, 0, sf.Type.Bits()); err == nil {
+			constant.SetUint(n)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: field %s.%s has invalid const %q for type %s", ErrUnsupportedType, t, sf.Name, value, sf.Type)
+	}
+
+	if sf.Name == "_" {
+		f.label = t.Name() + " constant"
+	}
+	label, i := f.label, f.index
+	f.encode = func(enc *Encoder, _ reflect.Value) error { return plan.encode(enc, constant) }
+	f.decode = func(dec *Decoder, v reflect.Value) error {
+		got := reflect.New(sf.Type).Elem()
+		if err := plan.decode(dec, got); err != nil {
+			return err
+		}
+		if !got.Equal(constant) {
+			if got.CanInt() {
+				return fmt.Errorf("%w: %s is %#x, expected %s", ErrInvalidData, label, got.Int(), value)
+			}
+			return fmt.Errorf("%w: %s is %#x, expected %s", ErrInvalidData, label, got.Uint(), value)
+		}
+		if sf.Name != "_" {
+			v.Field(i).Set(got)
+		}
+		return nil
+	}
+	f.sized = true
+	return nil
+}
+
+// unionField plans a union payload field: variable-length opaque data after a key field
+// declared before it, or nothing for the void discriminants
+func (b *planBuilder) unionField(f *reflectField, sf reflect.StructField, s *structCodec, opts map[string]string) error {
+	if sf.Type.Kind() != reflect.Slice || sf.Type.Elem().Kind() != reflect.Uint8 {
+		return fmt.Errorf("%w: union field %s.%s must be []byte, got %s", ErrUnsupportedType, s.typ, sf.Name, sf.Type)
+	}
+	keyIndex := -1
+	for _, prev := range s.fields {
+		if prev.name == opts["union"] {
+			keyIndex = prev.index
+		}
+	}
+	if keyIndex < 0 {
+		return fmt.Errorf("%w: union field %s.%s references key field %s which must be declared before it", ErrUnsupportedType, s.typ, sf.Name, opts["union"])
+	}
+	keyType := s.typ.Field(keyIndex).Type
+	if kind := keyType.Kind(); kind != reflect.Bool && kind != reflect.Int32 && kind != reflect.Uint32 {
+		return fmt.Errorf("%w: union key %s.%s must be uint32, int32 or bool, got %s", ErrUnsupportedType, s.typ, opts["union"], keyType)
+	}
+
+	// Discriminants are held as the bits of the key value
+	parseKeys := func(option string) (map[uint64]bool, error) {
+		keys := make(map[uint64]bool)
+		for _, name := range strings.Split(opts[option], "|") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			var bits uint64
+			var err error
+			switch keyType.Kind() {
+			case reflect.Bool:
+				var val bool
+				val, err = strconv.ParseBool(name)
+				if val {
+					bits = 1
+				}
+			case reflect.Int32:
+				var val int64
+				val, err = strconv.ParseInt(name, 0, 32)
+				bits = uint64(val) // #nosec G115
+			default:
+				bits, err = strconv.ParseUint(name, 0, 32)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s discriminant %q of %s.%s must be a literal of type %s", ErrUnsupportedType, option, name, s.typ, sf.Name, keyType)
+			}
+			keys[bits] = true
+		}
+		return keys, nil
+	}
+	void, err := parseKeys("void")
+	if err != nil {
+		return err
+	}
+	arms, err := parseKeys("arms")
+	if err != nil {
+		return err
+	}
+
+	// Discriminants listed in neither void= nor arms= fall to default=, then to the unknown
+	// policy, as in the generated switch. Without arms= every such discriminant carries a payload.
+	fallback := cmp.Or(opts["unknown"], "void")
+	switch fallback {
+	case "void", "reject", "capture":
+	default:
+		return fmt.Errorf("%w: union field %s.%s has invalid unknown policy %q", ErrUnsupportedType, s.typ, sf.Name, fallback)
+	}
+	switch def := opts["default"]; def {
+	case "":
+	case "nil":
+		fallback = "void"
+	default:
+		fallback = "capture" // the default payload struct is held as opaque data
+	}
+	if !hasOption(opts, "arms") {
+		if (hasOption(opts, "unknown") || hasOption(opts, "default")) && fallback != "capture" {
+			return fmt.Errorf("%w: union field %s.%s needs arms=A|B to tell payload discriminants from unknown ones", ErrUnsupportedType, s.typ, sf.Name)
+		}
+		fallback = "capture"
+	} else if !hasOption(opts, "void") && fallback != "void" {
+		return fmt.Errorf("%w: union field %s.%s needs void=A|B since reflection cannot infer void constants", ErrUnsupportedType, s.typ, sf.Name)
+	}
+
+	keyName := opts["union"]
+	// carries reports whether the key selects a payload, or an error for a rejected discriminant
+	carries := func(v reflect.Value) (bool, error) {
+		key := v.Field(keyIndex)
+		var bits uint64
+		switch key.Kind() {
+		case reflect.Bool:
+			if key.Bool() {
+				bits = 1
+			}
+		case reflect.Int32:
+			bits = uint64(key.Int()) // #nosec G115
+		default:
+			bits = key.Uint()
+		}
+		switch {
+		case void[bits]:
+			return false, nil
+		case arms[bits]:
+			return true, nil
+		case fallback == "reject":
+			return false, fmt.Errorf("%w: %s=%v", ErrUnknownDiscriminant, keyName, key.Interface())
+		}
+		return fallback == "capture", nil
+	}
+	i := f.index
+	f.encode = func(enc *Encoder, v reflect.Value) error {
+		if ok, err := carries(v); !ok {
+			return err
+		}
+		return enc.EncodeBytes(v.Field(i).Bytes())
+	}
+	f.decode = func(dec *Decoder, v reflect.Value) error {
+		if ok, err := carries(v); !ok {
+			return err
+		}
+		val, err := dec.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		v.Field(i).SetBytes(val)
+		return nil
+	}
+	return nil
+}
+
+// listField plans an optional list head pointer or a slice held as a list: each element is
+// preceded by TRUE and the list ends with FALSE
+func (b *planBuilder) listField(f *reflectField, sf reflect.StructField) error {
+	kind := sf.Type.Kind()
+	if kind != reflect.Pointer && kind != reflect.Slice {
+		return fmt.Errorf("%w: list field %s must be a pointer or slice, got %s", ErrUnsupportedType, sf.Name, sf.Type)
+	}
+	elemType := sf.Type.Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: list field %s must hold struct nodes, got %s", ErrUnsupportedType, sf.Name, sf.Type)
+	}
+	elem, err := b.typePlan(sf.Type.Elem(), fieldOptions{})
+	if err != nil {
+		return fmt.Errorf("field %s: %w", sf.Name, err)
+	}
+
+	i := f.index
+	f.sized = true
+	if kind == reflect.Pointer {
+		f.encode = func(enc *Encoder, v reflect.Value) error {
+			head := v.Field(i)
+			if err := enc.EncodeBool(!head.IsNil()); err != nil || head.IsNil() {
+				return err
+			}
+			return elem.encode(enc, head.Elem())
+		}
+		f.decode = func(dec *Decoder, v reflect.Value) error {
+			more, err := dec.DecodeBool()
+			if err != nil {
+				return err
+			}
+			head := v.Field(i)
+			if !more {
+				head.SetZero()
+				return nil
+			}
+			p := reflect.New(sf.Type.Elem())
+			if err := elem.decode(dec, p.Elem()); err != nil {
+				return err
+			}
+			head.Set(p)
+			return nil
+		}
+		return nil
+	}
+
+	f.encode = func(enc *Encoder, v reflect.Value) error {
+		list := v.Field(i)
+		for j := range list.Len() {
+			if err := enc.EncodeBool(true); err != nil {
+				return err
+			}
+			if err := elem.encode(enc, list.Index(j)); err != nil {
+				return fmt.Errorf("failed to encode element: %w", err)
+			}
+		}
+		return enc.EncodeBool(false)
+	}
+	f.decode = func(dec *Decoder, v reflect.Value) error {
+		list := reflect.MakeSlice(sf.Type, 0, 0)
+		for {
+			more, err := dec.DecodeBool()
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+			item := reflect.New(sf.Type.Elem()).Elem()
+			if err := elem.decode(dec, item); err != nil {
+				return fmt.Errorf("failed to decode element: %w", err)
+			}
+			list = reflect.Append(list, item)
+		}
+		v.Field(i).Set(list)
+		return nil
+	}
+	return nil
+}
+
+// opaqueField plans a struct encapsulated as variable-length opaque data
+func (b *planBuilder) opaqueField(f *reflectField, sf reflect.StructField) error {
+	isPointer := sf.Type.Kind() == reflect.Pointer
+	elemType := sf.Type
+	if isPointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: opaque field %s must be a struct or pointer to struct, got %s", ErrUnsupportedType, sf.Name, sf.Type)
+	}
+	elem, err := b.typePlan(elemType, fieldOptions{})
+	if err != nil {
+		return fmt.Errorf("field %s: %w", sf.Name, err)
+	}
+
+	i := f.index
+	f.encode = func(enc *Encoder, v reflect.Value) error {
+		fv := v.Field(i)
+		if isPointer {
+			if fv.IsNil() {
+				return fmt.Errorf("%w: nil %s", ErrInvalidData, sf.Type)
+			}
+			fv = fv.Elem()
+		}
+		return enc.EncodeOpaque(reflectValue{elem, fv})
+	}
+	f.decode = func(dec *Decoder, v reflect.Value) error {
+		fv := v.Field(i)
+		if isPointer {
+			fv.Set(reflect.New(elemType))
+			fv = fv.Elem()
+		}
+		return dec.DecodeOpaque(reflectValue{elem, fv})
+	}
+	f.sized = true
+	return nil
+}
+
+// inlineField plans an embedded struct tagged xdr:"inline", whose fields are encoded in
+// place without its own Codec
+func (b *planBuilder) inlineField(f *reflectField, sf reflect.StructField) error {
+	isPointer := sf.Type.Kind() == reflect.Pointer
+	elemType := sf.Type
+	if isPointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: inline field %s must be an embedded struct, got %s", ErrUnsupportedType, sf.Name, sf.Type)
+	}
+	plan, err := b.structPlan(elemType)
+	if err != nil {
+		return err
+	}
+
+	i := f.index
+	f.encode = func(enc *Encoder, v reflect.Value) error {
+		fv := v.Field(i)
+		if isPointer {
+			if fv.IsNil() {
+				return fmt.Errorf("%w: embedded field %s is nil", ErrInvalidData, sf.Name)
+			}
+			fv = fv.Elem()
+		}
+		return plan.encode(enc, fv)
+	}
+	f.decode = func(dec *Decoder, v reflect.Value) error {
+		fv := v.Field(i)
+		if isPointer {
+			fv.Set(reflect.New(elemType))
+			fv = fv.Elem()
+		}
+		return plan.decode(dec, fv)
+	}
+	f.sized = plan.sized
+	return nil
+}
+
+// resolveCovers resolves the fields covered by each checksum field, in wire order
+func resolveCovers(s *structCodec, covers map[int]string) error {
+	for i, list := range covers {
+		f := &s.fields[i]
+		covered := make(map[string]bool)
+		for _, name := range strings.Split(list, "|") {
+			if name = strings.TrimSpace(name); name != "" {
+				covered[name] = true
+			}
+		}
+		if len(covered) == 0 {
+			return fmt.Errorf("%w: checksum field %s.%s must name the fields it covers", ErrUnsupportedType, s.typ, f.name)
+		}
+		var names []string
+		for j, other := range s.fields {
+			if !covered[other.name] {
+				continue
+			}
+			if j == i || other.name == "_" {
+				return fmt.Errorf("%w: checksum field %s.%s cannot cover %s", ErrUnsupportedType, s.typ, f.name, other.name)
+			}
+			delete(covered, other.name)
+			f.covers = append(f.covers, j)
+			names = append(names, other.name)
+		}
+		if len(covered) > 0 {
+			return fmt.Errorf("%w: checksum field %s.%s covers unknown fields %v", ErrUnsupportedType, s.typ, f.name, slices.Sorted(maps.Keys(covered)))
+		}
+		f.coverFor = names[0]
+		if n := len(names); n > 1 {
+			f.coverFor = strings.Join(names[:n-1], ", ") + " and " + names[n-1]
+		}
+	}
+	return nil
+}
+
+// encode encodes the struct v, following its list link iteratively
+func (s *structCodec) encode(enc *Encoder, v reflect.Value) error {
+	if s.recursive {
+		p := addressable(v).Addr().Interface()
+		if err := enc.Enter(p); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", s.typ.Name(), err)
+		}
+		defer enc.Leave(p)
+	}
+	// List nodes are not entered one by one, so a chain tracks its own nodes for cycles
+	var seen map[any]struct{}
+	if s.link >= 0 && enc.CycleCheck() {
+		seen = make(map[any]struct{})
+	}
+	for {
+		if seen != nil {
+			p := addressable(v).Addr().Interface()
+			if _, ok := seen[p]; ok {
+				return fmt.Errorf("failed to encode %s: %w", s.typ.Name(), ErrEncodingLoop)
+			}
+			seen[p] = struct{}{}
+		}
+		if err := s.encodeFields(enc, v); err != nil {
+			return err
+		}
+		if s.link < 0 {
+			return nil
+		}
+		next := v.Field(s.link)
+		if err := enc.EncodeBool(!next.IsNil()); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", s.typ.Field(s.link).Name, err)
+		}
+		if next.IsNil() {
+			return nil
+		}
+		v = next.Elem()
+	}
+}
+
+// encodeFields encodes the fields of one struct value, then fills in its checksums
+func (s *structCodec) encodeFields(enc *Encoder, v reflect.Value) error {
+	var spans [][2]int
+	if s.checksums {
+		spans = make([][2]int, len(s.fields))
+	}
+	for i := range s.fields {
+		f := &s.fields[i]
+		start := enc.Len()
+		if f.checksum != "" {
+			if err := enc.EncodeUint32(0); err != nil {
+				return fmt.Errorf("failed to encode %s: %w", f.label, err)
+			}
+		} else if err := f.encode(enc, v); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", f.label, err)
+		}
+		if spans != nil {
+			spans[i] = [2]int{start, enc.Len()}
+		}
+	}
+
+	for i := range s.fields {
+		f := &s.fields[i]
+		if f.checksum == "" {
+			continue
+		}
+		parts := make([][]byte, len(f.covers))
+		for j, c := range f.covers {
+			parts[j] = enc.Bytes()[spans[c][0]:spans[c][1]]
+		}
+		if err := enc.PatchUint32(spans[i][0], reflectChecksums[f.checksum](parts...)); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", f.label, err)
+		}
+	}
+	return nil
+}
+
+// decode decodes into the struct v, following its list link iteratively
+func (s *structCodec) decode(dec *Decoder, v reflect.Value) error {
+	if s.recursive {
+		if err := dec.Enter(); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", s.typ.Name(), err)
+		}
+		defer dec.Leave()
+	}
+	for {
+		if err := s.decodeFields(dec, v); err != nil {
+			return err
+		}
+		if s.link < 0 {
+			return nil
+		}
+		more, err := dec.DecodeBool()
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", s.typ.Field(s.link).Name, err)
+		}
+		next := v.Field(s.link)
+		if !more {
+			next.SetZero()
+			return nil
+		}
+		next.Set(reflect.New(s.typ))
+		v = next.Elem()
+	}
+}
+
+// decodeFields decodes the fields of one struct value, then verifies its checksums
+func (s *structCodec) decodeFields(dec *Decoder, v reflect.Value) error {
+	var spans [][2]int
+	if s.checksums {
+		spans = make([][2]int, len(s.fields))
+	}
+	for i := range s.fields {
+		f := &s.fields[i]
+		start := dec.Position()
+		var err error
+		if f.checksum != "" {
+			var sum uint32
+			if sum, err = dec.DecodeUint32(); err == nil {
+				v.Field(f.index).SetUint(uint64(sum))
+			}
+		} else {
+			err = f.decode(dec, v)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", f.label, err)
+		}
+		if spans != nil {
+			spans[i] = [2]int{start, dec.Position()}
+		}
+	}
+
+	for i := range s.fields {
+		f := &s.fields[i]
+		if f.checksum == "" {
+			continue
+		}
+		parts := make([][]byte, len(f.covers))
+		for j, c := range f.covers {
+			parts[j] = dec.GetSlice(spans[c][0], spans[c][1])
+		}
+		// #nosec G115
+		want := uint32(v.Field(f.index).Uint())
+		if sum := reflectChecksums[f.checksum](parts...); sum != want {
+			verb := "%#x"
+			if f.checksum == "len" {
+				verb = "%d"
+			}
+			return fmt.Errorf("%w: %s is "+verb+", computed "+verb+" over %s", ErrChecksumMismatch, f.name, want, sum, f.coverFor)
+		}
+	}
+	return nil
+}
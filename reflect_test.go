@@ -0,0 +1,445 @@
+package xdr
+
+import (
+	"hash/crc32"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reflectStatus int32
+
+type reflectPlain struct {
+	ID   uint32
+	Name string
+}
+
+type reflectFields struct {
+	_       uint32 `xdr:"const=0xCAFE"`
+	Flag    bool
+	Small   int8
+	Mode    uint16
+	Count   int
+	Big     uint64
+	Status  reflectStatus
+	Data    []byte
+	Digest  [5]byte
+	Tags    []string    `xdr:"max=4"`
+	Grid    [2][]uint32 `xdr:"max=|3"`
+	Options map[string]uint32
+	Set     map[uint32]struct{}
+	Child   *reflectPlain
+	Nested  TestType
+	When    time.Time `xdr:"time=nfs"`
+	TTL     time.Duration
+	_       [3]byte `xdr:"reserved=3"`
+	Skipped string  `xdr:"-"`
+}
+
+// encodeReflectFields encodes v the way generated code would
+func encodeReflectFields(t *testing.T, v *reflectFields) []byte {
+	t.Helper()
+	enc := NewEncoder(make([]byte, 512))
+	require.NoError(t, enc.EncodeUint32(0xCAFE))
+	require.NoError(t, enc.EncodeBool(v.Flag))
+	require.NoError(t, enc.EncodeInt8(v.Small))
+	require.NoError(t, enc.EncodeUint16(v.Mode))
+	require.NoError(t, enc.EncodeInt(v.Count))
+	require.NoError(t, enc.EncodeUint64(v.Big))
+	require.NoError(t, enc.EncodeInt32(int32(v.Status)))
+	require.NoError(t, enc.EncodeBytes(v.Data))
+	require.NoError(t, enc.EncodeFixedBytes(v.Digest[:]))
+	require.NoError(t, enc.EncodeUint32(uint32(len(v.Tags))))
+	for _, tag := range v.Tags {
+		require.NoError(t, enc.EncodeString(tag))
+	}
+	for _, row := range v.Grid {
+		require.NoError(t, enc.EncodeUint32(uint32(len(row))))
+		for _, x := range row {
+			require.NoError(t, enc.EncodeUint32(x))
+		}
+	}
+	require.NoError(t, enc.EncodeUint32(uint32(len(v.Options))))
+	for _, key := range SortedKeys(v.Options) {
+		require.NoError(t, enc.EncodeString(key))
+		require.NoError(t, enc.EncodeUint32(v.Options[key]))
+	}
+	require.NoError(t, enc.EncodeUint32(uint32(len(v.Set))))
+	for _, key := range SortedKeys(v.Set) {
+		require.NoError(t, enc.EncodeUint32(key))
+	}
+	require.NoError(t, enc.EncodeUint32(v.Child.ID))
+	require.NoError(t, enc.EncodeString(v.Child.Name))
+	require.NoError(t, v.Nested.Encode(enc))
+	require.NoError(t, enc.EncodeTimeNFS(v.When))
+	require.NoError(t, enc.EncodeDurationUnixNano(v.TTL))
+	require.NoError(t, enc.EncodeReserved(3))
+	return enc.Bytes()
+}
+
+func TestMarshalReflect(t *testing.T) {
+	original := &reflectFields{
+		Flag:    true,
+		Small:   -5,
+		Mode:    0o755,
+		Count:   -1 << 40,
+		Big:     1 << 63,
+		Status:  -2,
+		Data:    []byte("abcde"),
+		Digest:  [5]byte{1, 2, 3, 4, 5},
+		Tags:    []string{"a", "bc"},
+		Grid:    [2][]uint32{{1, 2, 3}, {}},
+		Options: map[string]uint32{"z": 26, "a": 1, "m": 13},
+		Set:     map[uint32]struct{}{9: {}, 3: {}, 7: {}},
+		Child:   &reflectPlain{ID: 7, Name: "child"},
+		Nested:  TestType{ID: 8, Name: "nested"},
+		When:    time.Unix(1700000000, 42).UTC(),
+		TTL:     90 * time.Second,
+		Skipped: "not encoded",
+	}
+
+	data, err := MarshalReflect(original)
+	require.NoError(t, err)
+	assert.Equal(t, encodeReflectFields(t, original), data)
+
+	var decoded reflectFields
+	require.NoError(t, UnmarshalReflect(data, &decoded))
+	want := *original
+	want.Skipped = ""
+	assert.Equal(t, want, decoded)
+}
+
+type reflectTimes struct {
+	Stamps []time.Time `xdr:"time=nfs"`
+	Window [2]time.Duration
+}
+
+func TestMarshalReflectTimeArrays(t *testing.T) {
+	// Time layouts apply to every element of an array
+	v := &reflectTimes{
+		Stamps: []time.Time{time.Unix(1700000000, 1).UTC(), time.Unix(1700000001, 2).UTC()},
+		Window: [2]time.Duration{time.Second, time.Minute},
+	}
+	enc := NewEncoder(make([]byte, 64))
+	require.NoError(t, enc.EncodeUint32(2))
+	for _, stamp := range v.Stamps {
+		require.NoError(t, enc.EncodeTimeNFS(stamp))
+	}
+	for _, d := range v.Window {
+		require.NoError(t, enc.EncodeDurationUnixNano(d))
+	}
+
+	data, err := MarshalReflect(v)
+	require.NoError(t, err)
+	assert.Equal(t, enc.Bytes(), data)
+
+	var decoded reflectTimes
+	require.NoError(t, UnmarshalReflect(data, &decoded))
+	assert.Equal(t, v, &decoded)
+}
+
+func TestMarshalReflectMatchesCodec(t *testing.T) {
+	plain, err := MarshalReflect(reflectPlain{ID: 42, Name: "same"})
+	require.NoError(t, err)
+	generated, err := Marshal(&TestType{ID: 42, Name: "same"})
+	require.NoError(t, err)
+	assert.Equal(t, generated, plain)
+
+	// Codec types are encoded with their own methods
+	direct, err := MarshalReflect(&TestType{ID: 42, Name: "same"})
+	require.NoError(t, err)
+	assert.Equal(t, generated, direct)
+}
+
+type reflectUnion struct {
+	Status  reflectStatus
+	Result  []byte `xdr:"union=Status,void=0|-1"`
+	Follows bool
+	Attr    []byte `xdr:"union=Follows,void=false"`
+}
+
+func TestMarshalReflectUnion(t *testing.T) {
+	tests := []struct {
+		name  string
+		value reflectUnion
+		want  func(enc *Encoder) error
+	}{
+		{"void", reflectUnion{Status: -1, Result: []byte("ignored")}, func(enc *Encoder) error {
+			if err := enc.EncodeInt32(-1); err != nil {
+				return err
+			}
+			return enc.EncodeBool(false)
+		}},
+		{"payloads", reflectUnion{Status: 2, Result: []byte("ok"), Follows: true, Attr: []byte{1}}, func(enc *Encoder) error {
+			if err := enc.EncodeInt32(2); err != nil {
+				return err
+			}
+			if err := enc.EncodeBytes([]byte("ok")); err != nil {
+				return err
+			}
+			if err := enc.EncodeBool(true); err != nil {
+				return err
+			}
+			return enc.EncodeBytes([]byte{1})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := NewEncoder(make([]byte, 64))
+			require.NoError(t, tt.want(enc))
+
+			data, err := MarshalReflect(&tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, enc.Bytes(), data)
+
+			var decoded reflectUnion
+			require.NoError(t, UnmarshalReflect(data, &decoded))
+			assert.Equal(t, tt.value.Status, decoded.Status)
+			if tt.value.Status > 0 {
+				assert.Equal(t, tt.value.Result, decoded.Result)
+			} else {
+				assert.Nil(t, decoded.Result)
+			}
+		})
+	}
+}
+
+type reflectPolicies struct {
+	Status   reflectStatus
+	Strict   []byte `xdr:"union=Status,void=0,arms=1,unknown=reject"`
+	Captured []byte `xdr:"union=Status,void=0|2,arms=1,unknown=capture"`
+	Defaults []byte `xdr:"union=Status,arms=1,default=nil,unknown=reject"`
+}
+
+func TestMarshalReflectUnionPolicies(t *testing.T) {
+	value := reflectPolicies{Status: 1, Strict: []byte{1}, Captured: []byte{2}, Defaults: []byte{3}}
+	data, err := MarshalReflect(&value)
+	require.NoError(t, err)
+	var decoded reflectPolicies
+	require.NoError(t, UnmarshalReflect(data, &decoded))
+	assert.Equal(t, value, decoded)
+
+	_, err = MarshalReflect(&reflectPolicies{Status: 2})
+	assert.ErrorIs(t, err, ErrUnknownDiscriminant)
+	err = UnmarshalReflect([]byte{0, 0, 0, 9}, &decoded)
+	assert.ErrorIs(t, err, ErrUnknownDiscriminant)
+
+	// Without the reject policy, unknown discriminants capture an arm or fall to the void default
+	type lenient struct {
+		Status   reflectStatus
+		Captured []byte `xdr:"union=Status,void=0|2,arms=1,unknown=capture"`
+		Defaults []byte `xdr:"union=Status,arms=1,default=nil,unknown=reject"`
+	}
+	data, err = MarshalReflect(&lenient{Status: 9, Captured: []byte{7}, Defaults: []byte("ignored")})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 9, 0, 0, 0, 1, 7, 0, 0, 0}, data)
+}
+
+type reflectFrame struct {
+	Length uint32 `xdr:"len=Body"`
+	CRC    uint32 `xdr:"crc32=Header|Body"`
+	Header reflectPlain
+	Body   []byte
+}
+
+func TestMarshalReflectChecksum(t *testing.T) {
+	data, err := MarshalReflect(&reflectFrame{Header: reflectPlain{ID: 1, Name: "h"}, Body: []byte("hello")})
+	require.NoError(t, err)
+
+	dec := NewDecoder(data)
+	length, err := dec.DecodeUint32()
+	require.NoError(t, err)
+	sum, err := dec.DecodeUint32()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(12), length)
+	assert.Equal(t, crc32.ChecksumIEEE(data[8:]), sum)
+
+	var decoded reflectFrame
+	require.NoError(t, UnmarshalReflect(data, &decoded))
+	assert.Equal(t, sum, decoded.CRC)
+
+	data[len(data)-4] ^= 0xFF
+	err = UnmarshalReflect(data, &decoded)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+type reflectEntry struct {
+	FileID uint64
+	Next   *reflectEntry `xdr:"list"`
+}
+
+type reflectDirList struct {
+	Entries *reflectEntry  `xdr:"list"`
+	Items   []reflectPlain `xdr:"list"`
+	EOF     bool
+}
+
+func TestMarshalReflectList(t *testing.T) {
+	list := &reflectDirList{
+		Entries: &reflectEntry{FileID: 1, Next: &reflectEntry{FileID: 2}},
+		Items:   []reflectPlain{{ID: 3, Name: "item"}},
+		EOF:     true,
+	}
+
+	want := func() []byte {
+		enc := NewEncoder(make([]byte, 128))
+		require.NoError(t, enc.EncodeBool(true))
+		require.NoError(t, enc.EncodeUint64(1))
+		require.NoError(t, enc.EncodeBool(true))
+		require.NoError(t, enc.EncodeUint64(2))
+		require.NoError(t, enc.EncodeBool(false))
+		require.NoError(t, enc.EncodeBool(true))
+		require.NoError(t, (&TestType{ID: 3, Name: "item"}).Encode(enc))
+		require.NoError(t, enc.EncodeBool(false))
+		require.NoError(t, enc.EncodeBool(true))
+		return enc.Bytes()
+	}()
+
+	data, err := MarshalReflect(list)
+	require.NoError(t, err)
+	assert.Equal(t, want, data)
+
+	var decoded reflectDirList
+	require.NoError(t, UnmarshalReflect(data, &decoded))
+	assert.Equal(t, list, &decoded)
+}
+
+func TestMarshalReflectCyclicList(t *testing.T) {
+	head := &reflectEntry{FileID: 1, Next: &reflectEntry{FileID: 2}}
+	head.Next.Next = head
+	plan, err := reflectPlanFor(reflect.TypeFor[reflectEntry]())
+	require.NoError(t, err)
+
+	enc := NewEncoder(make([]byte, 1024))
+	enc.SetCycleCheck(true)
+	err = plan.encode(enc, reflect.ValueOf(head).Elem())
+	assert.ErrorIs(t, err, ErrEncodingLoop)
+}
+
+func TestUnmarshalReflectLongList(t *testing.T) {
+	// Longer than DefaultMaxDepth: lists are walked without nesting
+	const n = 1000
+	enc := NewEncoder(make([]byte, n*12+8))
+	for i := range n {
+		require.NoError(t, enc.EncodeBool(true))
+		require.NoError(t, enc.EncodeUint64(uint64(i)))
+	}
+	require.NoError(t, enc.EncodeBool(false))
+	require.NoError(t, enc.EncodeBool(false))
+
+	var decoded struct {
+		Entries *reflectEntry `xdr:"list"`
+		EOF     bool
+	}
+	require.NoError(t, UnmarshalReflect(enc.Bytes(), &decoded))
+	count := 0
+	for e := decoded.Entries; e != nil; e = e.Next {
+		assert.Equal(t, uint64(count), e.FileID)
+		count++
+	}
+	assert.Equal(t, n, count)
+}
+
+type reflectNode struct {
+	Value    uint32
+	Children []*reflectNode
+}
+
+func TestUnmarshalReflectDepth(t *testing.T) {
+	// Each level is a value and a single child
+	const levels = DefaultMaxDepth + 10
+	enc := NewEncoder(make([]byte, levels*8+8))
+	for range levels {
+		require.NoError(t, enc.EncodeUint32(0))
+		require.NoError(t, enc.EncodeUint32(1))
+	}
+	require.NoError(t, enc.EncodeUint32(0))
+	require.NoError(t, enc.EncodeUint32(0))
+
+	var node reflectNode
+	err := UnmarshalReflect(enc.Bytes(), &node)
+	assert.ErrorIs(t, err, ErrMaxDepthExceeded)
+}
+
+type reflectBounded struct {
+	Values []uint32 `xdr:"max=2"`
+}
+
+type reflectHeader struct {
+	Magic uint32 `xdr:"const=0x58445200"`
+}
+
+type reflectHidden struct {
+	ID     uint32
+	secret string
+}
+
+type reflectNarrow struct {
+	Small int8
+}
+
+func TestMarshalReflectErrors(t *testing.T) {
+	_, err := MarshalReflect(&reflectBounded{Values: []uint32{1, 2, 3}})
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	enc := NewEncoder(make([]byte, 32))
+	require.NoError(t, enc.EncodeUint32(3))
+	err = UnmarshalReflect(enc.Bytes(), &reflectBounded{})
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	err = UnmarshalReflect([]byte{0, 0, 0, 1}, &reflectHeader{})
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	err = UnmarshalReflect([]byte{0, 0, 1, 0}, &reflectNarrow{})
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = MarshalReflect(&reflectHidden{ID: 1, secret: "x"})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	_, err = MarshalReflect(struct{ F float64 }{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	// Union policies reflection cannot honour
+	_, err = MarshalReflect(struct {
+		Kind uint32
+		Body []byte `xdr:"union=Kind,void=0,unknown=reject"`
+	}{})
+	assert.ErrorIs(t, err, ErrUnsupportedType, "unknown policy without arms")
+	_, err = MarshalReflect(struct {
+		Kind uint32
+		Body []byte `xdr:"union=Kind,arms=1,unknown=capture"`
+	}{})
+	assert.ErrorIs(t, err, ErrUnsupportedType, "inferred void discriminants")
+	_, err = MarshalReflect(struct {
+		Kind uint32
+		Body []byte `xdr:"union=Kind,arms=1,unknown=skip"`
+	}{})
+	assert.ErrorIs(t, err, ErrUnsupportedType, "invalid unknown policy")
+
+	_, err = MarshalReflect(&reflectFields{})
+	assert.ErrorIs(t, err, ErrInvalidData, "nil pointer field")
+
+	err = UnmarshalReflect(nil, reflectPlain{})
+	assert.ErrorIs(t, err, ErrInvalidData, "non-pointer target")
+}
+
+func TestReflectPlanCache(t *testing.T) {
+	first, err := reflectPlanFor(reflect.TypeFor[reflectFields]())
+	require.NoError(t, err)
+	second, err := reflectPlanFor(reflect.TypeFor[reflectFields]())
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+}
+
+func BenchmarkMarshalReflect(b *testing.B) {
+	v := &reflectPlain{ID: 1, Name: "benchmark"}
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := MarshalReflect(v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
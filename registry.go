@@ -0,0 +1,129 @@
+package xdr
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Interface values are written as the uint32 ID their dynamic type is registered
+// under, followed by the value as variable-length opaque data. Readers look the ID up
+// in the same registry to allocate the value to decode into.
+
+// TypeRegistry maps uint32 type IDs to the Codec types an interface may hold
+type TypeRegistry struct {
+	mu        sync.RWMutex
+	factories map[uint32]func() Codec
+	ids       map[reflect.Type]uint32
+}
+
+// NewTypeRegistry returns an empty registry
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		factories: make(map[uint32]func() Codec),
+		ids:       make(map[reflect.Type]uint32),
+	}
+}
+
+// Register adds the type returned by factory under id
+// The factory returns a new value to decode into, usually a pointer such as &T{}
+// Each id and each type can only be registered once
+func (r *TypeRegistry) Register(id uint32, factory func() Codec) error {
+	v := factory()
+	if v == nil {
+		return fmt.Errorf("factory for type ID %d returned nil", id)
+	}
+	t := reflect.TypeOf(v)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.factories[id]; exists {
+		return fmt.Errorf("type ID %d is already registered", id)
+	}
+	if existing, exists := r.ids[t]; exists {
+		return fmt.Errorf("type %s is already registered with ID %d", t, existing)
+	}
+	r.factories[id] = factory
+	r.ids[t] = id
+	return nil
+}
+
+// MustRegister is like Register but panics on error, for use in init functions
+func (r *TypeRegistry) MustRegister(id uint32, factory func() Codec) {
+	if err := r.Register(id, factory); err != nil {
+		panic(err)
+	}
+}
+
+// TypeID returns the ID the dynamic type of v is registered under
+func (r *TypeRegistry) TypeID(v Codec) (uint32, error) {
+	t := reflect.TypeOf(v)
+	r.mu.RLock()
+	id, ok := r.ids[t]
+	r.mu.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("%w: %v", ErrUnregisteredType, t)
+	}
+	return id, nil
+}
+
+// New returns a new value of the type registered under id
+func (r *TypeRegistry) New(id uint32) (Codec, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[id]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: type ID %d", ErrUnregisteredType, id)
+	}
+	return factory(), nil
+}
+
+// Encode encodes v as its type ID followed by its encoding as opaque data
+func (r *TypeRegistry) Encode(enc *Encoder, v Codec) error {
+	if v == nil {
+		return fmt.Errorf("%w: cannot encode a nil interface value", ErrInvalidData)
+	}
+	// A nil pointer in a non-nil interface would panic in its Encode method
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return fmt.Errorf("%w: cannot encode a nil %T", ErrInvalidData, v)
+	}
+	id, err := r.TypeID(v)
+	if err != nil {
+		return err
+	}
+	if err := enc.EncodeUint32(id); err != nil {
+		return err
+	}
+	return enc.EncodeOpaque(v)
+}
+
+// Decode decodes a value written by Encode into a new value of its registered type
+func (r *TypeRegistry) Decode(dec *Decoder) (Codec, error) {
+	id, err := dec.DecodeUint32()
+	if err != nil {
+		return nil, err
+	}
+	v, err := r.New(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := dec.DecodeOpaque(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DecodeInterface decodes a value written by TypeRegistry.Encode and returns it as T,
+// the interface type of the field it is decoded into
+func DecodeInterface[T any](dec *Decoder, r *TypeRegistry) (T, error) {
+	var zero T
+	v, err := r.Decode(dec)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := v.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %T does not implement %v", ErrInvalidData, v, reflect.TypeFor[T]())
+	}
+	return typed, nil
+}
@@ -0,0 +1,63 @@
+package xdr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeRegistry(t *testing.T) {
+	r := NewTypeRegistry()
+	require.NoError(t, r.Register(1, func() Codec { return &TestType{} }))
+	require.NoError(t, r.Register(2, func() Codec { return &NestedStruct{} }))
+
+	enc := NewEncoder(make([]byte, 64))
+	require.NoError(t, r.Encode(enc, &TestType{ID: 7, Name: "ab"}))
+	assert.Equal(t, []byte{
+		0, 0, 0, 1,
+		0, 0, 0, 12,
+		0, 0, 0, 7, 0, 0, 0, 2, 'a', 'b', 0, 0,
+	}, enc.Bytes(), "Interface values should be a type ID and an opaque body")
+
+	v, err := r.Decode(NewDecoder(enc.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, &TestType{ID: 7, Name: "ab"}, v)
+
+	id, err := r.TypeID(&NestedStruct{})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), id)
+}
+
+func TestTypeRegistryErrors(t *testing.T) {
+	r := NewTypeRegistry()
+	r.MustRegister(1, func() Codec { return &TestType{} })
+
+	t.Run("duplicates", func(t *testing.T) {
+		assert.Error(t, r.Register(1, func() Codec { return &NestedStruct{} }), "IDs should be unique")
+		assert.Error(t, r.Register(2, func() Codec { return &TestType{} }), "Types should be registered once")
+		assert.Error(t, r.Register(3, func() Codec { return nil }))
+		assert.Panics(t, func() { r.MustRegister(1, func() Codec { return &TestType{} }) })
+	})
+
+	t.Run("unregistered", func(t *testing.T) {
+		enc := NewEncoder(make([]byte, 64))
+		assert.ErrorIs(t, r.Encode(enc, &NestedStruct{}), ErrUnregisteredType)
+		assert.ErrorIs(t, r.Encode(enc, nil), ErrInvalidData)
+		assert.ErrorIs(t, r.Encode(enc, (*TestType)(nil)), ErrInvalidData, "Typed nil pointers should be rejected")
+
+		_, err := r.Decode(NewDecoder([]byte{0, 0, 0, 9, 0, 0, 0, 0}))
+		assert.ErrorIs(t, err, ErrUnregisteredType)
+	})
+
+	t.Run("interface mismatch", func(t *testing.T) {
+		enc := NewEncoder(make([]byte, 64))
+		require.NoError(t, r.Encode(enc, &TestType{ID: 1}))
+		_, err := DecodeInterface[interface{ Unknown() }](NewDecoder(enc.Bytes()), r)
+		assert.ErrorIs(t, err, ErrInvalidData)
+
+		v, err := DecodeInterface[Codec](NewDecoder(enc.Bytes()), r)
+		require.NoError(t, err)
+		assert.Equal(t, &TestType{ID: 1}, v)
+	})
+}
@@ -0,0 +1,42 @@
+package xdr
+
+// Reserved areas are runs of zero bytes in a protocol header, padded to a multiple of
+// four like fixed-length opaque data. Decoding skips them, or with DecodeReserved
+// requires them to still be zero.
+
+// EncodeReserved encodes n zero bytes and their padding
+func (e *Encoder) EncodeReserved(n int) error {
+	totalLen := n + (4-(n%4))%4
+	if n < 0 || e.pos+totalLen > len(e.buf) {
+		return ErrBufferTooSmall
+	}
+	clear(e.buf[e.pos : e.pos+totalLen])
+	e.pos += totalLen
+	return nil
+}
+
+// SkipReserved skips n reserved bytes and their padding without checking them
+func (d *Decoder) SkipReserved(n int) error {
+	totalLen := n + (4-(n%4))%4
+	if n < 0 || d.pos+totalLen > len(d.buf) {
+		return ErrUnexpectedEOF
+	}
+	d.pos += totalLen
+	return nil
+}
+
+// DecodeReserved skips n reserved bytes and their padding, returning ErrInvalidData
+// if any of them is not zero
+func (d *Decoder) DecodeReserved(n int) error {
+	totalLen := n + (4-(n%4))%4
+	if n < 0 || d.pos+totalLen > len(d.buf) {
+		return ErrUnexpectedEOF
+	}
+	for _, b := range d.buf[d.pos : d.pos+totalLen] {
+		if b != 0 {
+			return ErrInvalidData
+		}
+	}
+	d.pos += totalLen
+	return nil
+}
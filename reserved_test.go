@@ -0,0 +1,30 @@
+package xdr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReserved(t *testing.T) {
+	buf := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	enc := NewEncoder(buf)
+	require.NoError(t, enc.EncodeReserved(6))
+	require.NoError(t, enc.EncodeUint32(1))
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, enc.Bytes(), "Reserved bytes should be zeroed and padded")
+	assert.ErrorIs(t, enc.EncodeReserved(1), ErrBufferTooSmall)
+
+	dec := NewDecoder(enc.Bytes())
+	require.NoError(t, dec.DecodeReserved(6))
+	v, err := dec.DecodeUint32()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), v)
+
+	dec = NewDecoder([]byte{0, 0, 1, 0, 0, 0, 0, 2})
+	assert.ErrorIs(t, dec.DecodeReserved(4), ErrInvalidData)
+	require.NoError(t, dec.SkipReserved(4), "SkipReserved should ignore the content")
+	assert.Equal(t, 4, dec.Remaining())
+	assert.ErrorIs(t, dec.SkipReserved(8), ErrUnexpectedEOF)
+	assert.ErrorIs(t, dec.DecodeReserved(8), ErrUnexpectedEOF)
+}
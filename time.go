@@ -0,0 +1,171 @@
+package xdr
+
+import (
+	"math"
+	"time"
+)
+
+// Wire layouts for time.Time and time.Duration values:
+//   - NFS:      uint32 seconds, uint32 nanoseconds (NFSv3 nfstime3)
+//   - Timespec: int64 seconds, uint32 nanoseconds (NFSv4 nfstime4)
+//   - UnixNano: int64 nanoseconds
+//
+// Times are measured from the Unix epoch and decode in UTC.
+
+const nanosPerSecond = int64(time.Second)
+
+// Range of times representable as int64 Unix nanoseconds
+var (
+	minUnixNano = time.Unix(0, math.MinInt64)
+	maxUnixNano = time.Unix(0, math.MaxInt64)
+)
+
+// EncodeTimeNFS encodes a time as uint32 seconds and nanoseconds
+func (e *Encoder) EncodeTimeNFS(t time.Time) error {
+	sec := t.Unix()
+	if sec < 0 || sec > math.MaxUint32 {
+		return ErrOverflow
+	}
+	// #nosec G115
+	if err := e.EncodeUint32(uint32(sec)); err != nil {
+		return err
+	}
+	// #nosec G115
+	return e.EncodeUint32(uint32(t.Nanosecond()))
+}
+
+// EncodeTimeTimespec encodes a time as int64 seconds and uint32 nanoseconds
+func (e *Encoder) EncodeTimeTimespec(t time.Time) error {
+	if err := e.EncodeInt64(t.Unix()); err != nil {
+		return err
+	}
+	// #nosec G115
+	return e.EncodeUint32(uint32(t.Nanosecond()))
+}
+
+// EncodeTimeUnixNano encodes a time as int64 nanoseconds
+func (e *Encoder) EncodeTimeUnixNano(t time.Time) error {
+	if t.Before(minUnixNano) || t.After(maxUnixNano) {
+		return ErrOverflow
+	}
+	return e.EncodeInt64(t.UnixNano())
+}
+
+// EncodeDurationNFS encodes a non-negative duration as uint32 seconds and nanoseconds
+func (e *Encoder) EncodeDurationNFS(d time.Duration) error {
+	sec := int64(d) / nanosPerSecond
+	if d < 0 || sec > math.MaxUint32 {
+		return ErrOverflow
+	}
+	// #nosec G115
+	if err := e.EncodeUint32(uint32(sec)); err != nil {
+		return err
+	}
+	// #nosec G115
+	return e.EncodeUint32(uint32(int64(d) % nanosPerSecond))
+}
+
+// EncodeDurationTimespec encodes a duration as int64 seconds and uint32 nanoseconds
+// Negative durations use floored seconds so the nanoseconds stay in [0, 1e9)
+func (e *Encoder) EncodeDurationTimespec(d time.Duration) error {
+	sec, nsec := int64(d)/nanosPerSecond, int64(d)%nanosPerSecond
+	if nsec < 0 {
+		sec--
+		nsec += nanosPerSecond
+	}
+	if err := e.EncodeInt64(sec); err != nil {
+		return err
+	}
+	// #nosec G115
+	return e.EncodeUint32(uint32(nsec))
+}
+
+// EncodeDurationUnixNano encodes a duration as int64 nanoseconds
+func (e *Encoder) EncodeDurationUnixNano(d time.Duration) error {
+	return e.EncodeInt64(int64(d))
+}
+
+// decodeNanoseconds decodes a uint32 nanoseconds field, rejecting values of a second or more
+func (d *Decoder) decodeNanoseconds() (int64, error) {
+	nsec, err := d.DecodeUint32()
+	if err != nil {
+		return 0, err
+	}
+	if int64(nsec) >= nanosPerSecond {
+		return 0, ErrInvalidData
+	}
+	return int64(nsec), nil
+}
+
+// DecodeTimeNFS decodes a time encoded as uint32 seconds and nanoseconds
+func (d *Decoder) DecodeTimeNFS() (time.Time, error) {
+	sec, err := d.DecodeUint32()
+	if err != nil {
+		return time.Time{}, err
+	}
+	nsec, err := d.decodeNanoseconds()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(sec), nsec).UTC(), nil
+}
+
+// DecodeTimeTimespec decodes a time encoded as int64 seconds and uint32 nanoseconds
+func (d *Decoder) DecodeTimeTimespec() (time.Time, error) {
+	sec, err := d.DecodeInt64()
+	if err != nil {
+		return time.Time{}, err
+	}
+	nsec, err := d.decodeNanoseconds()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// DecodeTimeUnixNano decodes a time encoded as int64 nanoseconds
+func (d *Decoder) DecodeTimeUnixNano() (time.Time, error) {
+	nsec, err := d.DecodeInt64()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, nsec).UTC(), nil
+}
+
+// DecodeDurationNFS decodes a duration encoded as uint32 seconds and nanoseconds
+func (d *Decoder) DecodeDurationNFS() (time.Duration, error) {
+	sec, err := d.DecodeUint32()
+	if err != nil {
+		return 0, err
+	}
+	nsec, err := d.decodeNanoseconds()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(int64(sec)*nanosPerSecond + nsec), nil
+}
+
+// DecodeDurationTimespec decodes a duration encoded as int64 seconds and uint32 nanoseconds
+func (d *Decoder) DecodeDurationTimespec() (time.Duration, error) {
+	sec, err := d.DecodeInt64()
+	if err != nil {
+		return 0, err
+	}
+	nsec, err := d.decodeNanoseconds()
+	if err != nil {
+		return 0, err
+	}
+	if sec < math.MinInt64/nanosPerSecond || sec > (math.MaxInt64-nsec)/nanosPerSecond {
+		return 0, ErrOverflow
+	}
+	return time.Duration(sec*nanosPerSecond + nsec), nil
+}
+
+// DecodeDurationUnixNano decodes a duration encoded as int64 nanoseconds
+func (d *Decoder) DecodeDurationUnixNano() (time.Duration, error) {
+	nsec, err := d.DecodeInt64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(nsec), nil
+}
@@ -0,0 +1,109 @@
+package xdr
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeLayouts(t *testing.T) {
+	stamp := time.Date(2024, time.March, 9, 12, 30, 45, 123456789, time.UTC)
+
+	t.Run("NFS", func(t *testing.T) {
+		enc := NewEncoder(make([]byte, 16))
+		require.NoError(t, enc.EncodeTimeNFS(stamp))
+		assert.Len(t, enc.Bytes(), 8)
+
+		got, err := NewDecoder(enc.Bytes()).DecodeTimeNFS()
+		require.NoError(t, err)
+		assert.True(t, stamp.Equal(got), "Expected %v, got %v", stamp, got)
+		assert.Equal(t, time.UTC, got.Location())
+
+		assert.ErrorIs(t, enc.EncodeTimeNFS(time.Unix(-1, 0)), ErrOverflow)
+		assert.ErrorIs(t, enc.EncodeTimeNFS(time.Unix(math.MaxUint32+1, 0)), ErrOverflow)
+	})
+
+	t.Run("Timespec", func(t *testing.T) {
+		before := time.Date(1901, time.January, 1, 0, 0, 0, 5, time.UTC)
+		enc := NewEncoder(make([]byte, 32))
+		require.NoError(t, enc.EncodeTimeTimespec(stamp))
+		require.NoError(t, enc.EncodeTimeTimespec(before))
+		assert.Len(t, enc.Bytes(), 24)
+
+		dec := NewDecoder(enc.Bytes())
+		got, err := dec.DecodeTimeTimespec()
+		require.NoError(t, err)
+		assert.True(t, stamp.Equal(got), "Expected %v, got %v", stamp, got)
+		got, err = dec.DecodeTimeTimespec()
+		require.NoError(t, err)
+		assert.True(t, before.Equal(got), "Expected %v, got %v", before, got)
+	})
+
+	t.Run("UnixNano", func(t *testing.T) {
+		enc := NewEncoder(make([]byte, 8))
+		require.NoError(t, enc.EncodeTimeUnixNano(stamp))
+
+		got, err := NewDecoder(enc.Bytes()).DecodeTimeUnixNano()
+		require.NoError(t, err)
+		assert.True(t, stamp.Equal(got), "Expected %v, got %v", stamp, got)
+
+		assert.ErrorIs(t, enc.EncodeTimeUnixNano(time.Time{}), ErrOverflow)
+	})
+
+	t.Run("Invalid nanoseconds", func(t *testing.T) {
+		data := []byte{0, 0, 0, 1, 0x3b, 0x9a, 0xca, 0x00} // 1e9 nanoseconds
+		_, err := NewDecoder(data).DecodeTimeNFS()
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+}
+
+func TestDurationLayouts(t *testing.T) {
+	durations := []time.Duration{0, 1500 * time.Millisecond, 90 * time.Minute, -1500 * time.Millisecond}
+
+	for _, d := range durations {
+		enc := NewEncoder(make([]byte, 64))
+		if d >= 0 {
+			require.NoError(t, enc.EncodeDurationNFS(d))
+		}
+		require.NoError(t, enc.EncodeDurationTimespec(d))
+		require.NoError(t, enc.EncodeDurationUnixNano(d))
+
+		dec := NewDecoder(enc.Bytes())
+		if d >= 0 {
+			got, err := dec.DecodeDurationNFS()
+			require.NoError(t, err)
+			assert.Equal(t, d, got, "NFS layout")
+		}
+		got, err := dec.DecodeDurationTimespec()
+		require.NoError(t, err)
+		assert.Equal(t, d, got, "Timespec layout")
+		got, err = dec.DecodeDurationUnixNano()
+		require.NoError(t, err)
+		assert.Equal(t, d, got, "UnixNano layout")
+	}
+
+	t.Run("Negative timespec nanoseconds are normalized", func(t *testing.T) {
+		enc := NewEncoder(make([]byte, 12))
+		require.NoError(t, enc.EncodeDurationTimespec(-1500*time.Millisecond))
+		dec := NewDecoder(enc.Bytes())
+		sec, err := dec.DecodeInt64()
+		require.NoError(t, err)
+		nsec, err := dec.DecodeUint32()
+		require.NoError(t, err)
+		assert.Equal(t, int64(-2), sec)
+		assert.Equal(t, uint32(500000000), nsec)
+	})
+
+	t.Run("Out of range", func(t *testing.T) {
+		enc := NewEncoder(make([]byte, 16))
+		assert.ErrorIs(t, enc.EncodeDurationNFS(-time.Second), ErrOverflow)
+
+		require.NoError(t, enc.EncodeInt64(math.MaxInt64/int64(time.Second)+1))
+		require.NoError(t, enc.EncodeUint32(0))
+		_, err := NewDecoder(enc.Bytes()).DecodeDurationTimespec()
+		assert.ErrorIs(t, err, ErrOverflow)
+	})
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// foreignPackage holds the declarations of another package needed to resolve qualified union references
+type foreignPackage struct {
+	ImportPath  string
+	TypeDefs    map[string]ast.Node     // type name -> declaration
+	TypeAliases map[string]string       // type name -> underlying type name
+	Constants   map[string]ConstantInfo // constant name -> value and type
+}
+
+// foreignPackageCache caches loaded packages by import path
+var foreignPackageCache = make(map[string]*foreignPackage)
+
+// splitQualified splits a qualified reference like proto.MsgText into its package qualifier and name
+func splitQualified(ref string) (qualifier, name string, ok bool) {
+	parts := strings.Split(ref, ".")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", ref, false
+	}
+	return parts[0], parts[1], true
+}
+
+// isQualifiedRef reports whether a reference names a declaration in another package
+func isQualifiedRef(ref string) bool {
+	_, _, ok := splitQualified(ref)
+	return ok
+}
+
+// unqualifiedName strips a package qualifier from a type or constant name
+func unqualifiedName(ref string) string {
+	if _, name, ok := splitQualified(ref); ok {
+		return name
+	}
+	return ref
+}
+
+// isBuiltinType reports whether a type name is a predeclared Go type
+func isBuiltinType(typeName string) bool {
+	return types.Universe.Lookup(typeName) != nil
+}
+
+// loadForeignPackage loads the declarations of an imported package
+// Packages in the current module are parsed from their directory; external packages use go/packages
+func loadForeignPackage(importPath, filename string) (*foreignPackage, error) {
+	if pkg, ok := foreignPackageCache[importPath]; ok {
+		return pkg, nil
+	}
+
+	moduleRoot, moduleName, err := findModuleInfo(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find module info: %w", err)
+	}
+
+	var pkg *foreignPackage
+	if importPath == moduleName || strings.HasPrefix(importPath, moduleName+"/") {
+		packageDir, err := findPackageDirectoryInModule(moduleRoot, importPath, moduleName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find internal package directory for %s: %w", importPath, err)
+		}
+		pkg, err = loadForeignPackageDir(importPath, packageDir)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pkg, err = loadExternalPackage(importPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	foreignPackageCache[importPath] = pkg
+	return pkg, nil
+}
+
+// loadForeignPackageDir parses the non-test Go files of a package directory
+func loadForeignPackageDir(importPath, packageDir string) (*foreignPackage, error) {
+	entries, err := os.ReadDir(packageDir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read package directory %s: %w", packageDir, err)
+	}
+
+	pkg := &foreignPackage{
+		ImportPath:  importPath,
+		TypeDefs:    make(map[string]ast.Node),
+		TypeAliases: make(map[string]string),
+		Constants:   make(map[string]ConstantInfo),
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(packageDir, entry.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", entry.Name(), err)
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+					pkg.TypeDefs[typeSpec.Name.Name] = genDecl
+					if ident, ok := typeSpec.Type.(*ast.Ident); ok {
+						pkg.TypeAliases[typeSpec.Name.Name] = ident.Name
+					}
+				}
+			}
+		}
+		for name, constantInfo := range collectConstants(file) {
+			pkg.Constants[name] = constantInfo
+		}
+	}
+	return pkg, nil
+}
+
+// loadExternalPackage loads an external package with go/types
+// Type declarations are synthesized from the underlying basic types since no syntax is loaded
+func loadExternalPackage(importPath string) (*foreignPackage, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes,
+	}
+	pkgs, err := packages.Load(cfg, importPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package %s: %w", importPath, err)
+	}
+	if len(pkgs) == 0 || pkgs[0].Types == nil {
+		return nil, fmt.Errorf("no packages found for %s", importPath)
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return nil, fmt.Errorf("package %s has errors: %v", importPath, pkgs[0].Errors)
+	}
+
+	pkg := &foreignPackage{
+		ImportPath:  importPath,
+		TypeDefs:    make(map[string]ast.Node),
+		TypeAliases: make(map[string]string),
+		Constants:   make(map[string]ConstantInfo),
+	}
+	scope := pkgs[0].Types.Scope()
+	for _, name := range scope.Names() {
+		switch obj := scope.Lookup(name).(type) {
+		case *types.TypeName:
+			if basic, ok := obj.Type().Underlying().(*types.Basic); ok {
+				pkg.TypeAliases[name] = basic.Name()
+				pkg.TypeDefs[name] = &ast.GenDecl{
+					Tok:   token.TYPE,
+					Specs: []ast.Spec{&ast.TypeSpec{Name: ast.NewIdent(name), Type: ast.NewIdent(basic.Name())}},
+				}
+			}
+		case *types.Const:
+			var typeName string
+			if named, ok := obj.Type().(*types.Named); ok && named.Obj().Pkg() == pkgs[0].Types {
+				typeName = named.Obj().Name()
+			}
+			value := obj.Val().ExactString()
+			if obj.Val().Kind() == constant.Bool {
+				value = obj.Val().String()
+			}
+			pkg.Constants[name] = ConstantInfo{Value: value, Type: typeName}
+		}
+	}
+	return pkg, nil
+}
+
+// qualifyLocalType qualifies a type name declared in a foreign package, leaving builtins unqualified
+func qualifyLocalType(qualifier, typeName string) string {
+	if typeName == "" || isBuiltinType(typeName) || strings.Contains(typeName, ".") {
+		return typeName
+	}
+	return qualifier + "." + typeName
+}
+
+// mergeForeignPackage adds a foreign package's declarations under its qualifier (e.g. proto.MsgText)
+func mergeForeignPackage(qualifier string, pkg *foreignPackage, typeDefs map[string]ast.Node, typeAliases map[string]string, constants map[string]ConstantInfo) {
+	for name, node := range pkg.TypeDefs {
+		typeDefs[qualifier+"."+name] = node
+	}
+	for name, underlying := range pkg.TypeAliases {
+		typeAliases[qualifier+"."+name] = qualifyLocalType(qualifier, underlying)
+	}
+	for name, constantInfo := range pkg.Constants {
+		constants[qualifier+"."+name] = ConstantInfo{
+			Value: constantInfo.Value,
+			Type:  qualifyLocalType(qualifier, constantInfo.Type),
+		}
+	}
+}
+
+// collectQualifiedUnionRefs returns the package qualifiers a file uses in union references:
+// payload union/discriminant values and union key types
+func collectQualifiedUnionRefs(parsedTypes []TypeInfo) []string {
+	var qualifiers []string
+	addRef := func(ref string) {
+		if qualifier, _, ok := splitQualified(ref); ok {
+			qualifiers = append(qualifiers, qualifier)
+		}
+	}
+	for _, typeInfo := range parsedTypes {
+		if typeInfo.PayloadConfig != nil {
+			addRef(typeInfo.PayloadConfig.UnionType)
+			addRef(typeInfo.PayloadConfig.Discriminant)
+		}
+		for _, field := range typeInfo.Fields {
+			if field.IsKey {
+				addRef(field.Type)
+			}
+			if field.UnionConfig != nil {
+				addRef(field.UnionConfig.DiscriminantType)
+			}
+		}
+	}
+	return qualifiers
+}
+
+// resolveQualifiedUnionRefs loads the packages behind a file's qualified union references and merges them
+func resolveQualifiedUnionRefs(qualifiers []string, file *ast.File, filename string, typeDefs map[string]ast.Node, typeAliases map[string]string, constants map[string]ConstantInfo) {
+	for _, qualifier := range qualifiers {
+		importPath := findImportPath(qualifier, file, filename)
+		if importPath == "" {
+			log.Fatalf("%s: package %s used in a union reference is not imported", filename, qualifier)
+		}
+		pkg, err := loadForeignPackage(importPath, filename)
+		if err != nil {
+			log.Fatalf("%s: failed to load package %s: %v", filename, importPath, err)
+		}
+		mergeForeignPackage(qualifier, pkg, typeDefs, typeAliases, constants)
+		debugf("Merged declarations from %s as %s", importPath, qualifier)
+	}
+}
+
+// foreignUnionKeyType returns the qualified key field type of a union declared in another package
+// unionField selects a union embedded in an ordinary struct; empty selects a union container
+func foreignUnionKeyType(unionType, unionField string, typeDefs map[string]ast.Node) string {
+	qualifier, name, ok := splitQualified(unionType)
+	if !ok {
+		return ""
+	}
+	genDecl, ok := typeDefs[unionType].(*ast.GenDecl)
+	if !ok {
+		return ""
+	}
+
+	var structType *ast.StructType
+	for _, spec := range genDecl.Specs {
+		if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == name {
+			structType, _ = typeSpec.Type.(*ast.StructType)
+		}
+	}
+	if structType == nil {
+		return ""
+	}
+
+	keyField := ""
+	if unionField == "" {
+		keyField, _ = getUnionFieldNames(unionType, typeDefs)
+	} else {
+		for _, field := range structType.Fields.List {
+			for _, fieldName := range field.Names {
+				if fieldName.Name == unionField && field.Tag != nil {
+					keyField = parseXDRTagOptions(parseXDRTag(strings.Trim(field.Tag.Value, "`")))["union"]
+				}
+			}
+		}
+	}
+	for _, field := range structType.Fields.List {
+		for _, fieldName := range field.Names {
+			if fieldName.Name == keyField {
+				return qualifyLocalType(qualifier, formatType(field.Type))
+			}
+		}
+	}
+	return ""
+}
+
+// packageImportPath returns the import path of a package directory within its module
+func packageImportPath(packageDir string) (importPath, moduleRoot string, err error) {
+	absDir, err := filepath.Abs(packageDir)
+	if err != nil {
+		return "", "", err
+	}
+	moduleRoot, moduleName, err := findModuleInfo(filepath.Join(absDir, "x.go"))
+	if err != nil {
+		return "", "", err
+	}
+	rel, err := filepath.Rel(moduleRoot, absDir)
+	if err != nil {
+		return "", "", err
+	}
+	if rel == "." {
+		return moduleName, moduleRoot, nil
+	}
+	return moduleName + "/" + filepath.ToSlash(rel), moduleRoot, nil
+}
+
+// collectForeignPayloadMappings scans the other packages of the module for payload directives
+// that target unions in packageDir (e.g. union=proto.Message,discriminant=proto.MsgText)
+// Discriminants are rewritten to how the union's package refers to them
+func collectForeignPayloadMappings(packageDir string, localFiles []*ast.File) map[string][]PayloadMapping {
+	mappings := make(map[string][]PayloadMapping)
+
+	importPath, moduleRoot, err := packageImportPath(packageDir)
+	if err != nil {
+		debugf("Skipping foreign payload scan: %v", err)
+		return mappings
+	}
+	absPackageDir, _ := filepath.Abs(packageDir)
+
+	err = filepath.WalkDir(moduleRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != moduleRoot && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			// Nested modules are separate import path spaces
+			if path != moduleRoot {
+				if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+					return filepath.SkipDir
+				}
+			}
+			if path == absPackageDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_xdr.go") || strings.HasSuffix(path, "_xdr_test.go") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path) // #nosec G304 -- Code generator needs to read Go source files
+		if err != nil || !strings.Contains(string(content), "+xdr:payload") {
+			return nil
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, content, parser.ParseComments)
+		if err != nil {
+			debugf("Skipping unparsable file %s: %v", path, err)
+			return nil
+		}
+
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				payload := collectXDRDirectives(file, typeSpec.Pos()).Payload
+				if payload == nil {
+					continue
+				}
+				qualifier, unionName, ok := splitQualified(payload.Union)
+				if !ok || findImportPath(qualifier, file, path) != importPath {
+					continue
+				}
+
+				discriminant := localizeDiscriminant(payload.Discriminant, file, path, importPath, localFiles, packageDir)
+				unionKey := unionName
+				if payload.Field != "" {
+					unionKey = fieldUnionName(unionName, payload.Field)
+				}
+				mappings[unionKey] = append(mappings[unionKey], PayloadMapping{
+					PayloadType:  file.Name.Name + "." + typeSpec.Name.Name,
+					Discriminant: discriminant,
+				})
+				debugf("Found foreign payload mapping: %s.%s -> %s (discriminant: %s)", file.Name.Name, typeSpec.Name.Name, unionKey, discriminant)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to scan module for payload directives: %v", err)
+	}
+	return mappings
+}
+
+// localizeDiscriminant rewrites a payload's qualified discriminant for use in the union's package
+func localizeDiscriminant(discriminant string, file *ast.File, filename, unionImportPath string, localFiles []*ast.File, packageDir string) string {
+	qualifier, name, ok := splitQualified(discriminant)
+	if !ok {
+		log.Fatalf("%s: discriminant %s of a payload for a union in another package must be package-qualified", filename, discriminant)
+	}
+	constantPath := findImportPath(qualifier, file, filename)
+	if constantPath == unionImportPath {
+		return name
+	}
+
+	// The constant lives in a third package: use the union package's own qualifier for it
+	for _, localFile := range localFiles {
+		for _, imp := range localFile.Imports {
+			if strings.Trim(imp.Path.Value, `"`) != constantPath {
+				continue
+			}
+			if imp.Name != nil {
+				return imp.Name.Name + "." + name
+			}
+			return getPackageNameFromImport(constantPath, filepath.Join(packageDir, "x.go")) + "." + name
+		}
+	}
+	log.Fatalf("%s: discriminant %s refers to package %s which the union's package does not import", filename, discriminant, constantPath)
+	return ""
+}
@@ -151,6 +151,9 @@ func AnalyzeTypeDependencies(types []TypeInfo) *StructDependencyGraph {
 	for _, typeInfo := range types {
 		debugf("Analyzing dependencies for type: %s", typeInfo.Name)
 		for _, field := range typeInfo.Fields {
+			if field.isListLink(typeInfo.Name) {
+				continue // list chains are walked iteratively, not recursively
+			}
 			referencedType := extractStructTypeFromField(field)
 			debugf("  Field %s (type: %s) -> referenced type: %s", field.Name, field.Type, referencedType)
 			if referencedType != "" {
@@ -184,6 +187,14 @@ func extractStructTypeFromField(field FieldInfo) string {
 		return "ANY_TYPE" // Special marker for implicitly cyclable types
 	}
 
+	// Handle map types (map[K]StructName -> StructName)
+	if strings.HasPrefix(fieldType, "map[") {
+		_, fieldType = splitMapType(fieldType)
+		if fieldType == "struct{}" {
+			return ""
+		}
+	}
+
 	// Handle pointer types (*StructName -> StructName)
 	fieldType = strings.TrimPrefix(fieldType, "*")
 
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"go/ast"
+	"log"
+	"strings"
+)
+
+// structField is a struct field after embedded struct expansion
+type structField struct {
+	*ast.Field
+	InlinePointer bool // marker for an inline *T: nil-checked on encode and allocated on decode
+}
+
+// embeddedFieldName returns the field name Go gives an embedded type (Header for *proto.Header)
+func embeddedFieldName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedFieldName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
+}
+
+// qualifyExpr qualifies the type names of a field declared in a foreign package, leaving builtins alone
+func qualifyExpr(expr ast.Expr, qualifier string) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if isBuiltinType(t.Name) {
+			return t
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent(qualifier), Sel: ast.NewIdent(t.Name)}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualifyExpr(t.X, qualifier)}
+	case *ast.ArrayType:
+		arrayType := &ast.ArrayType{Elt: qualifyExpr(t.Elt, qualifier)}
+		if t.Len != nil {
+			arrayType.Len = qualifyExpr(t.Len, qualifier)
+		}
+		return arrayType
+	case *ast.MapType:
+		return &ast.MapType{Key: qualifyExpr(t.Key, qualifier), Value: qualifyExpr(t.Value, qualifier)}
+	default:
+		return expr
+	}
+}
+
+// lookupEmbeddedStruct finds the struct declaration of an embedded type, loading another package if qualified
+// The returned qualifier is empty for types in the current package
+func lookupEmbeddedStruct(typeName string, typeDefs map[string]ast.Node, file *ast.File, filename string) (*ast.StructType, string) {
+	qualifier, name, qualified := splitQualified(typeName)
+	if qualified {
+		importPath := findImportPath(qualifier, file, filename)
+		if importPath == "" {
+			return nil, ""
+		}
+		pkg, err := loadForeignPackage(importPath, filename)
+		if err != nil {
+			debugf("Cannot load package %s for embedded type %s: %v", importPath, typeName, err)
+			return nil, ""
+		}
+		typeDefs = pkg.TypeDefs
+	} else {
+		qualifier = ""
+	}
+
+	genDecl, ok := typeDefs[name].(*ast.GenDecl)
+	if !ok {
+		return nil, ""
+	}
+	for _, spec := range genDecl.Specs {
+		if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == name {
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				return structType, qualifier
+			}
+		}
+	}
+	return nil, ""
+}
+
+// expandEmbeddedFields names embedded fields after their type so they delegate to the embedded
+// type's Codec, and replaces xdr:"inline" embedded structs with their fields in declaration order
+func expandEmbeddedFields(typeName string, fields []*ast.Field, typeDefs map[string]ast.Node, file *ast.File, filename string, seen map[string]bool) []structField {
+	var result []structField
+	for _, field := range fields {
+		if len(field.Names) > 0 {
+			result = append(result, structField{Field: field})
+			continue
+		}
+
+		name := embeddedFieldName(field.Type)
+		if name == "" {
+			log.Fatalf("Embedded field %s in %s is not a named type", formatType(field.Type), typeName)
+		}
+		xdrTag := ""
+		if field.Tag != nil {
+			xdrTag = parseXDRTag(strings.Trim(field.Tag.Value, "`"))
+		}
+		if _, inline := parseXDRTagOptions(xdrTag)["inline"]; !inline {
+			// Delegate to the embedded type's Encode/Decode like a field named after the type
+			result = append(result, structField{Field: &ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(name)},
+				Type:  field.Type,
+				Tag:   field.Tag,
+			}})
+			continue
+		}
+
+		embeddedType := formatType(field.Type)
+		structName := embeddedType
+		isPointer := false
+		if star, ok := field.Type.(*ast.StarExpr); ok {
+			structName = formatType(star.X)
+			isPointer = true
+		}
+		if seen[structName] {
+			log.Fatalf("Inline embedded struct %s in %s embeds itself", structName, typeName)
+		}
+		structType, qualifier := lookupEmbeddedStruct(structName, typeDefs, file, filename)
+		if structType == nil {
+			log.Fatalf("Inline embedded field %s in %s must be a struct declared in this module", embeddedType, typeName)
+		}
+		debugf("Inlining embedded struct %s into %s", embeddedType, typeName)
+
+		if isPointer {
+			result = append(result, structField{
+				Field:         &ast.Field{Names: []*ast.Ident{ast.NewIdent(name)}, Type: field.Type},
+				InlinePointer: true,
+			})
+		}
+		inner := structType.Fields.List
+		if qualifier != "" {
+			inner = make([]*ast.Field, len(structType.Fields.List))
+			for i, innerField := range structType.Fields.List {
+				for _, innerName := range innerField.Names {
+					if !innerName.IsExported() {
+						log.Fatalf("Inline embedded struct %s in %s has unexported field %s", structName, typeName, innerName.Name)
+					}
+				}
+				inner[i] = &ast.Field{Names: innerField.Names, Type: qualifyExpr(innerField.Type, qualifier), Tag: innerField.Tag}
+			}
+		}
+		seen[structName] = true
+		result = append(result, expandEmbeddedFields(typeName, inner, typeDefs, file, filename, seen)...)
+		delete(seen, structName)
+	}
+	return result
+}
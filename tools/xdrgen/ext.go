@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"log"
+	"strings"
+)
+
+// ExtConfig describes a version extension union declared with +xdr:ext
+// Version 0 carries no data and version N carries the Nth type of the versions list
+type ExtConfig struct {
+	TypeName string       // generated union type, e.g., "AccountExt"
+	Versions []ExtVersion // versions 1..N in declaration order
+}
+
+// ExtVersion is one non-void arm of an extension union
+type ExtVersion struct {
+	Number int    // version discriminant
+	Field  string // payload field, e.g., "V1"
+	Type   string // Go type of the payload, e.g., "AccountExtV1"
+}
+
+// MaxVersion returns the highest version the union understands
+func (c ExtConfig) MaxVersion() int {
+	return len(c.Versions)
+}
+
+// collectExtDirectives collects all // +xdr:ext directives in a file
+// Ext directives are free-standing and declare the union type rather than annotate one
+func collectExtDirectives(file *ast.File) []ExtConfig {
+	var exts []ExtConfig
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			directive, args, isXDR := parseXDRDirective(comment.Text)
+			if !isXDR || directive != "ext" {
+				continue
+			}
+			ext, err := parseExtDirective(args)
+			if err != nil {
+				log.Fatalf("Invalid +xdr:ext directive %q: %v", comment.Text, err)
+			}
+			exts = append(exts, ext)
+		}
+	}
+	return exts
+}
+
+// parseExtDirective builds an ExtConfig from the type= and versions=A|B arguments
+func parseExtDirective(args map[string]string) (ExtConfig, error) {
+	typeName, versions := args["type"], args["versions"]
+	if typeName == "" || versions == "" {
+		return ExtConfig{}, fmt.Errorf("type and versions are required")
+	}
+	if !ast.IsExported(typeName) || strings.Contains(typeName, ".") {
+		return ExtConfig{}, fmt.Errorf("type %s must be an exported name in this package", typeName)
+	}
+	ext := ExtConfig{TypeName: typeName}
+	for i, versionType := range strings.Split(versions, "|") {
+		versionType = strings.TrimSpace(versionType)
+		if versionType == "" {
+			return ExtConfig{}, fmt.Errorf("version %d of %s has no type", i+1, typeName)
+		}
+		if strings.Contains(versionType, ".") {
+			return ExtConfig{}, fmt.Errorf("version type %s must be declared in this package", versionType)
+		}
+		ext.Versions = append(ext.Versions, ExtVersion{
+			Number: i + 1,
+			Field:  fmt.Sprintf("V%d", i+1),
+			Type:   versionType,
+		})
+	}
+	return ext, nil
+}
+
+// Descending returns the non-void versions newest first
+func (c ExtConfig) Descending() []ExtVersion {
+	versions := make([]ExtVersion, len(c.Versions))
+	for i, version := range c.Versions {
+		versions[len(c.Versions)-1-i] = version
+	}
+	return versions
+}
+
+// declaresType reports whether a file declares a type with the given name
+func declaresType(file *ast.File, name string) bool {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -33,12 +33,15 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 )
 
 var silent bool
 var debug = false
 var disableLoopDetection = false
+var unknownDiscriminantPolicy = UnknownPolicyVoid
 
 // logf logs a message unless in silent mode
 func logf(msg string, args ...any) {
@@ -78,7 +81,8 @@ func main() {
 	flag.BoolVar(&silent, "silent", false, "suppress all output except errors")
 	flag.BoolVar(&silent, "s", false, "suppress all output except errors (shorthand)")
 	flag.BoolVar(&debug, "debug", false, "enable debug logging")
-	flag.BoolVar(&disableLoopDetection, "disable-loop-detection", false, "disable runtime loop detection even for types with potential cycles")
+	flag.BoolVar(&disableLoopDetection, "disable-loop-detection", false, "disable runtime depth tracking even for types with potential cycles")
+	flag.StringVar(&unknownDiscriminantPolicy, "unknown-discriminant", UnknownPolicyVoid, "policy for union discriminants without an arm or default: void, reject or capture")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "xdrgen - XDR Code Generator\n\n")
@@ -92,22 +96,62 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  //go:generate ../../bin/xdrgen types.go  (with relative path)\n\n")
 		fmt.Fprintf(os.Stderr, "XDR Generation Directives:\n")
 		fmt.Fprintf(os.Stderr, "  // +xdr:generate                              - Generate for standalone struct\n")
+		fmt.Fprintf(os.Stderr, "    (or a named slice, array, map or primitive type: type Entries []Entry)\n")
 		fmt.Fprintf(os.Stderr, "  // +xdr:union,key=FieldName,default=TypeName  - Union container\n")
-		fmt.Fprintf(os.Stderr, "  // +xdr:payload,union=UnionName,discriminant=Const - Union payload\n\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:union,key=FieldName,unknown=reject     - Union container with unknown discriminant policy\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:union,key=FieldName,void=A|B           - Declare void arms; other constants follow the unknown policy\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:union,key=FieldName,exhaustive         - Every key constant needs a payload, arm or void declaration\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:union,key=FieldName,mux                - Also generate UnionNameMux, an xdr.Mux with a Handle method per case\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:payload,union=UnionName,discriminant=Const - Union payload\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:arm,union=UnionName,discriminant=Const,type=T - Primitive/array union arm\n")
+		fmt.Fprintf(os.Stderr, "  Field []byte `xdr:\"union=KeyField\"`              - Union embedded in an ordinary struct\n")
+		fmt.Fprintf(os.Stderr, "    (payloads/arms target it with union=StructName,field=FieldName)\n")
+		fmt.Fprintf(os.Stderr, "    (arms=A|B lists its payload/arm discriminants for xdr.MarshalReflect and must match them)\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:converter,type=T,encode=fn,decode=fn   - Encode a third-party type with your own functions\n")
+		fmt.Fprintf(os.Stderr, "    (fn(enc *xdr.Encoder, v T) error and fn(dec *xdr.Decoder) (T, error), same file)\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:ext,type=T,versions=A|B                 - Generate version extension union T\n")
+		fmt.Fprintf(os.Stderr, "    (version 0 is void and version N carries the Nth type; see Latest and EncodeVersion)\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:register,interface=I,type=T,id=N       - Register T in IRegistry for fields of interface type I\n")
+		fmt.Fprintf(os.Stderr, "    (encoded as {type id, opaque body}; all registrations of I in one file)\n")
+		fmt.Fprintf(os.Stderr, "  // +xdr:envelope,type=T,id=N,version=V          - Register T for xdr.MarshalAny/UnmarshalAny (version defaults to 1)\n\n")
 		fmt.Fprintf(os.Stderr, "Supported Go Types (auto-detected):\n")
 		fmt.Fprintf(os.Stderr, "  uint32, uint64, int32, int64  - Integer types\n")
+		fmt.Fprintf(os.Stderr, "  uint8/16, int8/16, int, uint  - Widened to 32 bits (int/uint: 64), range-checked on decode\n")
 		fmt.Fprintf(os.Stderr, "  string                        - Variable-length string\n")
 		fmt.Fprintf(os.Stderr, "  []byte, [N]byte               - Byte arrays (variable/fixed)\n")
 		fmt.Fprintf(os.Stderr, "  bool                          - Boolean (encoded as uint32)\n")
 		fmt.Fprintf(os.Stderr, "  struct types                  - Nested structs (auto-detected)\n")
-		fmt.Fprintf(os.Stderr, "  []Type                        - Variable-length arrays\n")
+		fmt.Fprintf(os.Stderr, "  []Type, [N]Type               - Variable/fixed arrays, nested to any depth ([][4]T)\n")
+		fmt.Fprintf(os.Stderr, "  map[K]V, map[K]struct{}       - Key/value pairs or a set, sorted by string/integer key\n")
+		fmt.Fprintf(os.Stderr, "  time.Time, time.Duration      - Well-known layout (time: timespec, duration: unixnano)\n")
+		fmt.Fprintf(os.Stderr, "  generic T, []T, [N]T          - T xdr.Codec instantiated with a pointer (Page[*Entry])\n")
+		fmt.Fprintf(os.Stderr, "  generic PT, []PT, [N]PT       - PT interface{ *T; xdr.Codec } over T any (Page[Entry, *Entry])\n")
 		fmt.Fprintf(os.Stderr, "  type aliases                  - Automatically resolved\n\n")
 		fmt.Fprintf(os.Stderr, "Optional struct tags:\n")
-		fmt.Fprintf(os.Stderr, "  `xdr:\"-\"`          - exclude field from encoding/decoding\n\n")
+		fmt.Fprintf(os.Stderr, "  `xdr:\"-\"`          - exclude field from encoding/decoding\n")
+		fmt.Fprintf(os.Stderr, "  `xdr:\"list\"`       - optional-data linked list, encoded iteratively; on the\n")
+		fmt.Fprintf(os.Stderr, "                     last field Next *T of T, a head *T or a []T of nodes\n")
+		fmt.Fprintf(os.Stderr, "  `xdr:\"time=nfs\"`   - time/duration layout: nfs (uint32 sec+nsec), timespec\n")
+		fmt.Fprintf(os.Stderr, "                     (int64 sec, uint32 nsec) or unixnano (int64 nanoseconds)\n")
+		fmt.Fprintf(os.Stderr, "  `xdr:\"max=A|B\"`    - length bounds per array dimension, outermost first (empty: unbounded)\n")
+		fmt.Fprintf(os.Stderr, "  `xdr:\"const=V\"`    - integer constant written on encode and verified on decode (magic numbers)\n")
+		fmt.Fprintf(os.Stderr, "  `xdr:\"reserved=N\"` - N zero bytes, skipped on decode (add ,strict to require zeros)\n")
+		fmt.Fprintf(os.Stderr, "  `xdr:\"opaque\"`     - struct or *struct encoded as length-prefixed opaque data, decoded within its length\n")
+		fmt.Fprintf(os.Stderr, "  `xdr:\"crc32=A|B\"`  - uint32 checksum of the encoded fields A and B, verified on decode\n")
+		fmt.Fprintf(os.Stderr, "                     (also crc32c, adler32, or len for their encoded byte length)\n")
+		fmt.Fprintf(os.Stderr, "  `xdr:\"inline\"`     - on an embedded struct: encode its fields in place instead of\n")
+		fmt.Fprintf(os.Stderr, "                     calling its Encode/Decode (the default for embedded fields)\n\n")
 		fmt.Fprintf(os.Stderr, "Discriminated Unions:\n")
 		fmt.Fprintf(os.Stderr, "  Union containers use: // +xdr:union,key=FieldName,default=DefaultType\n")
 		fmt.Fprintf(os.Stderr, "  Payload types use: // +xdr:payload,union=ContainerName,discriminant=ConstName\n")
-		fmt.Fprintf(os.Stderr, "  Void cases are auto-detected when no payload exists for a discriminant\n")
+		fmt.Fprintf(os.Stderr, "  Discriminants may be uint32, int32 or bool (or an alias); bool payloads use discriminant=true|false\n")
+		fmt.Fprintf(os.Stderr, "  Payloads in another package qualify both: union=proto.Message,discriminant=proto.MsgText\n")
+		fmt.Fprintf(os.Stderr, "  Void cases are auto-detected when no payload exists for a discriminant, unless listed with void=A|B\n")
+		fmt.Fprintf(os.Stderr, "  Discriminants without an arm or default follow the unknown policy:\n")
+		fmt.Fprintf(os.Stderr, "    void    - encode/decode nothing (default)\n")
+		fmt.Fprintf(os.Stderr, "    reject  - return xdr.ErrUnknownDiscriminant with the value\n")
+		fmt.Fprintf(os.Stderr, "    capture - capture the opaque arm in the payload field\n")
+		fmt.Fprintf(os.Stderr, "  Set per union with unknown=Policy or globally with -unknown-discriminant\n")
 		fmt.Fprintf(os.Stderr, "  Example:\n")
 		fmt.Fprintf(os.Stderr, "    // +xdr:union,key=Type,default=MessageTypeVoid\n")
 		fmt.Fprintf(os.Stderr, "    type NetworkMessage struct { Type MessageType; Payload []byte }\n")
@@ -141,6 +185,10 @@ func main() {
 		os.Exit(1)
 	}
 
+	if !isValidUnknownPolicy(unknownDiscriminantPolicy) {
+		log.Fatalf("Invalid -unknown-discriminant value %q (must be %s, %s or %s)", unknownDiscriminantPolicy, UnknownPolicyVoid, UnknownPolicyReject, UnknownPolicyCapture)
+	}
+
 	inputPath := flag.Arg(0)
 
 	// Set debug from environment variable if present
@@ -214,6 +262,10 @@ func processFileWithPackageContext(inputFile, packageDir string) {
 	allStructTypes := make(map[string]bool)
 	allTypeAliases := make(map[string]string)            // Collect type aliases from all files
 	payloadMappings := make(map[string][]PayloadMapping) // unionType -> []PayloadMapping
+	unionContainers := make(map[string]*UnionConfig)     // container type -> directive-level config
+	armMappings := make(map[string][]*ArmDirective)      // unionType -> +xdr:arm directives
+	registryFiles := make(map[string]string)             // interface -> file with its +xdr:register directives
+	var astFiles []*ast.File
 
 	// Parse ALL files in the package to gather complete type information
 	debugf("Package-level collection: allFiles contains %d files", len(allFiles))
@@ -226,6 +278,7 @@ func processFileWithPackageContext(inputFile, packageDir string) {
 			log.Fatalf("Package-level collection: failed to parse file %s: %v", file, err)
 		}
 		debugf("Package-level collection: successfully parsed file %s", file)
+		astFiles = append(astFiles, astFile)
 
 		// Collect type definitions, constants, and type aliases
 		ast.Inspect(astFile, func(n ast.Node) bool {
@@ -240,8 +293,12 @@ func processFileWithPackageContext(inputFile, packageDir string) {
 
 						// Collect type aliases
 						if typeSpec.Assign != token.NoPos || typeSpec.Assign == 0 {
-							if ident, ok := typeSpec.Type.(*ast.Ident); ok {
-								allTypeAliases[typeSpec.Name.Name] = ident.Name
+							switch t := typeSpec.Type.(type) {
+							case *ast.Ident:
+								allTypeAliases[typeSpec.Name.Name] = t.Name
+							case *ast.ArrayType:
+								// Array definitions let nested arrays resolve each dimension
+								allTypeAliases[typeSpec.Name.Name] = formatType(t)
 							}
 						}
 					}
@@ -256,6 +313,23 @@ func processFileWithPackageContext(inputFile, packageDir string) {
 			allConstants[name] = constantInfo
 		}
 
+		// Collect free-standing union arm directives
+		for _, arm := range collectArmDirectives(astFile) {
+			if isQualifiedRef(arm.Union) {
+				log.Fatalf("%s: +xdr:arm for union %s must be declared in the union's package", file, arm.Union)
+			}
+			armMappings[arm.unionName()] = append(armMappings[arm.unionName()], arm)
+			debugf("Found arm mapping: %s -> %s (discriminant: %s)", arm.Type, arm.unionName(), arm.Discriminant)
+		}
+
+		// Collect free-standing interface registrations; each interface's registry is generated in one file
+		for _, registration := range collectRegisterDirectives(astFile) {
+			if other, ok := registryFiles[registration.Interface]; ok && other != file {
+				log.Fatalf("%s: +xdr:register directives for %s must all be in one file (also found in %s)", file, registration.Interface, other)
+			}
+			registryFiles[registration.Interface] = file
+		}
+
 		// Parse file to collect payload directives (using collection mode to skip strict validation)
 		types, _, _, err := parseFileWithPackageTypeDefsForCollection(file, allTypeDefs, allConstants, allTypeAliases)
 		if err != nil {
@@ -263,73 +337,114 @@ func processFileWithPackageContext(inputFile, packageDir string) {
 			continue // Skip files that can't be parsed for payload directives
 		}
 
-		// Collect payload mappings from parsed types
+		// Load other packages named by qualified union, discriminant and key type references
+		resolveQualifiedUnionRefs(collectQualifiedUnionRefs(types), astFile, file, allTypeDefs, allTypeAliases, allConstants)
+
+		// Collect payload mappings and union containers from parsed types
 		for _, typeInfo := range types {
-			if typeInfo.IsPayload && typeInfo.PayloadConfig != nil {
+			if typeInfo.IsPayload && typeInfo.PayloadConfig != nil && isQualifiedRef(typeInfo.PayloadConfig.UnionType) {
+				// The union's package picks this payload up when it is generated
+				debugf("Found payload %s for union %s in another package", typeInfo.Name, typeInfo.PayloadConfig.UnionType)
+			} else if typeInfo.IsPayload && typeInfo.PayloadConfig != nil {
 				mapping := PayloadMapping{
 					PayloadType:  typeInfo.Name,
 					Discriminant: typeInfo.PayloadConfig.Discriminant,
 				}
-				unionType := typeInfo.PayloadConfig.UnionType
+				unionType := typeInfo.PayloadConfig.unionName()
 				payloadMappings[unionType] = append(payloadMappings[unionType], mapping)
 				debugf("Found payload mapping: %s -> %s (discriminant: %s)",
 					typeInfo.Name, unionType, typeInfo.PayloadConfig.Discriminant)
 			}
+			for _, field := range typeInfo.Fields {
+				if field.UnionConfig != nil {
+					unionContainers[field.UnionConfig.ContainerType] = field.UnionConfig
+					debugf("Found embedded union field: %s (discriminant type: %s)", field.UnionConfig.ContainerType, field.UnionConfig.DiscriminantType)
+				}
+			}
+			if typeInfo.IsDiscriminatedUnion && typeInfo.UnionConfig != nil {
+				unionContainers[typeInfo.Name] = typeInfo.UnionConfig
+				debugf("Found union container: %s (discriminant type: %s)", typeInfo.Name, typeInfo.UnionConfig.DiscriminantType)
+			}
+		}
+	}
+
+	// Add payloads declared in other packages of the module for unions in this package
+	if len(unionContainers) > 0 {
+		for unionType, mappings := range collectForeignPayloadMappings(packageDir, astFiles) {
+			payloadMappings[unionType] = append(payloadMappings[unionType], mappings...)
 		}
 	}
 
-	// Build union configurations from payload mappings
+	// Build union configurations for every container, then add cases from payload mappings
+	for containerType, containerConfig := range unionContainers {
+		allUnionConfigs[containerType] = &UnionConfig{
+			ContainerType:    containerType,
+			DiscriminantType: containerConfig.DiscriminantType,
+			DiscriminantXDR:  resolveDiscriminantXDRType(containerConfig.DiscriminantType, allTypeAliases),
+			DefaultCase:      containerConfig.DefaultCase,
+			UnknownPolicy:    containerConfig.UnknownPolicy,
+			Exhaustive:       containerConfig.Exhaustive,
+			Mux:              containerConfig.Mux,
+			DeclaredVoid:     containerConfig.DeclaredVoid,
+			Cases:            make(map[string]string),
+			VoidCases:        []string{},
+		}
+	}
 	for unionType, mappings := range payloadMappings {
-		unionConfig := &UnionConfig{
-			ContainerType: unionType,
-			Cases:         make(map[string]string),
-			VoidCases:     []string{},
+		unionConfig, exists := allUnionConfigs[unionType]
+		if !exists {
+			unionConfig = &UnionConfig{
+				ContainerType: unionType,
+				Cases:         make(map[string]string),
+				VoidCases:     []string{},
+			}
+			allUnionConfigs[unionType] = unionConfig
 		}
 
 		// Map discriminants to payload types
 		for _, mapping := range mappings {
 			unionConfig.Cases[mapping.Discriminant] = mapping.PayloadType
 		}
-
-		allUnionConfigs[unionType] = unionConfig
 		debugf("Created union config for %s: %+v", unionType, unionConfig)
 	}
 
-	// Compute void cases for each union by finding constants without payload mappings
-	// We need to find the discriminant type for each union by looking at the union container struct
-	for unionType, unionConfig := range allUnionConfigs {
-		// Find the discriminant type by looking at the union container struct
-		if unionTypeDef, exists := allTypeDefs[unionType]; exists {
-			if structType, ok := unionTypeDef.(*ast.StructType); ok {
-				// Find the key field to determine discriminant type
-				for _, field := range structType.Fields.List {
-					if field.Tag != nil {
-						tag := strings.Trim(field.Tag.Value, "`")
-						if strings.Contains(tag, `xdr:"key"`) {
-							// Get the field type
-							if ident, ok := field.Type.(*ast.Ident); ok {
-								discriminantType := ident.Name
-								unionConfig.DiscriminantType = discriminantType
-								debugf("Found discriminant type %s for union %s", discriminantType, unionType)
-
-								// Now find constants of this discriminant type
-								for constantName, constantInfo := range allConstants {
-									if constantInfo.Type == discriminantType {
-										if _, hasPayload := unionConfig.Cases[constantName]; !hasPayload {
-											unionConfig.VoidCases = append(unionConfig.VoidCases, constantName)
-											debugf("Added void case %s for union %s", constantName, unionType)
-										}
-									}
-								}
-								break
-							}
-						}
-					}
-				}
+	// Map discriminants to primitive/array arm types
+	for unionType, arms := range armMappings {
+		unionConfig, exists := allUnionConfigs[unionType]
+		if !exists {
+			log.Fatalf("+xdr:arm references union %s which has no +xdr:union container or xdr:\"union\" field", unionType)
+		}
+		if unionConfig.Arms == nil {
+			unionConfig.Arms = make(map[string]string)
+		}
+		for _, arm := range arms {
+			if payloadType, conflict := unionConfig.Cases[arm.Discriminant]; conflict {
+				log.Fatalf("Union %s discriminant %s has both payload %s and arm type %s", unionType, arm.Discriminant, payloadType, arm.Type)
+			}
+			if armType, duplicate := unionConfig.Arms[arm.Discriminant]; duplicate {
+				log.Fatalf("Union %s discriminant %s has multiple arm types (%s and %s)", unionType, arm.Discriminant, armType, arm.Type)
 			}
+			unionConfig.Arms[arm.Discriminant] = arm.Type
 		}
 	}
 
+	// Registered interfaces must be Codec interfaces declared in this package
+	for iface, file := range registryFiles {
+		declaration := lookupInterface(iface, allTypeDefs)
+		if declaration == nil {
+			log.Fatalf("%s: +xdr:register interface %s is not an interface declared in this package", file, iface)
+		}
+		if !embedsCodec(declaration) {
+			log.Fatalf("%s: +xdr:register interface %s must embed xdr.Codec", file, iface)
+		}
+	}
+
+	// Compute void cases for each union: constants of the discriminant type without payload mappings
+	for unionType, unionConfig := range allUnionConfigs {
+		unionConfig.VoidCases = computeVoidCases(unionConfig, allConstants)
+		debugf("Void cases for union %s: %v", unionType, unionConfig.VoidCases)
+	}
+
 	if debug {
 		var keys []string
 		for k := range allTypeDefs {
@@ -342,12 +457,12 @@ func processFileWithPackageContext(inputFile, packageDir string) {
 	debugf("Processing %d generatable files for code generation", len(generatableFiles))
 	for _, file := range generatableFiles {
 		debugf("Processing generatable file: %s", file)
-		processFileWithPackageUnionContext(file, allUnionConfigs, allTypeDefs, allConstants, allStructTypes, allTypeAliases)
+		processFileWithPackageUnionContext(file, allUnionConfigs, allTypeDefs, allConstants, allStructTypes, allTypeAliases, registryFiles)
 	}
 }
 
 // processFileWithPackageUnionContext processes a file with complete package-level union configuration context
-func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[string]*UnionConfig, allTypeDefs map[string]ast.Node, allConstants map[string]ConstantInfo, allStructTypes map[string]bool, allTypeAliases map[string]string) {
+func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[string]*UnionConfig, allTypeDefs map[string]ast.Node, allConstants map[string]ConstantInfo, allStructTypes map[string]bool, allTypeAliases map[string]string, registryFiles map[string]string) {
 	// Generate output file name, handling test files specially
 	var outputFile string
 	if strings.HasSuffix(inputFile, "_test.go") {
@@ -369,6 +484,21 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 
 	// Update container structs with package-level union configurations
 	for i := range types {
+		if types[i].IsPayload && types[i].PayloadConfig != nil {
+			if unionConfig, exists := allUnionConfigs[types[i].PayloadConfig.unionName()]; exists {
+				types[i].PayloadConfig.DiscriminantXDR = unionConfig.DiscriminantXDR
+			} else if keyType := foreignUnionKeyType(types[i].PayloadConfig.UnionType, types[i].PayloadConfig.UnionField, allTypeDefs); keyType != "" {
+				types[i].PayloadConfig.DiscriminantXDR = resolveDiscriminantXDRType(keyType, allTypeAliases)
+			}
+		}
+		for j := range types[i].Fields {
+			if types[i].Fields[j].UnionConfig != nil {
+				if unionConfig, exists := allUnionConfigs[types[i].Fields[j].UnionConfig.ContainerType]; exists {
+					types[i].Fields[j].UnionConfig = unionConfig
+					debugf("Updated embedded union field %s with package-level union config", unionConfig.ContainerType)
+				}
+			}
+		}
 		if types[i].IsDiscriminatedUnion {
 			if unionConfig, exists := allUnionConfigs[types[i].Name]; exists {
 				types[i].UnionConfig = unionConfig
@@ -381,13 +511,6 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 
 	// Package-level validation is done before individual file processing
 
-	if len(types) == 0 {
-		logf("No types requiring XDR generation found in %s", inputFile)
-		return
-	}
-
-	// Type counts are shown in the generation message, no need for duplicate output
-
 	// Extract package name from input file
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, inputFile, nil, parser.ParseComments)
@@ -395,6 +518,25 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 		log.Fatal("Error parsing package:", err)
 	}
 
+	// Extension unions are declared by directive alone, so a file may have nothing else to generate
+	exts := collectExtDirectives(file)
+	for _, ext := range exts {
+		if declaresType(file, ext.TypeName) {
+			log.Fatalf("+xdr:ext type %s is already declared; the directive generates it", ext.TypeName)
+		}
+	}
+
+	// Interface registries are generated in the file holding their +xdr:register directives
+	registries := groupRegistrations(collectRegisterDirectives(file))
+	envelopes := collectEnvelopeDirectives(file)
+
+	if len(types) == 0 && len(exts) == 0 && len(registries) == 0 && len(envelopes) == 0 {
+		logf("No types requiring XDR generation found in %s", inputFile)
+		return
+	}
+
+	// Type counts are shown in the generation message, no need for duplicate output
+
 	packageName := file.Name.Name // Use the package name from the source file exactly, do not modify
 
 	// Use package-level constants for union validation
@@ -405,18 +547,28 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 
 	// Check if this file contains any structs that need code generation
 	// Only generate code for files that actually contain XDR-tagged structs
-	if len(types) == 0 {
+	if len(types) == 0 && len(exts) == 0 && len(registries) == 0 && len(envelopes) == 0 {
 		debugf("No XDR-tagged structs found in %s, skipping code generation", inputFile)
 		return
 	}
 
+	// Interface fields use the registry generated from their type's +xdr:register directives
+	for _, typeInfo := range types {
+		for _, field := range typeInfo.Fields {
+			if _, registered := registryFiles[field.Type]; field.XDRType == "interface" && !registered {
+				log.Fatalf("Field %s.%s has interface type %s with no +xdr:register directives", typeInfo.Name, field.Name, field.Type)
+			}
+		}
+	}
+
 	// Validate discriminated unions with package-level constants
 	if err := validateDiscriminatedUnions(types, constants); err != nil {
 		log.Fatal("Validation error:", err)
 	}
 
 	// Validate union configurations with package-level context
-	validationErrors := validateUnionConfiguration(types, constants, allTypeDefs, file)
+	validationErrors := validateUnionConfiguration(types, constants, allTypeDefs, allTypeAliases, file)
+	validationErrors = append(validationErrors, validateUnionExhaustiveness(types, constants)...)
 	if len(validationErrors) > 0 {
 		for _, err := range validationErrors {
 			logf("Validation error: %s: %s", err.Location, err.Message)
@@ -424,6 +576,9 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 		log.Fatal("Union configuration validation failed")
 	}
 
+	// Add generated wrapper types for primitive/array union arms after their containers
+	types = addArmWrapperTypes(types, allTypeAliases, file, inputFile)
+
 	// Extract build tags from input file
 	buildTags := extractBuildTags(inputFile)
 
@@ -433,6 +588,9 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 	for _, typeInfo := range types {
 		structTypeNames = append(structTypeNames, typeInfo.Name)
 	}
+	for _, ext := range exts {
+		structTypeNames = append(structTypeNames, ext.TypeName)
+	}
 
 	// Also collect all struct types from allTypeDefs (including those not being processed)
 	debugf("TypeDefs map contains %d types", len(allTypeDefs))
@@ -566,7 +724,7 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 	// Generate file header with consistent path for determinism
 	// Use just the filename to avoid path variations based on execution directory
 	relativeInputFile := filepath.Base(inputFile)
-	header, err := codeGen.GenerateFileHeader(relativeInputFile, packageName, externalImports, buildTags, len(types))
+	header, err := codeGen.GenerateFileHeader(relativeInputFile, packageName, externalImports, buildTags, len(types)+len(exts))
 	if err != nil {
 		log.Fatal("Error generating file header:", err)
 	}
@@ -575,31 +733,63 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 
 	// Generate code for each type
 	for _, typeInfo := range types {
-		// Generate encode method
-		encodeMethod, err := codeGen.GenerateEncodeMethod(typeInfo)
-		if err != nil {
-			log.Fatal("Error generating encode method:", err)
+		// Generate wrapper type and union accessors for primitive/array arms
+		if typeInfo.ArmConfig != nil {
+			armCode, err := codeGen.GenerateUnionArm(*typeInfo.ArmConfig)
+			if err != nil {
+				log.Fatal("Error generating union arm:", err)
+			}
+			output.WriteString(armCode)
+			output.WriteString("\n")
 		}
-		output.WriteString(encodeMethod)
-		output.WriteString("\n")
 
-		// Generate decode method
-		decodeMethod, err := codeGen.GenerateDecodeMethod(typeInfo)
-		if err != nil {
-			log.Fatal("Error generating decode method:", err)
+		if typeInfo.Underlying != "" {
+			// Named non-struct types convert to a wrapper struct with the generated methods
+			namedCode, err := codeGen.GenerateNamedType(typeInfo)
+			if err != nil {
+				log.Fatal("Error generating named type:", err)
+			}
+			output.WriteString(namedCode)
+			output.WriteString("\n")
+		} else {
+			// Generate encode method
+			encodeMethod, err := codeGen.GenerateEncodeMethod(typeInfo)
+			if err != nil {
+				log.Fatal("Error generating encode method:", err)
+			}
+			output.WriteString(encodeMethod)
+			output.WriteString("\n")
+
+			// Generate decode method
+			decodeMethod, err := codeGen.GenerateDecodeMethod(typeInfo)
+			if err != nil {
+				log.Fatal("Error generating decode method:", err)
+			}
+			output.WriteString(decodeMethod)
+			output.WriteString("\n")
 		}
-		output.WriteString(decodeMethod)
-		output.WriteString("\n")
 
-		// Generate payload-specific methods if this is a payload type
-		if typeInfo.IsPayload {
-			// Generate ToUnion method
-			toUnionMethod, err := codeGen.GeneratePayloadToUnion(typeInfo, allTypeDefs)
+		// Generate the typed dispatcher of a union container declared with +xdr:union,mux
+		if typeInfo.IsDiscriminatedUnion && typeInfo.UnionConfig != nil && typeInfo.UnionConfig.Mux {
+			muxCode, err := codeGen.GenerateUnionMux(typeInfo)
 			if err != nil {
-				log.Fatal("Error generating ToUnion method:", err)
+				log.Fatal("Error generating union mux:", err)
 			}
-			output.WriteString(toUnionMethod)
+			output.WriteString(muxCode)
 			output.WriteString("\n")
+		}
+
+		// Generate payload-specific methods if this is a payload type
+		if typeInfo.IsPayload {
+			// Generate ToUnion method (only container unions have a type to convert to)
+			if typeInfo.PayloadConfig.UnionField == "" {
+				toUnionMethod, err := codeGen.GeneratePayloadToUnion(typeInfo, allTypeDefs)
+				if err != nil {
+					log.Fatal("Error generating ToUnion method:", err)
+				}
+				output.WriteString(toUnionMethod)
+				output.WriteString("\n")
+			}
 
 			// Generate EncodeToUnion method
 			encodeToUnionMethod, err := codeGen.GeneratePayloadEncodeToUnion(typeInfo)
@@ -611,7 +801,7 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 		}
 
 		// Generate compile-time assertion
-		assertion, err := codeGen.GenerateAssertion(typeInfo.Name)
+		assertion, err := codeGen.GenerateTypeAssertion(typeInfo)
 		if err != nil {
 			log.Fatal("Error generating assertion:", err)
 		}
@@ -619,6 +809,43 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 		output.WriteString("\n")
 	}
 
+	// Generate version extension unions
+	for _, ext := range exts {
+		extCode, err := codeGen.GenerateExtUnion(ext)
+		if err != nil {
+			log.Fatal("Error generating extension union:", err)
+		}
+		output.WriteString(extCode)
+		output.WriteString("\n")
+
+		assertion, err := codeGen.GenerateAssertion(ext.TypeName)
+		if err != nil {
+			log.Fatal("Error generating assertion:", err)
+		}
+		output.WriteString(assertion)
+		output.WriteString("\n")
+	}
+
+	// Generate interface registries
+	for _, registry := range registries {
+		registryCode, err := codeGen.GenerateRegistry(registry)
+		if err != nil {
+			log.Fatal("Error generating registry:", err)
+		}
+		output.WriteString(registryCode)
+		output.WriteString("\n")
+	}
+
+	// Register envelope message types
+	if len(envelopes) > 0 {
+		envelopeCode, err := codeGen.GenerateEnvelopeRegistrations(envelopes)
+		if err != nil {
+			log.Fatal("Error generating envelope registrations:", err)
+		}
+		output.WriteString(envelopeCode)
+		output.WriteString("\n")
+	}
+
 	// Write to output file
 	if err := os.WriteFile(outputFile, []byte(output.String()), 0600); err != nil {
 		log.Fatal("Error writing output file:", err)
@@ -630,8 +857,143 @@ func processFileWithPackageUnionContext(inputFile string, allUnionConfigs map[st
 	}
 
 	if !silent {
-		logf("Generated XDR methods for %d types in %s", len(types), outputFile)
+		logf("Generated XDR methods for %d types in %s", len(types)+len(exts), outputFile)
+	}
+}
+
+// computeVoidCases returns the sorted void cases of a union: the void=A|B list when declared,
+// otherwise every constant of the discriminant type without a payload or arm
+func computeVoidCases(unionConfig *UnionConfig, constants map[string]ConstantInfo) []string {
+	if unionConfig.DeclaredVoid != nil {
+		voidCases := append([]string{}, unionConfig.DeclaredVoid...)
+		sort.Strings(voidCases)
+		return voidCases
+	}
+	return unselectedConstants(unionConfig, constants)
+}
+
+// unselectedConstants returns the sorted constants of the union's discriminant type that no payload,
+// arm or declared void case selects
+// Bool discriminants without typed constants use the true/false literals as their cases
+func unselectedConstants(unionConfig *UnionConfig, constants map[string]ConstantInfo) []string {
+	voidCases := []string{}
+	if unionConfig.DiscriminantType == "" {
+		return voidCases
+	}
+	// Constants sharing a value with an earlier case would produce duplicate switch cases
+	takenValues := make(map[string]bool)
+	for _, constantName := range append(unionCaseConstants(unionConfig), unionConfig.DeclaredVoid...) {
+		if constantInfo, exists := constants[constantName]; exists {
+			takenValues[constantValueKey(constantInfo.Value)] = true
+		} else if isVoidLiteral(constantName, unionConfig.DiscriminantXDR) {
+			takenValues[constantValueKey(constantName)] = true
+		}
+	}
+	var constantNames []string
+	for constantName, constantInfo := range constants {
+		if constantInfo.Type == unionConfig.DiscriminantType {
+			constantNames = append(constantNames, constantName)
+		}
+	}
+	sort.Strings(constantNames)
+	for _, constantName := range constantNames {
+		_, hasPayload := unionConfig.Cases[constantName]
+		_, hasArm := unionConfig.Arms[constantName]
+		valueKey := constantValueKey(constants[constantName].Value)
+		if !hasPayload && !hasArm && !takenValues[valueKey] && !slices.Contains(unionConfig.DeclaredVoid, constantName) {
+			voidCases = append(voidCases, constantName)
+			takenValues[valueKey] = true
+		}
+	}
+	if unionConfig.DiscriminantXDR == "bool" && !hasTypedConstants(unionConfig, constants) {
+		for _, literal := range []string{"false", "true"} {
+			_, hasPayload := unionConfig.Cases[literal]
+			_, hasArm := unionConfig.Arms[literal]
+			if !hasPayload && !hasArm && !slices.Contains(unionConfig.DeclaredVoid, literal) {
+				voidCases = append(voidCases, literal)
+			}
+		}
+	}
+	sort.Strings(voidCases)
+	return voidCases
+}
+
+// addArmWrapperTypes inserts a wrapper type after each union container for every +xdr:arm arm
+// The wrapper has a single Value field so arms reuse the regular field encode/decode generation
+func addArmWrapperTypes(types []TypeInfo, typeAliases map[string]string, file *ast.File, filename string) []TypeInfo {
+	var result []TypeInfo
+	for _, typeInfo := range types {
+		result = append(result, typeInfo)
+		for _, field := range typeInfo.Fields {
+			if !field.IsUnion {
+				continue
+			}
+			unionConfig := unionConfigForField(field, typeInfo)
+			if unionConfig == nil || len(unionConfig.Arms) == 0 {
+				continue
+			}
+
+			// Accessors for embedded unions are prefixed with the field name
+			keyField := unionKeyForField(field, typeInfo)
+			prefix := ""
+			if field.UnionConfig != nil {
+				prefix = field.Name
+			}
+
+			var discriminants []string
+			for discriminant := range unionConfig.Arms {
+				discriminants = append(discriminants, discriminant)
+			}
+			sort.Strings(discriminants)
+
+			for _, discriminant := range discriminants {
+				armType := unionConfig.Arms[discriminant]
+				accessorName := prefix + upperFirst(discriminant)
+				wrapperType := lowerFirst(typeInfo.Name) + accessorName + "Arm"
+				result = append(result, TypeInfo{
+					Name:   wrapperType,
+					Fields: []FieldInfo{newArmFieldInfo(armType, typeAliases, file, filename)},
+					ArmConfig: &ArmConfig{
+						UnionType:    typeInfo.Name,
+						Discriminant: discriminant,
+						ArmType:      armType,
+						AccessorName: accessorName,
+						WrapperType:  wrapperType,
+						KeyField:     keyField,
+						PayloadField: field.Name,
+					},
+				})
+				debugf("Added arm wrapper %s for %s.%s (%s)", wrapperType, unionConfig.ContainerType, discriminant, armType)
+			}
+		}
+	}
+	return result
+}
+
+// upperFirst returns s with its first letter upper-cased
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// lowerFirst returns s with its first letter lower-cased
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// hasTypedConstants reports whether any constant is declared with the union's discriminant type
+func hasTypedConstants(unionConfig *UnionConfig, constants map[string]ConstantInfo) bool {
+	for _, constantInfo := range constants {
+		if constantInfo.Type == unionConfig.DiscriminantType {
+			return true
+		}
 	}
+	return false
 }
 
 // formatGeneratedCode formats the generated Go code using go/format
@@ -5,6 +5,7 @@ import (
 	"go/parser"
 	"go/token"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,6 +26,9 @@ func TestIsSupportedXDRType(t *testing.T) {
 		{"uint32", "uint32", true},
 		{"uint64", "uint64", true},
 		{"int64", "int64", true},
+		{"uint16", "uint16", true},
+		{"int8", "int8", true},
+		{"int", "int", true},
 		{"string", "string", true},
 		{"bytes", "bytes", true},
 		{"bool", "bool", true},
@@ -44,6 +48,52 @@ func TestIsSupportedXDRType(t *testing.T) {
 	}
 }
 
+func TestAutoDiscoverNarrowIntegers(t *testing.T) {
+	aliases := map[string]string{"Mode": "uint16", "Digest": "[16]uint8"}
+	tests := map[string]string{
+		"uint8":        "uint8",
+		"byte":         "uint8",
+		"uint16":       "uint16",
+		"int8":         "int8",
+		"int16":        "int16",
+		"int":          "int",
+		"uint":         "uint",
+		"Mode":         "uint16",
+		"[]uint8":      "bytes",
+		"[4]uint8":     "bytes",
+		"Digest":       "bytes",
+		"[]int16":      "int16",
+		"[3]uint16":    "uint16",
+		"map[int]Mode": "map",
+	}
+	for goType, expected := range tests {
+		assert.Equal(t, expected, autoDiscoverXDRTypeWithFile(goType, aliases, nil, ""), "Unexpected XDR type for %s", goType)
+	}
+	assert.Equal(t, "[]byte", resolveAliasTypeWithFile("[]uint8", aliases, nil, ""))
+	assert.Equal(t, "[16]byte", resolveAliasTypeWithFile("Digest", aliases, nil, ""))
+}
+
+func TestValidConstValue(t *testing.T) {
+	tests := []struct {
+		value   string
+		xdrType string
+		valid   bool
+	}{
+		{"0xDEADBEEF", "uint32", true},
+		{"4294967296", "uint32", false},
+		{"-1", "uint32", false},
+		{"-1", "int64", true},
+		{"0x7fff", "int16", true},
+		{"0x8000", "int16", false},
+		{"0o644", "uint16", true},
+		{"MagicValue", "uint32", false},
+		{"1", "string", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.valid, validConstValue(tt.value, tt.xdrType), "validConstValue(%q, %s)", tt.value, tt.xdrType)
+	}
+}
+
 func TestParseXDRTag(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -80,6 +130,29 @@ func TestParseXDRTag(t *testing.T) {
 	}
 }
 
+func TestParseXDRTagOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected map[string]string
+	}{
+		{"empty", "", map[string]string{}},
+		{"flag", "inline", map[string]string{"inline": ""}},
+		{"union key", "union=Status", map[string]string{"union": "Status"}},
+		{
+			name:     "union with options",
+			tag:      "union=Status, unknown=reject,default=nil",
+			expected: map[string]string{"union": "Status", "unknown": "reject", "default": "nil"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseXDRTagOptions(tt.tag))
+		})
+	}
+}
+
 func TestExtractReceiverType(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -270,6 +343,378 @@ func TestFindKeyField(t *testing.T) {
 	}
 }
 
+func TestResolveDiscriminantXDRType(t *testing.T) {
+	aliases := map[string]string{
+		"OpCode":   "uint32",
+		"Stat":     "int32",
+		"Present":  "bool",
+		"NFSStat":  "Stat",
+		"Size":     "uint64",
+		"LoopA":    "LoopB",
+		"LoopB":    "LoopA",
+		"Filename": "string",
+	}
+
+	tests := []struct {
+		typeName string
+		expected string
+	}{
+		{"uint32", "uint32"},
+		{"int32", "int32"},
+		{"bool", "bool"},
+		{"OpCode", "uint32"},
+		{"Stat", "int32"},
+		{"Present", "bool"},
+		{"NFSStat", "int32"},
+		{"Size", ""},
+		{"Filename", ""},
+		{"LoopA", ""},
+		{"Unknown", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveDiscriminantXDRType(tt.typeName, aliases))
+		})
+	}
+}
+
+func TestCollectConstantsSignedAndBool(t *testing.T) {
+	src := `package test
+
+type Stat int32
+type Present bool
+
+const (
+	StatOK    Stat = 0
+	StatNoEnt Stat = -2
+	StatPlus  Stat = +3
+	StatParen Stat = (-4)
+	Yes Present = true
+	No  Present = false
+)
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, 0)
+	require.NoError(t, err)
+
+	constants := collectConstants(file)
+	assert.Equal(t, ConstantInfo{Value: "0", Type: "Stat"}, constants["StatOK"])
+	assert.Equal(t, ConstantInfo{Value: "-2", Type: "Stat"}, constants["StatNoEnt"])
+	assert.Equal(t, ConstantInfo{Value: "+3", Type: "Stat"}, constants["StatPlus"])
+	assert.Equal(t, ConstantInfo{Value: "-4", Type: "Stat"}, constants["StatParen"])
+	assert.Equal(t, ConstantInfo{Value: "true", Type: "Present"}, constants["Yes"])
+	assert.Equal(t, ConstantInfo{Value: "false", Type: "Present"}, constants["No"])
+}
+
+func TestComputeVoidCasesBool(t *testing.T) {
+	config := &UnionConfig{
+		DiscriminantType: "bool",
+		DiscriminantXDR:  "bool",
+		Cases:            map[string]string{"true": "Attr"},
+	}
+	assert.Equal(t, []string{"false"}, computeVoidCases(config, map[string]ConstantInfo{}))
+
+	config = &UnionConfig{
+		DiscriminantType: "Present",
+		DiscriminantXDR:  "bool",
+		Cases:            map[string]string{"Yes": "Attr"},
+	}
+	constants := map[string]ConstantInfo{
+		"Yes": {Value: "true", Type: "Present"},
+		"No":  {Value: "false", Type: "Present"},
+	}
+	assert.Equal(t, []string{"No"}, computeVoidCases(config, constants))
+}
+
+func TestCollectArmDirectives(t *testing.T) {
+	src := `package test
+
+// +xdr:arm,union=Result,discriminant=ResOK,type=uint64
+// +xdr:arm,union=Result,discriminant=ResData,type=[]byte
+
+// +xdr:union,key=Status
+type Result struct {
+	Status ResStat
+	Body   []byte
+}
+
+// +xdr:arm,union=Result,discriminant=ResHandle,type=[16]byte
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	arms := collectArmDirectives(file)
+	require.Len(t, arms, 3)
+	assert.Equal(t, &ArmDirective{Union: "Result", Discriminant: "ResOK", Type: "uint64"}, arms[0])
+	assert.Equal(t, &ArmDirective{Union: "Result", Discriminant: "ResData", Type: "[]byte"}, arms[1])
+	assert.Equal(t, &ArmDirective{Union: "Result", Discriminant: "ResHandle", Type: "[16]byte"}, arms[2])
+}
+
+func TestCollectConverterDirectives(t *testing.T) {
+	src := `package test
+
+import "net/netip"
+
+// +xdr:converter,type=netip.Addr,encode=encodeAddr,decode=decodeAddr
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	converters := collectConverterDirectives(file)
+	addr := &ConverterDirective{Type: "netip.Addr", Encode: "encodeAddr", Decode: "decodeAddr"}
+	assert.Equal(t, map[string]*ConverterDirective{"netip.Addr": addr}, converters)
+
+	assert.Equal(t, addr, converterFor("netip.Addr", converters))
+	assert.Equal(t, addr, converterFor("[]netip.Addr", converters), "Slices use the element converter")
+	assert.Equal(t, addr, converterFor("[4]netip.Addr", converters), "Fixed arrays use the element converter")
+	assert.Nil(t, converterFor("*netip.Addr", converters))
+	assert.Nil(t, converterFor("netip.Prefix", converters))
+}
+
+func TestCollectExtDirectives(t *testing.T) {
+	src := `package test
+
+// +xdr:ext,type=AccountExt,versions=AccountExtV1|AccountExtV2
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	exts := collectExtDirectives(file)
+	require.Len(t, exts, 1)
+	assert.Equal(t, ExtConfig{
+		TypeName: "AccountExt",
+		Versions: []ExtVersion{
+			{Number: 1, Field: "V1", Type: "AccountExtV1"},
+			{Number: 2, Field: "V2", Type: "AccountExtV2"},
+		},
+	}, exts[0])
+	assert.Equal(t, 2, exts[0].MaxVersion())
+
+	for _, args := range []map[string]string{
+		{"type": "AccountExt"},
+		{"versions": "AccountExtV1"},
+		{"type": "accountExt", "versions": "AccountExtV1"},
+		{"type": "AccountExt", "versions": "AccountExtV1||AccountExtV3"},
+		{"type": "AccountExt", "versions": "proto.AccountExtV1"},
+	} {
+		_, err := parseExtDirective(args)
+		assert.Error(t, err, "Expected error for %v", args)
+	}
+}
+
+func TestCollectRegisterDirectives(t *testing.T) {
+	src := `package test
+
+// +xdr:register,interface=Plugin,type=Gzip,id=1
+// +xdr:register,interface=Plugin,type=Rename,id=RenameID
+// +xdr:register,interface=Filter,type=Prefix,id=0x10
+
+type Plugin interface {
+	xdr.Codec
+	Name() string
+}
+
+type Filter interface {
+	Encode(enc *xdr.Encoder) error
+	Decode(dec *xdr.Decoder) error
+}
+
+type Named interface {
+	Name() string
+}
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	registries := groupRegistrations(collectRegisterDirectives(file))
+	require.Len(t, registries, 2)
+	assert.Equal(t, "PluginRegistry", registries[0].Registry)
+	require.Len(t, registries[0].Types, 2)
+	assert.Equal(t, "1", registries[0].Types[0].IDExpr())
+	assert.Equal(t, "uint32(RenameID)", registries[0].Types[1].IDExpr())
+	assert.Equal(t, "FilterRegistry", registries[1].Registry)
+	assert.Equal(t, "0x10", registries[1].Types[0].IDExpr())
+
+	typeDefs := make(map[string]ast.Node)
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok {
+			typeDefs[genDecl.Specs[0].(*ast.TypeSpec).Name.Name] = genDecl
+		}
+	}
+	assert.True(t, embedsCodec(lookupInterface("Plugin", typeDefs)), "Embedded xdr.Codec")
+	assert.True(t, embedsCodec(lookupInterface("Filter", typeDefs)), "Encode and Decode methods")
+	assert.False(t, embedsCodec(lookupInterface("Named", typeDefs)))
+	assert.Nil(t, lookupInterface("Missing", typeDefs))
+}
+
+func TestCollectEnvelopeDirectives(t *testing.T) {
+	src := `package test
+
+// +xdr:envelope,type=Created,id=0x10,version=2
+// +xdr:envelope,type=Deleted,id=DeletedID
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	envelopes := collectEnvelopeDirectives(file)
+	require.Len(t, envelopes, 2)
+	assert.Equal(t, &EnvelopeDirective{Type: "Created", ID: "0x10", Version: "2"}, envelopes[0])
+	assert.Equal(t, &EnvelopeDirective{Type: "Deleted", ID: "DeletedID", Version: "1"}, envelopes[1], "Version should default to 1")
+	assert.Equal(t, "uint32(DeletedID)", envelopes[1].IDExpr())
+	assert.Equal(t, "1", envelopes[1].VersionExpr())
+}
+
+func TestExpandEmbeddedFields(t *testing.T) {
+	src := `package test
+
+type Auth struct {
+	UID uint32
+	GID uint32
+}
+
+type Handle struct {
+	Data []byte
+}
+
+type Args struct {
+	Handle
+	*Auth ` + "`xdr:\"inline\"`" + `
+	Offset uint64
+}
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	typeDefs := make(map[string]ast.Node)
+	var args *ast.StructType
+	for _, decl := range file.Decls {
+		genDecl := decl.(*ast.GenDecl)
+		typeSpec := genDecl.Specs[0].(*ast.TypeSpec)
+		typeDefs[typeSpec.Name.Name] = genDecl
+		if typeSpec.Name.Name == "Args" {
+			args = typeSpec.Type.(*ast.StructType)
+		}
+	}
+
+	fields := expandEmbeddedFields("Args", args.Fields.List, typeDefs, file, "test.go", map[string]bool{})
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Names[0].Name)
+	}
+	assert.Equal(t, []string{"Handle", "Auth", "UID", "GID", "Offset"}, names)
+	assert.Equal(t, "Handle", formatType(fields[0].Type), "Delegated field keeps the embedded type")
+	assert.True(t, fields[1].InlinePointer, "Inline pointer is preceded by an allocation marker")
+	assert.Equal(t, "*Auth", formatType(fields[1].Type))
+}
+
+func TestParseNamedType(t *testing.T) {
+	src := `package test
+
+type Entry struct {
+	ID uint32
+}
+
+// Entries is a list message
+// +xdr:generate
+type Entries []uint32
+
+// Listing is a reply payload
+// +xdr:payload,union=Reply,discriminant=ReplyOK
+type Listing []Entry
+
+// Path has no directive
+type Path string
+
+type Groups [16]uint32
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	specs := make(map[string]*ast.TypeSpec)
+	for _, decl := range file.Decls {
+		typeSpec := decl.(*ast.GenDecl).Specs[0].(*ast.TypeSpec)
+		specs[typeSpec.Name.Name] = typeSpec
+	}
+	aliases := map[string]string{"Entries": "[]uint32", "Listing": "[]Entry", "Path": "string", "Groups": "[16]uint32"}
+
+	entries, ok := parseNamedType(specs["Entries"], file, "test.go", aliases)
+	require.True(t, ok)
+	assert.Equal(t, "[]uint32", entries.Underlying)
+	require.Len(t, entries.Fields, 1)
+	assert.Equal(t, "Value", entries.Fields[0].Name)
+	assert.Equal(t, "uint32", entries.Fields[0].XDRType)
+	assert.False(t, entries.IsPayload)
+
+	listing, ok := parseNamedType(specs["Listing"], file, "test.go", aliases)
+	require.True(t, ok)
+	assert.True(t, listing.IsPayload)
+	assert.Equal(t, &PayloadConfig{UnionType: "Reply", Discriminant: "ReplyOK"}, listing.PayloadConfig)
+
+	_, ok = parseNamedType(specs["Path"], file, "test.go", aliases)
+	assert.False(t, ok, "Named types without a directive are not generated")
+	_, ok = parseNamedType(specs["Groups"], file, "test.go", aliases)
+	assert.False(t, ok, "Named types without a doc comment are not generated")
+}
+
+func TestQualifyExpr(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{"Handle", "proto.Handle"},
+		{"*Node", "*proto.Node"},
+		{"[]Entry", "[]proto.Entry"},
+		{"[MaxLen]byte", "[proto.MaxLen]byte"},
+		{"map[string]Attr", "map[string]proto.Attr"},
+		{"uint32", "uint32"},
+		{"time.Time", "time.Time"},
+	}
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.src)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, formatType(qualifyExpr(expr, "proto")), tt.src)
+	}
+}
+
+func TestGenericTypeNames(t *testing.T) {
+	expr, err := parser.ParseExpr("Page[*Entry]")
+	require.NoError(t, err)
+	assert.Equal(t, "Page[*Entry]", formatType(expr))
+
+	expr, err = parser.ParseExpr("proto.Pair[*Key, *Value]")
+	require.NoError(t, err)
+	assert.Equal(t, "proto.Pair[*Key, *Value]", formatType(expr))
+
+	expr, err = parser.ParseExpr("*Pair[K, V]")
+	require.NoError(t, err)
+	assert.Equal(t, "*Pair", extractReceiverType(expr))
+
+	for src, want := range map[string]string{
+		"interface{ *T; xdr.Codec }": "T",
+		"interface{ xdr.Codec; *T }": "T",
+		"xdr.Codec":                  "",
+		"interface{ *T }":            "",
+		"any":                        "",
+	} {
+		expr, err := parser.ParseExpr(src)
+		require.NoError(t, err)
+		pointee, ok := codecPointerConstraint(expr)
+		assert.Equal(t, want, pointee, src)
+		assert.Equal(t, want != "", ok, src)
+	}
+
+	typeParams := map[string]bool{"T": true}
+	assert.True(t, mapUsesTypeParam("map[string]T", typeParams))
+	assert.True(t, mapUsesTypeParam("map[T]*T", typeParams))
+	assert.False(t, mapUsesTypeParam("map[string]uint32", typeParams))
+	assert.False(t, mapUsesTypeParam("[]T", typeParams))
+
+	assert.Equal(t, "[K, V]", TypeInfo{Name: "Pair", TypeParams: []string{"K", "V"}}.typeParamList())
+	assert.Equal(t, "", TypeInfo{Name: "Entry"}.typeParamList())
+	assert.Equal(t, "[T any, PT interface{ *T; xdr.Codec }]", TypeInfo{Name: "Page", TypeParams: []string{"T", "PT"}, PointerParams: map[string]string{"PT": "T"}}.typeParamDecl())
+	assert.Equal(t, "[T xdr.Codec]", TypeInfo{Name: "Page", TypeParams: []string{"T"}, CodecParams: map[string]bool{"T": true}}.typeParamDecl())
+}
+
 func TestParseUnionComment(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -418,3 +863,286 @@ func createTempFile(t *testing.T, content string) string {
 
 	return tmpFile.Name()
 }
+
+func TestCrossPackageUnionRefs(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0600))
+	}
+	writeFile("go.mod", "module example.com/crosspkg\n\ngo 1.23\n")
+	writeFile("proto/proto.go", `package proto
+
+type MsgType int32
+
+const (
+	MsgPing MsgType = 0
+	MsgText MsgType = -1
+)
+
+// +xdr:union,key=Type
+type Message struct {
+	Type MsgType
+	Body []byte
+}
+`)
+	writeFile("feature/feature.go", `package feature
+
+import "example.com/crosspkg/proto"
+
+// +xdr:payload,union=proto.Message,discriminant=proto.MsgText
+type TextBody struct {
+	Text string
+}
+
+var _ = proto.MsgPing
+`)
+
+	t.Run("payload package merges qualified declarations", func(t *testing.T) {
+		pkg, err := loadForeignPackage("example.com/crosspkg/proto", filepath.Join(root, "feature", "feature.go"))
+		require.NoError(t, err)
+
+		typeDefs := make(map[string]ast.Node)
+		typeAliases := make(map[string]string)
+		constants := make(map[string]ConstantInfo)
+		mergeForeignPackage("proto", pkg, typeDefs, typeAliases, constants)
+
+		assert.Equal(t, ConstantInfo{Value: "-1", Type: "proto.MsgType"}, constants["proto.MsgText"])
+		assert.Equal(t, "int32", typeAliases["proto.MsgType"])
+		assert.Equal(t, "proto.MsgType", foreignUnionKeyType("proto.Message", "", typeDefs))
+
+		keyField, payloadField := getUnionFieldNames("proto.Message", typeDefs)
+		assert.Equal(t, "Type", keyField)
+		assert.Equal(t, "Body", payloadField)
+
+		assert.Equal(t, "int32", resolveDiscriminantXDRType("proto.MsgType", typeAliases))
+	})
+
+	t.Run("union package finds foreign payloads", func(t *testing.T) {
+		protoFile, err := parser.ParseFile(token.NewFileSet(), filepath.Join(root, "proto", "proto.go"), nil, parser.ParseComments)
+		require.NoError(t, err)
+
+		mappings := collectForeignPayloadMappings(filepath.Join(root, "proto"), []*ast.File{protoFile})
+		assert.Equal(t, map[string][]PayloadMapping{
+			"Message": {{PayloadType: "feature.TextBody", Discriminant: "MsgText"}},
+		}, mappings)
+	})
+}
+
+func TestExtractPackageImportsForForeignPayload(t *testing.T) {
+	file := &ast.File{
+		Imports: []*ast.ImportSpec{
+			{Path: &ast.BasicLit{Value: `"example.com/crosspkg/proto"`}},
+		},
+	}
+	types := []TypeInfo{
+		{
+			Name:          "TextBody",
+			IsPayload:     true,
+			PayloadConfig: &PayloadConfig{UnionType: "proto.Message", Discriminant: "proto.MsgText"},
+			Fields:        []FieldInfo{{Name: "Text", Type: "string", XDRType: "string"}},
+		},
+	}
+	assert.Equal(t, []string{"example.com/crosspkg/proto"}, extractPackageImportsFromTypes(types, file))
+}
+
+func TestExtractPackageImportsForTimeFields(t *testing.T) {
+	file := &ast.File{
+		Imports: []*ast.ImportSpec{
+			{Path: &ast.BasicLit{Value: `"time"`}},
+		},
+	}
+	direct := []TypeInfo{{Name: "Attr", Fields: []FieldInfo{
+		{Name: "Mtime", Type: "time.Time", XDRType: "time:timespec"},
+		{Name: "TTL", Type: "time.Duration", XDRType: "duration:unixnano"},
+	}}}
+	assert.Empty(t, extractPackageImportsFromTypes(direct, file), "Direct time fields need no import")
+
+	named := []TypeInfo{{Name: "Attr", Fields: []FieldInfo{
+		{Name: "Birth", Type: "Stamp", XDRType: "time:nfs"},
+	}}}
+	assert.Equal(t, []string{"time"}, extractPackageImportsFromTypes(named, file), "Named time fields convert through time.Time")
+}
+
+func TestExtractPackageImportsForConverterFields(t *testing.T) {
+	file := &ast.File{
+		Imports: []*ast.ImportSpec{
+			{Path: &ast.BasicLit{Value: `"net/netip"`}},
+		},
+	}
+	converter := &ConverterDirective{Type: "netip.Addr", Encode: "encodeAddr", Decode: "decodeAddr"}
+	direct := []TypeInfo{{Name: "Endpoint", Fields: []FieldInfo{
+		{Name: "Addr", Type: "netip.Addr", XDRType: "converter", Converter: converter},
+		{Name: "Resolvers", Type: "[2]netip.Addr", XDRType: "converter", Converter: converter},
+	}}}
+	assert.Empty(t, extractPackageImportsFromTypes(direct, file), "Converter values are passed through")
+
+	slice := []TypeInfo{{Name: "Endpoint", Fields: []FieldInfo{
+		{Name: "Backups", Type: "[]netip.Addr", XDRType: "converter", Converter: converter},
+	}}}
+	assert.Equal(t, []string{"net/netip"}, extractPackageImportsFromTypes(slice, file), "Decoding a slice allocates it")
+}
+
+func TestCollectConstantsIota(t *testing.T) {
+	src := `package test
+
+type Op uint32
+
+const (
+	OpRead Op = iota + 1
+	OpWrite
+	OpSync
+)
+
+const (
+	FlagA Op = 1 << iota
+	FlagB
+	OpLatest = OpWrite
+	OpMax    = Op(0x10)
+)
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, 0)
+	require.NoError(t, err)
+
+	constants := collectConstants(file)
+	assert.Equal(t, ConstantInfo{Value: "1", Type: "Op"}, constants["OpRead"])
+	assert.Equal(t, ConstantInfo{Value: "3", Type: "Op"}, constants["OpSync"])
+	assert.Equal(t, ConstantInfo{Value: "2", Type: "Op"}, constants["FlagB"])
+	assert.Equal(t, ConstantInfo{Value: "2", Type: "Op"}, constants["OpLatest"])
+	assert.Equal(t, "16", constantValueKey(constants["OpMax"].Value))
+	assert.Equal(t, "-5", constantValueKey("-0x5"))
+}
+
+func TestValidateUnionExhaustiveness(t *testing.T) {
+	constants := map[string]ConstantInfo{
+		"OpRead":   {Value: "1", Type: "Op"},
+		"OpWrite":  {Value: "2", Type: "Op"},
+		"OpLatest": {Value: "0x2", Type: "Op"},
+		"OpSync":   {Value: "3", Type: "Op"},
+		"ModeFast": {Value: "4", Type: "Mode"},
+	}
+	union := func(config UnionConfig) []TypeInfo {
+		config.ContainerType = "Request"
+		config.DiscriminantType = "Op"
+		config.VoidCases = computeVoidCases(&config, constants)
+		return []TypeInfo{{Name: "Request", IsDiscriminatedUnion: true, UnionConfig: &config}}
+	}
+
+	tests := []struct {
+		name     string
+		types    []TypeInfo
+		expected []string
+	}{
+		{
+			name:  "inferred void cases allowed when not exhaustive",
+			types: union(UnionConfig{Cases: map[string]string{"OpRead": "ReadArgs"}}),
+		},
+		{
+			name: "exhaustive reports constants without payload or arm",
+			types: union(UnionConfig{
+				Exhaustive: true,
+				Cases:      map[string]string{"OpRead": "ReadArgs"},
+				Arms:       map[string]string{"OpWrite": "uint64"},
+			}),
+			expected: []string{"discriminant OpSync of key type Op has no payload, arm or void declaration (union is exhaustive)"},
+		},
+		{
+			name: "duplicate values selecting different arms",
+			types: union(UnionConfig{
+				Cases: map[string]string{"OpWrite": "WriteArgs", "OpLatest": "LatestArgs"},
+			}),
+			expected: []string{"discriminants OpLatest and OpWrite have the same value 2 but select different arms (LatestArgs and WriteArgs)"},
+		},
+		{
+			name: "declared void cases satisfy exhaustive",
+			types: union(UnionConfig{
+				Exhaustive:   true,
+				Cases:        map[string]string{"OpRead": "ReadArgs", "OpWrite": "WriteArgs"},
+				DeclaredVoid: []string{"OpSync"},
+			}),
+		},
+		{
+			name: "declared void conflicts",
+			types: union(UnionConfig{
+				Cases:        map[string]string{"OpRead": "ReadArgs"},
+				DeclaredVoid: []string{"OpRead", "OpMissing"},
+			}),
+			expected: []string{
+				"discriminant OpRead is declared void but also selects ReadArgs",
+				"void discriminant OpMissing not found for discriminant type Op",
+			},
+		},
+		{
+			name: "integer literal void cases satisfy exhaustive",
+			types: union(UnionConfig{
+				Exhaustive:   true,
+				Cases:        map[string]string{"OpRead": "ReadArgs", "OpWrite": "WriteArgs"},
+				DeclaredVoid: []string{"0x3"},
+			}),
+		},
+		{
+			name: "integer literal void case sharing a payload value",
+			types: union(UnionConfig{
+				Cases:        map[string]string{"OpRead": "ReadArgs"},
+				DeclaredVoid: []string{"1"},
+			}),
+			expected: []string{"discriminants OpRead and 1 have the same value 1 but select different arms (ReadArgs and void)"},
+		},
+		{
+			name: "discriminant of another type",
+			types: union(UnionConfig{
+				Cases: map[string]string{"ModeFast": "FastArgs"},
+			}),
+			expected: []string{"discriminant ModeFast has type Mode but the union key type is Op"},
+		},
+		{
+			name: "declared arms match payloads and arms",
+			types: union(UnionConfig{
+				Cases:        map[string]string{"OpRead": "ReadArgs"},
+				Arms:         map[string]string{"OpWrite": "uint64"},
+				DeclaredArms: []string{"1", "OpLatest"},
+			}),
+		},
+		{
+			name: "declared arms mismatch",
+			types: union(UnionConfig{
+				Cases:        map[string]string{"OpRead": "ReadArgs", "OpWrite": "WriteArgs"},
+				DeclaredArms: []string{"OpRead", "OpSync", "OpMissing"},
+			}),
+			expected: []string{
+				"arm discriminant OpMissing not found for discriminant type Op",
+				"discriminant OpWrite selects WriteArgs but is not listed in arms=",
+				"discriminant OpSync is listed in arms= but selects no payload or arm",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var messages []string
+			for _, err := range validateUnionExhaustiveness(tt.types, constants) {
+				messages = append(messages, err.Message)
+			}
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
+
+func TestComputeVoidCasesDeclared(t *testing.T) {
+	constants := map[string]ConstantInfo{
+		"StatOK":    {Value: "0", Type: "Stat"},
+		"StatNoEnt": {Value: "2", Type: "Stat"},
+		"StatIO":    {Value: "5", Type: "Stat"},
+	}
+	config := &UnionConfig{
+		DiscriminantType: "Stat",
+		Cases:            map[string]string{"StatOK": "OKBody"},
+		DeclaredVoid:     []string{"StatNoEnt"},
+	}
+	assert.Equal(t, []string{"StatNoEnt"}, computeVoidCases(config, constants))
+	assert.Equal(t, []string{"StatIO"}, unselectedConstants(config, constants))
+
+	config.DeclaredVoid = parseVoidList("")
+	assert.Equal(t, []string{}, computeVoidCases(config, constants))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MuxConfig describes the typed xdr.Mux generated for a union container with +xdr:union,mux
+type MuxConfig struct {
+	UnionType        string    // e.g., "Message"
+	MuxType          string    // generated type, e.g., "MessageMux"
+	DiscriminantType string    // Go type of the union key, e.g., "MessageType"
+	Cases            []MuxCase // handled discriminants in name order
+}
+
+// MuxCase is one discriminant with a generated Handle method
+type MuxCase struct {
+	Discriminant string // discriminant constant, e.g., "MsgRead"
+	Method       string // registration method, e.g., "HandleMsgRead"
+	PayloadType  string // payload struct of a payload case
+	ArmType      string // Go type of an +xdr:arm case
+	WrapperType  string // wrapper struct decoding an +xdr:arm case
+	Void         bool   // the discriminant carries no payload
+}
+
+// buildMuxConfig returns the Mux of a union container from its package-level union configuration
+func buildMuxConfig(typeInfo TypeInfo) (MuxConfig, error) {
+	unionConfig := typeInfo.UnionConfig
+	if unionConfig.DiscriminantXDR != "uint32" && unionConfig.DiscriminantXDR != "int32" {
+		return MuxConfig{}, fmt.Errorf("union %s has mux but its key is not a 32-bit integer", typeInfo.Name)
+	}
+	discriminantType := unionConfig.DiscriminantType
+	if discriminantType == "" {
+		discriminantType = unionConfig.DiscriminantXDR
+	}
+	config := MuxConfig{
+		UnionType:        typeInfo.Name,
+		MuxType:          typeInfo.Name + "Mux",
+		DiscriminantType: discriminantType,
+	}
+
+	cases := make(map[string]MuxCase)
+	for discriminant, payloadType := range unionConfig.Cases {
+		cases[discriminant] = MuxCase{PayloadType: payloadType}
+	}
+	for discriminant, armType := range unionConfig.Arms {
+		cases[discriminant] = MuxCase{
+			ArmType:     armType,
+			WrapperType: lowerFirst(typeInfo.Name) + upperFirst(discriminant) + "Arm",
+		}
+	}
+	for _, discriminant := range unionConfig.VoidCases {
+		cases[discriminant] = MuxCase{Void: true}
+	}
+
+	var discriminants []string
+	for discriminant := range cases {
+		discriminants = append(discriminants, discriminant)
+	}
+	sort.Strings(discriminants)
+	for _, discriminant := range discriminants {
+		muxCase := cases[discriminant]
+		muxCase.Discriminant = discriminant
+		// Constants of a key type from another package are named without their qualifier
+		muxCase.Method = "Handle" + upperFirst(discriminant[strings.LastIndex(discriminant, ".")+1:])
+		if muxCase.Method == "HandleUnknown" {
+			return MuxConfig{}, fmt.Errorf("union %s has mux but discriminant %s conflicts with xdr.Mux.HandleUnknown", typeInfo.Name, discriminant)
+		}
+		config.Cases = append(config.Cases, muxCase)
+	}
+	return config, nil
+}
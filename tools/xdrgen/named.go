@@ -0,0 +1,89 @@
+package main
+
+import (
+	"go/ast"
+	"log"
+)
+
+// typeSpecDoc returns the doc comment of a type declaration, which is on the GenDecl
+// unless the type is declared in a parenthesized group
+func typeSpecDoc(file *ast.File, spec *ast.TypeSpec) *ast.CommentGroup {
+	if spec.Doc != nil {
+		return spec.Doc
+	}
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && len(genDecl.Specs) == 1 && genDecl.Specs[0] == spec {
+			return genDecl.Doc
+		}
+	}
+	return nil
+}
+
+// parseNamedType returns the TypeInfo of a named non-struct type marked +xdr:generate or +xdr:payload
+// Its single Value field holds the underlying type, encoded by a generated wrapper struct
+func parseNamedType(spec *ast.TypeSpec, file *ast.File, filename string, typeAliases map[string]string) (TypeInfo, bool) {
+	// Only the type's own doc comment may carry directives: unlike structs, named types
+	// commonly follow a generated struct without a comment of their own
+	if typeSpecDoc(file, spec) == nil {
+		return TypeInfo{}, false
+	}
+	directives := collectXDRDirectives(file, spec.Pos())
+	if !directives.Generate && directives.Payload == nil && directives.Union == nil {
+		return TypeInfo{}, false
+	}
+
+	name := spec.Name.Name
+	underlying := formatType(spec.Type)
+	switch {
+	case directives.Union != nil:
+		log.Fatalf("Union container %s must be a struct, got %s", name, underlying)
+	case spec.Assign != 0:
+		log.Fatalf("Type %s is an alias of %s; declare it as a named type (type %s %s) to generate its Codec", name, underlying, name, underlying)
+	case spec.TypeParams != nil:
+		log.Fatalf("Generic type %s must be a struct", name)
+	}
+	switch spec.Type.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.ArrayType, *ast.MapType:
+	default:
+		log.Fatalf("Type %s has unsupported underlying type %s (use a primitive, array, slice, map or struct)", name, underlying)
+	}
+
+	hasEncode, hasDecode := hasExistingMethods(file, name)
+	if hasEncode && hasDecode {
+		logf("Skipping %s - already has Encode/Decode methods", name)
+		return TypeInfo{}, false
+	}
+	if hasEncode || hasDecode {
+		log.Fatalf("Type %s has partial XDR implementation (only %s method found). Either implement both Encode and Decode manually, or remove existing method to use code generation.",
+			name, map[bool]string{true: "Encode", false: "Decode"}[hasEncode])
+	}
+
+	field := newArmFieldInfo(underlying, typeAliases, file, filename)
+	if field.XDRType == "map" {
+		resolveMapFieldInfo(&field, name, typeAliases, file, filename)
+	}
+	if containsAnyOrInterface(underlying) || !isSupportedXDRType(field.XDRType) {
+		log.Fatalf("Type %s has unsupported underlying type %s", name, underlying)
+	}
+	debugf("Named type %s encodes as %s (%s)", name, underlying, field.XDRType)
+
+	typeInfo := TypeInfo{
+		Name:       name,
+		Underlying: underlying,
+		Fields:     []FieldInfo{field},
+	}
+	if directives.Payload != nil {
+		typeInfo.IsPayload = true
+		typeInfo.PayloadConfig = &PayloadConfig{
+			UnionType:    directives.Payload.Union,
+			UnionField:   directives.Payload.Field,
+			Discriminant: directives.Payload.Discriminant,
+		}
+	}
+	return typeInfo, true
+}
+
+// namedTypeWrapper returns the name of the struct that encodes a named non-struct type
+func namedTypeWrapper(typeName string) string {
+	return lowerFirst(typeName) + "Value"
+}
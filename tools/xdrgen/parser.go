@@ -3,11 +3,16 @@ package main
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
@@ -16,11 +21,13 @@ import (
 // isSupportedXDRType checks if an auto-detected XDR type is supported by the generator
 func isSupportedXDRType(xdrType string) bool {
 	switch xdrType {
-	case "uint32", "uint64", "int32", "int64", "string", "bytes", "bool", "struct", "array":
+	case "uint32", "uint64", "int32", "int64", "string", "bytes", "bool", "struct", "array", "map", "converter",
+		"uint8", "uint16", "int8", "int16", "int", "uint", "reserved", "interface":
 		return true
 	default:
 		// Handle complex types with prefixes
-		if strings.HasPrefix(xdrType, "fixed:") || strings.HasPrefix(xdrType, "alias:") {
+		if strings.HasPrefix(xdrType, "fixed:") || strings.HasPrefix(xdrType, "alias:") ||
+			strings.HasPrefix(xdrType, "time:") || strings.HasPrefix(xdrType, "duration:") {
 			return true
 		}
 		return false
@@ -109,22 +116,7 @@ func validateDecodeSignature(funcDecl *ast.FuncDecl) bool {
 
 // collectExternalImports collects external package imports from types
 func collectExternalImports(types []TypeInfo, file *ast.File) []string {
-	imports := extractPackageImportsFromTypes(types, file)
-
-	// Only add "unsafe" import if any types need loop detection
-	needsUnsafe := false
-	for _, typeInfo := range types {
-		if typeInfo.CanHaveLoops && !disableLoopDetection {
-			needsUnsafe = true
-			break
-		}
-	}
-
-	if needsUnsafe {
-		imports = append(imports, "unsafe")
-	}
-
-	return imports
+	return extractPackageImportsFromTypes(types, file)
 }
 
 // extractPackageImports extracts package imports from types used in the struct
@@ -157,6 +149,17 @@ func extractPackageImportsFromTypes(types []TypeInfo, file *ast.File) []string {
 	usedPackages := make(map[string]bool)
 	for _, typeInfo := range types {
 		for _, field := range typeInfo.Fields {
+			// Time fields only refer to the time package when converting a named type
+			if timeMethodSuffix(field.XDRType) != "" {
+				if field.Type != "time.Time" && field.Type != "time.Duration" {
+					usedPackages["time"] = true
+				}
+				continue
+			}
+			// Converter values are passed through as is; only slices and arm accessors name the type
+			if field.XDRType == "converter" && !strings.HasPrefix(field.Type, "[]") && typeInfo.ArmConfig == nil {
+				continue
+			}
 			// Only include packages for fields that will generate package references
 			if willGeneratePackageReference(field) {
 				packageNames := extractPackageNamesFromType(field.Type)
@@ -167,6 +170,21 @@ func extractPackageImportsFromTypes(types []TypeInfo, file *ast.File) []string {
 				}
 			}
 		}
+
+		// ToUnion and EncodeToUnion refer to a union and discriminant in another package
+		if typeInfo.PayloadConfig != nil {
+			refs := []string{typeInfo.PayloadConfig.Discriminant}
+			if typeInfo.PayloadConfig.UnionField == "" {
+				refs = append(refs, typeInfo.PayloadConfig.UnionType)
+			}
+			for _, ref := range refs {
+				if qualifier, _, ok := splitQualified(ref); ok {
+					if importPath, exists := packageMap[qualifier]; exists {
+						usedPackages[importPath] = true
+					}
+				}
+			}
+		}
 	}
 
 	// Convert to slice
@@ -221,6 +239,92 @@ func findKeyField(structInfo TypeInfo) string {
 	return ""
 }
 
+// findKeyFieldType finds the Go type of the key field in the given struct
+func findKeyFieldType(structInfo TypeInfo) string {
+	for _, field := range structInfo.Fields {
+		if field.IsKey {
+			return field.Type
+		}
+	}
+	return ""
+}
+
+// timeFieldKind reports whether a type is time.Time ("time") or time.Duration ("duration"),
+// following local type definitions such as type Stamp time.Time
+func timeFieldKind(goType string, typeAliases map[string]string, file *ast.File, filename string) string {
+	for range 10 {
+		if qualifier, name, ok := splitQualified(goType); ok {
+			if findImportPath(qualifier, file, filename) != "time" {
+				return ""
+			}
+			switch name {
+			case "Time":
+				return "time"
+			case "Duration":
+				return "duration"
+			}
+			return ""
+		}
+		underlying, ok := typeAliases[goType]
+		if !ok {
+			return ""
+		}
+		goType = underlying
+	}
+	return ""
+}
+
+// arrayElementType strips every array dimension written in a type, "[][2]time.Time" -> "time.Time"
+func arrayElementType(goType string) string {
+	for strings.HasPrefix(goType, "[") {
+		goType = goType[strings.Index(goType, "]")+1:]
+	}
+	return goType
+}
+
+// codecPointerConstraint reports whether a type parameter constraint is interface{ *T; xdr.Codec },
+// returning T
+func codecPointerConstraint(expr ast.Expr) (string, bool) {
+	iface, ok := expr.(*ast.InterfaceType)
+	if !ok || len(iface.Methods.List) != 2 {
+		return "", false
+	}
+	var pointee string
+	hasCodec := false
+	for _, elem := range iface.Methods.List {
+		if len(elem.Names) > 0 {
+			return "", false
+		}
+		if star, ok := elem.Type.(*ast.StarExpr); ok {
+			if ident, ok := star.X.(*ast.Ident); ok {
+				pointee = ident.Name
+			}
+		} else if formatType(elem.Type) == "xdr.Codec" {
+			hasCodec = true
+		}
+	}
+	return pointee, pointee != "" && hasCodec
+}
+
+// mapUsesTypeParam reports whether a map type has a type parameter as its key or value
+func mapUsesTypeParam(goType string, typeParams map[string]bool) bool {
+	if !strings.HasPrefix(goType, "map[") {
+		return false
+	}
+	keyType, valueType := splitMapType(goType)
+	return typeParams[keyType] || typeParams[strings.TrimPrefix(valueType, "*")]
+}
+
+// splitMapType splits "map[K]V" into its key and value types
+func splitMapType(mapType string) (keyType, valueType string) {
+	inner := strings.TrimPrefix(mapType, "map[")
+	closeBracket := strings.Index(inner, "]")
+	if closeBracket < 0 {
+		return "", ""
+	}
+	return inner[:closeBracket], inner[closeBracket+1:]
+}
+
 // parseXDRTag extracts XDR encoding information from struct tag
 func parseXDRTag(tag string) string {
 	if tag == "" {
@@ -238,6 +342,52 @@ func parseXDRTag(tag string) string {
 	return xdrPart
 }
 
+// parseXDRTagOptions parses a comma-separated xdr tag into options
+// Format: name=value,flag (flags map to an empty value)
+func parseXDRTagOptions(xdrTag string) map[string]string {
+	options := make(map[string]string)
+	if xdrTag == "" {
+		return options
+	}
+	for _, part := range strings.Split(xdrTag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) == 2 {
+			options[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		} else {
+			options[part] = ""
+		}
+	}
+	return options
+}
+
+// constIntSizes maps the integer XDR types a constant field may have to their bit size and signedness
+var constIntSizes = map[string]struct {
+	bits   int
+	signed bool
+}{
+	"uint8": {8, false}, "uint16": {16, false}, "uint32": {32, false}, "uint64": {64, false}, "uint": {64, false},
+	"int8": {8, true}, "int16": {16, true}, "int32": {32, true}, "int64": {64, true}, "int": {64, true},
+}
+
+// validConstValue reports whether value is an integer literal that fits the XDR type of a constant field
+func validConstValue(value, xdrType string) bool {
+	size, ok := constIntSizes[xdrType]
+	if !ok {
+		return false
+	}
+	var err error
+	if size.signed {
+		_, err = strconv.ParseInt(value, 0, size.bits)
+	} else {
+		_, err = strconv.ParseUint(value, 0, size.bits)
+	}
+	return err == nil
+}
+
 // parseUnionComment parses a union configuration comment
 // Format: //xdr:union=DiscriminantType,case=ConstantValue
 func parseUnionComment(comment string) (*UnionConfig, error) {
@@ -296,27 +446,46 @@ type ConstantInfo struct {
 // collectConstants collects all constant definitions from the AST
 func collectConstants(file *ast.File) map[string]ConstantInfo {
 	constants := make(map[string]ConstantInfo)
+	known := make(map[string]constant.Value) // evaluated constants for later expressions
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		if decl, ok := n.(*ast.GenDecl); ok && decl.Tok == token.CONST {
-			for _, spec := range decl.Specs {
-				if valueSpec, ok := spec.(*ast.ValueSpec); ok {
-					// Get the type if specified
-					var typeName string
-					if valueSpec.Type != nil {
-						if ident, ok := valueSpec.Type.(*ast.Ident); ok {
-							typeName = ident.Name
-						}
+			// A spec without values repeats the previous type and expressions (iota enums)
+			var typeName string
+			var values []ast.Expr
+			for iota, spec := range decl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				if len(valueSpec.Values) > 0 {
+					values = valueSpec.Values
+					typeName = ""
+					if ident, ok := valueSpec.Type.(*ast.Ident); ok {
+						typeName = ident.Name
 					}
+				}
 
-					for i, name := range valueSpec.Names {
-						if i < len(valueSpec.Values) {
-							if basicLit, ok := valueSpec.Values[i].(*ast.BasicLit); ok {
-								constants[name.Name] = ConstantInfo{
-									Value: basicLit.Value,
-									Type:  typeName,
-								}
-							}
+				for i, name := range valueSpec.Names {
+					if i >= len(values) {
+						continue
+					}
+					evaluated, evalOK := evalConstantExpr(values[i], int64(iota), known)
+					if evalOK {
+						known[name.Name] = evaluated
+					}
+					value, ok := constantLiteralValue(values[i])
+					if !ok && evalOK {
+						value, ok = evaluated.ExactString(), true
+					}
+					if ok {
+						constantType := typeName
+						if constantType == "" {
+							constantType = inferConstantType(values[i], constants)
+						}
+						constants[name.Name] = ConstantInfo{
+							Value: value,
+							Type:  constantType,
 						}
 					}
 				}
@@ -328,6 +497,127 @@ func collectConstants(file *ast.File) map[string]ConstantInfo {
 	return constants
 }
 
+// evalConstantExpr evaluates an integer or boolean constant expression such as 1 << iota
+// Identifiers resolve to iota, true/false and previously evaluated constants
+func evalConstantExpr(expr ast.Expr, iota int64, known map[string]constant.Value) (constant.Value, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		value := constant.MakeFromLiteral(e.Value, e.Kind, 0)
+		return value, value.Kind() != constant.Unknown
+	case *ast.Ident:
+		switch e.Name {
+		case "iota":
+			return constant.MakeInt64(iota), true
+		case "true", "false":
+			return constant.MakeBool(e.Name == "true"), true
+		}
+		value, ok := known[e.Name]
+		return value, ok
+	case *ast.ParenExpr:
+		return evalConstantExpr(e.X, iota, known)
+	case *ast.CallExpr:
+		// Conversions like MsgType(1) keep the value of their argument
+		if len(e.Args) == 1 {
+			if _, ok := e.Fun.(*ast.Ident); ok {
+				return evalConstantExpr(e.Args[0], iota, known)
+			}
+		}
+	case *ast.UnaryExpr:
+		x, ok := evalConstantExpr(e.X, iota, known)
+		if !ok {
+			return nil, false
+		}
+		switch e.Op {
+		case token.ADD, token.SUB, token.XOR, token.NOT:
+			return constant.UnaryOp(e.Op, x, 0), true
+		}
+	case *ast.BinaryExpr:
+		x, okX := evalConstantExpr(e.X, iota, known)
+		y, okY := evalConstantExpr(e.Y, iota, known)
+		if !okX || !okY {
+			return nil, false
+		}
+		switch e.Op {
+		case token.SHL, token.SHR:
+			shift, ok := constant.Uint64Val(y)
+			if !ok || x.Kind() != constant.Int {
+				return nil, false
+			}
+			return constant.Shift(x, e.Op, uint(shift)), true
+		case token.ADD, token.SUB, token.MUL, token.REM, token.AND, token.OR, token.XOR, token.AND_NOT:
+			if x.Kind() != constant.Int || y.Kind() != constant.Int {
+				return nil, false
+			}
+			return constant.BinaryOp(x, e.Op, y), true
+		case token.QUO:
+			if x.Kind() != constant.Int || y.Kind() != constant.Int || constant.Sign(y) == 0 {
+				return nil, false
+			}
+			return constant.BinaryOp(x, token.QUO_ASSIGN, y), true
+		}
+	}
+	return nil, false
+}
+
+// inferConstantType returns the type an untyped-looking constant expression takes from
+// conversions (MsgType(1)) or other typed constants (Latest = MsgWrite)
+func inferConstantType(expr ast.Expr, constants map[string]ConstantInfo) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return constants[e.Name].Type
+	case *ast.ParenExpr:
+		return inferConstantType(e.X, constants)
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && len(e.Args) == 1 && types.Universe.Lookup(ident.Name) == nil {
+			return ident.Name
+		}
+	case *ast.UnaryExpr:
+		return inferConstantType(e.X, constants)
+	case *ast.BinaryExpr:
+		if typeName := inferConstantType(e.X, constants); typeName != "" {
+			return typeName
+		}
+		return inferConstantType(e.Y, constants)
+	}
+	return ""
+}
+
+// constantValueKey normalizes a collected constant value so equal values compare equal (e.g. 0x10 and 16)
+func constantValueKey(value string) string {
+	if value == "true" || value == "false" {
+		return value
+	}
+	literal := strings.TrimLeft(value, "+-")
+	normalized := constant.MakeFromLiteral(literal, token.INT, 0)
+	if normalized.Kind() == constant.Unknown {
+		return value
+	}
+	if strings.HasPrefix(value, "-") {
+		normalized = constant.UnaryOp(token.SUB, normalized, 0)
+	}
+	return normalized.ExactString()
+}
+
+// constantLiteralValue returns the source value of a literal constant expression
+// Handles basic literals, signed literals (e.g. -1) and the true/false identifiers
+func constantLiteralValue(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return e.Value, true
+	case *ast.UnaryExpr:
+		if basicLit, ok := e.X.(*ast.BasicLit); ok && (e.Op == token.SUB || e.Op == token.ADD) {
+			return e.Op.String() + basicLit.Value, true
+		}
+	case *ast.ParenExpr:
+		return constantLiteralValue(e.X)
+	case *ast.Ident:
+		if e.Name == "true" || e.Name == "false" {
+			return e.Name, true
+		}
+	}
+	return "", false
+}
+
 // collectTypedConstants collects constants that are of a specific type
 func collectTypedConstants(file *ast.File, typeName string) map[string]string {
 	constants := make(map[string]string)
@@ -341,8 +631,8 @@ func collectTypedConstants(file *ast.File, typeName string) map[string]string {
 						if ident, ok := valueSpec.Type.(*ast.Ident); ok && ident.Name == typeName {
 							for i, name := range valueSpec.Names {
 								if i < len(valueSpec.Values) {
-									if basicLit, ok := valueSpec.Values[i].(*ast.BasicLit); ok {
-										constants[name.Name] = basicLit.Value
+									if value, ok := constantLiteralValue(valueSpec.Values[i]); ok {
+										constants[name.Name] = value
 									}
 								}
 							}
@@ -386,6 +676,11 @@ func extractReceiverType(expr ast.Expr) string {
 		return "*" + extractReceiverType(t.X)
 	case *ast.Ident:
 		return t.Name
+	case *ast.IndexExpr:
+		// Generic receiver: *Page[T] is a method of Page
+		return extractReceiverType(t.X)
+	case *ast.IndexListExpr:
+		return extractReceiverType(t.X)
 	default:
 		return ""
 	}
@@ -442,6 +737,20 @@ func formatType(expr ast.Expr) string {
 			return "[]" + formatType(t.Elt)
 		}
 		return "[" + formatType(t.Len) + "]" + formatType(t.Elt)
+	case *ast.MapType:
+		value := formatType(t.Value)
+		if st, ok := t.Value.(*ast.StructType); ok && len(st.Fields.List) == 0 {
+			value = "struct{}"
+		}
+		return "map[" + formatType(t.Key) + "]" + value
+	case *ast.IndexExpr:
+		return formatType(t.X) + "[" + formatType(t.Index) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, len(t.Indices))
+		for i, index := range t.Indices {
+			args[i] = formatType(index)
+		}
+		return formatType(t.X) + "[" + strings.Join(args, ", ") + "]"
 	case *ast.InterfaceType:
 		return "any"
 	case *ast.BasicLit:
@@ -584,6 +893,10 @@ func resolveAliasTypeWithFile(goType string, typeAliases map[string]string, file
 		break
 	}
 
+	// uint8 is byte, so []uint8 and [N]uint8 are opaque data like []byte and [N]byte
+	if current == "uint8" || strings.HasSuffix(current, "]uint8") {
+		return strings.TrimSuffix(current, "uint8") + "byte"
+	}
 	return current
 }
 
@@ -651,6 +964,27 @@ func getPackageNameFromImport(importPath, currentFilename string) string {
 	return ""
 }
 
+// findImportPath returns the import path a package qualifier refers to in a file, or "" if not imported
+func findImportPath(packageName string, file *ast.File, filename string) string {
+	for _, imp := range file.Imports {
+		// Handle different import styles
+		if imp.Name != nil {
+			// Named import: import foo "package/path"
+			if imp.Name.Name == packageName {
+				return strings.Trim(imp.Path.Value, `"`)
+			}
+		} else {
+			// Regular import: import "package/path"
+			path := strings.Trim(imp.Path.Value, `"`)
+			// Check if this import's actual package name matches what we're looking for
+			if actualPackageName := getPackageNameFromImport(path, filename); actualPackageName == packageName {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
 // resolveCrossPackageType attempts to resolve a cross-package type to its underlying type
 // Returns the resolved type and nil error if successful, or error if it can't be resolved
 func resolveCrossPackageType(pkgType string, file *ast.File, filename string) (string, error) {
@@ -668,26 +1002,7 @@ func resolveCrossPackageType(pkgType string, file *ast.File, filename string) (s
 	typeName := parts[1]
 
 	// Look for import of this package
-	var importPath string
-	for _, imp := range file.Imports {
-		// Handle different import styles
-		if imp.Name != nil {
-			// Named import: import foo "package/path"
-			if imp.Name.Name == packageName {
-				importPath = strings.Trim(imp.Path.Value, `"`)
-				break
-			}
-		} else {
-			// Regular import: import "package/path"
-			path := strings.Trim(imp.Path.Value, `"`)
-			// Check if this import's actual package name matches what we're looking for
-			if actualPackageName := getPackageNameFromImport(path, filename); actualPackageName == packageName {
-				importPath = path
-				break
-			}
-		}
-	}
-
+	importPath := findImportPath(packageName, file, filename)
 	if importPath == "" {
 		return "", fmt.Errorf("import path not found for package: %s", packageName)
 	}
@@ -852,7 +1167,8 @@ func shouldWarnForCrossPackageType(goType string, file *ast.File, filename strin
 	}
 
 	// If we resolved it to another cross-package type, that indicates multi-depth
-	if strings.Contains(resolved, ".") {
+	// (a struct declaration formats as *ast.StructType and needs no warning)
+	if strings.Contains(resolved, ".") && resolved != "*ast.StructType" {
 		return true
 	}
 
@@ -917,6 +1233,10 @@ func autoDiscoverXDRTypeWithFile(goType string, typeAliases map[string]string, f
 		return "int32"
 	case "int64":
 		return "int64"
+	case "byte":
+		return "uint8"
+	case "uint16", "int8", "int16", "int", "uint":
+		return resolvedType
 	case "string":
 		return "string"
 	case "[]byte":
@@ -924,6 +1244,10 @@ func autoDiscoverXDRTypeWithFile(goType string, typeAliases map[string]string, f
 	case "bool":
 		return "bool"
 	default:
+		if strings.HasPrefix(resolvedType, "map[") {
+			return "map"
+		}
+
 		// Handle array types - return element type for array encoding
 		if strings.HasPrefix(resolvedType, "[]") {
 			elementType := strings.TrimPrefix(resolvedType, "[]")
@@ -972,6 +1296,9 @@ func parseXDRDirective(comment string) (directive string, args map[string]string
 				key := strings.TrimSpace(kv[0])
 				value := strings.TrimSpace(kv[1])
 				args[key] = value
+			} else if flag := strings.TrimSpace(part); flag != "" {
+				// Bare options like exhaustive are flags
+				args[flag] = ""
 			}
 		}
 	} else {
@@ -996,18 +1323,46 @@ type XDRDirectives struct {
 
 // UnionDirective represents +xdr:union directive
 type UnionDirective struct {
-	Key     string // key field name
-	Default string // default case (optional)
+	Key        string   // key field name
+	Default    string   // default case (optional)
+	Unknown    string   // unknown discriminant policy (optional)
+	Exhaustive bool     // every key constant must have a payload, arm or void declaration (optional)
+	Mux        bool     // generate a typed xdr.Mux for the union's messages (optional)
+	Void       []string // explicitly declared void discriminants (optional, nil = inferred)
 }
 
 // PayloadDirective represents +xdr:payload directive
 type PayloadDirective struct {
 	Union        string // union type name
+	Field        string // union field name for unions embedded in ordinary structs (optional)
+	Discriminant string // discriminant value
+}
+
+// ArmDirective represents +xdr:arm directive
+type ArmDirective struct {
+	Union        string // union type name
+	Field        string // union field name for unions embedded in ordinary structs (optional)
 	Discriminant string // discriminant value
+	Type         string // Go type of the arm
+}
+
+// ConverterDirective represents +xdr:converter directive
+type ConverterDirective struct {
+	Type   string // Go type as written in the file, e.g., "netip.Addr"
+	Encode string // func(enc *xdr.Encoder, v T) error
+	Decode string // func(dec *xdr.Decoder) (T, error)
+}
+
+// unionName returns the union identity the arm belongs to
+func (a *ArmDirective) unionName() string {
+	if a.Field != "" {
+		return fieldUnionName(a.Union, a.Field)
+	}
+	return a.Union
 }
 
 // parseUnionDirective parses +xdr:union directive
-// Format: key=FieldName,default=DefaultValue
+// Format: key=FieldName,default=DefaultValue,unknown=Policy[,void=A|B][,exhaustive][,mux]
 func parseUnionDirective(args map[string]string) *UnionDirective {
 	directive := &UnionDirective{}
 
@@ -1017,25 +1372,170 @@ func parseUnionDirective(args map[string]string) *UnionDirective {
 	if defaultVal, ok := args["default"]; ok {
 		directive.Default = defaultVal
 	}
+	if unknown, ok := args["unknown"]; ok {
+		directive.Unknown = unknown
+	}
+	_, directive.Exhaustive = args["exhaustive"]
+	_, directive.Mux = args["mux"]
+	if void, ok := args["void"]; ok {
+		directive.Void = parseVoidList(void)
+	}
 
 	return directive
 }
 
+// parseVoidList parses a void=A|B list of discriminants
+// The result is non-nil even when empty so void= declares that no discriminant is void
+func parseVoidList(value string) []string {
+	voidCases := []string{}
+	for _, voidCase := range strings.Split(value, "|") {
+		if voidCase = strings.TrimSpace(voidCase); voidCase != "" {
+			voidCases = append(voidCases, voidCase)
+		}
+	}
+	return voidCases
+}
+
 // parsePayloadDirective parses +xdr:payload directive
-// Format: union=UnionType,discriminant=DiscriminantValue
+// Format: union=UnionType[,field=FieldName],discriminant=DiscriminantValue
 func parsePayloadDirective(args map[string]string) *PayloadDirective {
 	directive := &PayloadDirective{}
 
 	if union, ok := args["union"]; ok {
 		directive.Union = union
 	}
+	if field, ok := args["field"]; ok {
+		directive.Field = field
+	}
+	if discriminant, ok := args["discriminant"]; ok {
+		directive.Discriminant = discriminant
+	}
+
+	return directive
+}
+
+// parseArmDirective parses +xdr:arm directive
+// Format: union=UnionType[,field=FieldName],discriminant=DiscriminantValue,type=GoType
+func parseArmDirective(args map[string]string) *ArmDirective {
+	directive := &ArmDirective{}
+
+	if union, ok := args["union"]; ok {
+		directive.Union = union
+	}
+	if field, ok := args["field"]; ok {
+		directive.Field = field
+	}
 	if discriminant, ok := args["discriminant"]; ok {
 		directive.Discriminant = discriminant
 	}
+	if armType, ok := args["type"]; ok {
+		directive.Type = armType
+	}
 
 	return directive
 }
 
+// collectArmDirectives collects all // +xdr:arm directives in a file
+// Arm directives are free-standing and may appear anywhere in the file
+func collectArmDirectives(file *ast.File) []*ArmDirective {
+	var arms []*ArmDirective
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			directive, args, isXDR := parseXDRDirective(comment.Text)
+			if !isXDR || directive != "arm" {
+				continue
+			}
+			arm := parseArmDirective(args)
+			if arm.Union == "" || arm.Discriminant == "" || arm.Type == "" {
+				log.Fatalf("Invalid +xdr:arm directive %q: union, discriminant and type are required", comment.Text)
+			}
+			arms = append(arms, arm)
+		}
+	}
+	return arms
+}
+
+// collectConverterDirectives collects all // +xdr:converter directives in a file keyed by type
+// Converter directives are free-standing and apply to fields, array elements and arms in the same file
+func collectConverterDirectives(file *ast.File) map[string]*ConverterDirective {
+	converters := make(map[string]*ConverterDirective)
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			directive, args, isXDR := parseXDRDirective(comment.Text)
+			if !isXDR || directive != "converter" {
+				continue
+			}
+			converter := &ConverterDirective{Type: args["type"], Encode: args["encode"], Decode: args["decode"]}
+			if converter.Type == "" || converter.Encode == "" || converter.Decode == "" {
+				log.Fatalf("Invalid +xdr:converter directive %q: type, encode and decode are required", comment.Text)
+			}
+			if _, duplicate := converters[converter.Type]; duplicate {
+				log.Fatalf("Type %s has multiple +xdr:converter directives", converter.Type)
+			}
+			converters[converter.Type] = converter
+		}
+	}
+	return converters
+}
+
+// converterFor returns the converter for a type or for the elements of an array type
+func converterFor(goType string, converters map[string]*ConverterDirective) *ConverterDirective {
+	if converter, ok := converters[goType]; ok {
+		return converter
+	}
+	if strings.HasPrefix(goType, "[") {
+		if closeBracket := strings.Index(goType, "]"); closeBracket > 0 {
+			return converters[goType[closeBracket+1:]]
+		}
+	}
+	return nil
+}
+
+// newArmFieldInfo builds the field info for the Value field of a union arm wrapper
+// Resolution mirrors struct field auto-detection so arms support the same types as fields
+func newArmFieldInfo(armType string, typeAliases map[string]string, file *ast.File, filename string) FieldInfo {
+	fieldInfo := FieldInfo{
+		Name: "Value",
+		Type: armType,
+	}
+	if converter := converterFor(armType, collectConverterDirectives(file)); converter != nil {
+		fieldInfo.ResolvedType = armType
+		fieldInfo.XDRType = "converter"
+		fieldInfo.Converter = converter
+	} else if implementsCodecInterface(armType, file) {
+		fieldInfo.ResolvedType = armType
+		fieldInfo.XDRType = "struct"
+	} else {
+		fieldInfo.ResolvedType = resolveAliasTypeWithFile(armType, typeAliases, file, filename)
+		fieldInfo.XDRType = autoDiscoverXDRTypeWithFile(armType, typeAliases, file, filename)
+	}
+	return fieldInfo
+}
+
+// resolveMapFieldInfo fills in the key and value types of a map field, rejecting unsupported ones
+func resolveMapFieldInfo(fieldInfo *FieldInfo, typeName string, typeAliases map[string]string, file *ast.File, filename string) {
+	keyType, valueType := splitMapType(fieldInfo.ResolvedType)
+	fieldInfo.MapKeyType = keyType
+	fieldInfo.MapValueType = valueType
+	fieldInfo.MapKeyXDR = autoDiscoverXDRTypeWithFile(keyType, typeAliases, file, filename)
+	switch fieldInfo.MapKeyXDR {
+	case "string", "uint32", "uint64", "int32", "int64", "uint8", "uint16", "int8", "int16", "int", "uint":
+	default:
+		log.Fatalf("Map field %s.%s must have a string or integer key, got %s", typeName, fieldInfo.Name, fieldInfo.Type)
+	}
+	resolvedValue := resolveAliasTypeWithFile(valueType, typeAliases, file, filename)
+	switch {
+	case valueType == "struct{}":
+		fieldInfo.MapValueXDR = "set"
+	case strings.HasPrefix(resolvedValue, "map[") || (strings.HasPrefix(resolvedValue, "[") && resolvedValue != "[]byte") ||
+		(strings.HasPrefix(valueType, "*") && isBuiltinType(strings.TrimPrefix(valueType, "*"))):
+		log.Fatalf("Map field %s.%s has unsupported value type %s (use a primitive, a struct or struct{})", typeName, fieldInfo.Name, valueType)
+	default:
+		fieldInfo.MapValueXDR = autoDiscoverXDRTypeWithFile(valueType, typeAliases, file, filename)
+	}
+	debugf("Detected map field %s.%s (key %s, value %s)", typeName, fieldInfo.Name, fieldInfo.MapKeyXDR, fieldInfo.MapValueXDR)
+}
+
 // collectXDRDirectives collects all // +xdr: directives immediately before a struct
 func collectXDRDirectives(file *ast.File, structPos token.Pos) *XDRDirectives {
 	directives := &XDRDirectives{}
@@ -1149,6 +1649,14 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 
 	var types []TypeInfo
 
+	// Struct declarations visible to xdr:"inline" embedded fields
+	structDefs := make(map[string]ast.Node, len(packageTypeDefs)+len(typeDefs))
+	maps.Copy(structDefs, packageTypeDefs)
+	maps.Copy(structDefs, typeDefs)
+
+	// Third-party types bound to user encode/decode functions
+	converters := collectConverterDirectives(file)
+
 	// Build a map of type aliases for lookup
 	typeAliases := make(map[string]string)
 
@@ -1309,6 +1817,7 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 						typeInfo.IsPayload = true
 						typeInfo.PayloadConfig = &PayloadConfig{
 							UnionType:    xdrDirectives.Payload.Union,
+							UnionField:   xdrDirectives.Payload.Field,
 							Discriminant: xdrDirectives.Payload.Discriminant,
 						}
 					}
@@ -1331,9 +1840,61 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 						map[bool]string{true: "Encode", false: "Decode"}[hasEncode])
 				}
 
-				for _, field := range structType.Fields.List {
-					// Skip embedded fields for now
-					if len(field.Names) == 0 {
+				// Generic structs use type parameters T xdr.Codec, instantiated with pointer types and
+				// allocated with xdr.NewCodec, or pair T any with PT interface{ *T; xdr.Codec } and
+				// allocate with PT(new(T)) without reflection
+				typeParams := make(map[string]bool)
+				if node.TypeParams != nil {
+					if typeInfo.IsDiscriminatedUnion || xdrDirectives.Payload != nil {
+						log.Fatalf("Generic type %s cannot be a union container or payload", typeInfo.Name)
+					}
+					typeInfo.PointerParams = make(map[string]string)
+					typeInfo.CodecParams = make(map[string]bool)
+					for _, param := range node.TypeParams.List {
+						pointee, isPointer := codecPointerConstraint(param.Type)
+						isCodec := formatType(param.Type) == "xdr.Codec"
+						if !isPointer && !isCodec && formatType(param.Type) != "any" {
+							log.Fatalf("Type parameters of %s must be T xdr.Codec, T any or PT interface{ *T; xdr.Codec }, got %s", typeInfo.Name, formatType(param.Type))
+						}
+						for _, name := range param.Names {
+							typeParams[name.Name] = true
+							typeInfo.TypeParams = append(typeInfo.TypeParams, name.Name)
+							if isPointer {
+								typeInfo.PointerParams[name.Name] = pointee
+							}
+							if isCodec {
+								typeInfo.CodecParams[name.Name] = true
+							}
+						}
+					}
+					for pointer, pointee := range typeInfo.PointerParams {
+						if !typeParams[pointee] || typeInfo.PointerParams[pointee] != "" {
+							log.Fatalf("Type parameter %s of %s must point to a type parameter constrained to any, got *%s", pointer, typeInfo.Name, pointee)
+						}
+					}
+					debugf("Struct %s is generic over %v", typeInfo.Name, typeInfo.TypeParams)
+				}
+
+				fields := expandEmbeddedFields(typeInfo.Name, structType.Fields.List, structDefs, file, filename, map[string]bool{})
+				declared := make(map[string]bool)
+				for _, field := range fields {
+					for _, name := range field.Names {
+						if declared[name.Name] && name.Name != "_" {
+							log.Fatalf("Field %s.%s is declared more than once after inlining embedded structs", typeInfo.Name, name.Name)
+						}
+						declared[name.Name] = true
+					}
+				}
+
+				for _, field := range fields {
+					// Inline embedded pointers are allocated before their fields are decoded
+					if field.InlinePointer {
+						typeInfo.Fields = append(typeInfo.Fields, FieldInfo{
+							Name:         field.Names[0].Name,
+							Type:         formatType(field.Type),
+							ResolvedType: formatType(field.Type),
+							XDRType:      "embedded",
+						})
 						continue
 					}
 
@@ -1344,9 +1905,36 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 							Type: fieldType,
 						}
 
+						// Codec type parameters are used as T, []T or [N]T and pointer type parameters as PT,
+						// []PT or [N]PT, so every element can be allocated before it is decoded
+						if len(typeParams) > 0 {
+							elemType := fieldType
+							if strings.HasPrefix(elemType, "[") {
+								elemType = elemType[strings.Index(elemType, "]")+1:]
+							}
+							if pointee := typeInfo.PointerParams[elemType]; pointee != "" {
+								fieldInfo.TypeParam = pointee
+							} else if typeInfo.CodecParams[elemType] {
+								fieldInfo.CodecParam = true
+							} else if typeParams[strings.TrimPrefix(elemType, "*")] || mapUsesTypeParam(fieldType, typeParams) {
+								log.Fatalf("Field %s.%s has type %s; type parameters may only be used as T, []T or [N]T for T xdr.Codec, or PT, []PT or [N]PT for PT interface{ *T; xdr.Codec }", typeInfo.Name, fieldInfo.Name, fieldType)
+							}
+						}
+
+						// Converter, time.Time and time.Duration types are mapped directly and never resolved across packages
+						converter := converterFor(fieldType, converters)
+						var timeKind string
+						if converter == nil {
+							timeKind = timeFieldKind(fieldType, typeAliases, file, filename)
+						}
+						// Arrays of time values apply the layout to every element
+						if converter == nil && timeKind == "" && strings.HasPrefix(fieldType, "[") {
+							timeKind = timeFieldKind(arrayElementType(fieldType), typeAliases, file, filename)
+						}
+
 						// Only resolve ResolvedType for non-Codec types
 						// If a type implements xdr.Codec, keep ResolvedType as the original type
-						if implementsCodecInterface(fieldType, file) {
+						if converter != nil || timeKind != "" || implementsCodecInterface(fieldType, file) {
 							fieldInfo.ResolvedType = fieldType // Keep original type name
 						} else {
 							fieldInfo.ResolvedType = resolveAliasTypeWithFile(fieldType, typeAliases, file, filename)
@@ -1365,14 +1953,9 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 							continue // Skip this field
 						}
 
-						// Handle XDR tags in minimal mode (only xdr:"-" is supported)
-						if xdrTag != "" {
-							// Check for skip tag first
-							if xdrTag == "-" {
-								continue // Skip this field
-							}
-							// All other struct tags are ignored - we use directives and auto-detection
-						}
+						// Layout tags (union, list, max, time, const, reserved, opaque and checksums) are handled
+						// below and xdr:"inline" when flattening embedded structs; the rest is auto-detected
+						xdrTagOptions := parseXDRTagOptions(xdrTag)
 
 						// Check if this field is the key field specified in the directive
 						if xdrDirectives.Union != nil && xdrDirectives.Union.Key == fieldInfo.Name {
@@ -1385,10 +1968,29 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 							log.Fatalf("Error: Field %s.%s has type '%s' which contains any/interface{} that is not supported in XDR encoding.\nXDR requires statically typed data structures. Consider using a concrete type instead.", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
 						}
 
+						// Interfaces are only supported as plain fields; their registry has no slice or pointer form
+						if elemType := strings.TrimLeft(fieldInfo.Type, "[]*0123456789"); elemType != fieldInfo.Type && lookupInterface(elemType, structDefs) != nil {
+							log.Fatalf("Field %s.%s has type %s; interface types are only supported as plain fields", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
+						}
+
 						// Auto-discover XDR type from Go type
 						var autoType string
 						// Priority 1: Check if type implements xdr.Codec interface
-						if implementsCodecInterface(fieldInfo.Type, file) {
+						if converter != nil {
+							fieldInfo.XDRType = "converter"
+							fieldInfo.Converter = converter
+							autoType = "converter"
+						} else if timeKind != "" {
+							autoType = timeKind
+						} else if iface := lookupInterface(fieldInfo.Type, structDefs); iface != nil {
+							// Interface fields hold registered Codec types, encoded as {type id, opaque body}
+							if !embedsCodec(iface) {
+								log.Fatalf("Field %s.%s has interface type %s, which must embed xdr.Codec", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
+							}
+							fieldInfo.XDRType = "interface"
+							fieldInfo.Registry = registryName(fieldInfo.Type)
+							autoType = "interface"
+						} else if implementsCodecInterface(fieldInfo.Type, file) {
 							fieldInfo.XDRType = "struct" // Use interface methods
 							autoType = "struct"
 							debugf("Type %s implements xdr.Codec interface, using struct encoding", fieldInfo.Type)
@@ -1408,6 +2010,24 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 						}
 						debugf("Auto-discovered XDR type for %s.%s: %s -> %s", typeInfo.Name, fieldInfo.Name, fieldInfo.Type, autoType)
 
+						// time.Time and time.Duration use a well-known layout selected with xdr:"time=layout"
+						layout, hasLayout := xdrTagOptions["time"]
+						if kind := timeKind; kind != "" {
+							if !hasLayout {
+								layout = TimeLayoutTimespec
+								if kind == "duration" {
+									layout = TimeLayoutUnixNano
+								}
+							}
+							if _, ok := timeLayoutMethods[layout]; !ok {
+								log.Fatalf("Field %s.%s has invalid time layout %q (must be %s, %s or %s)", typeInfo.Name, fieldInfo.Name, layout, TimeLayoutNFS, TimeLayoutTimespec, TimeLayoutUnixNano)
+							}
+							fieldInfo.XDRType = kind + ":" + layout
+							debugf("Detected %s field %s.%s with layout %s", kind, typeInfo.Name, fieldInfo.Name, layout)
+						} else if hasLayout {
+							log.Fatalf("Field %s.%s has a time layout but type %s is not time.Time or time.Duration", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
+						}
+
 						// Auto-detect union field: []byte immediately following a key field
 						if len(typeInfo.Fields) > 0 && typeInfo.Fields[len(typeInfo.Fields)-1].IsKey && fieldInfo.Type == "[]byte" {
 							fieldInfo.IsUnion = true
@@ -1416,12 +2036,146 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 							debugf("Auto-detected union field %s.%s ([]byte following key field)", typeInfo.Name, fieldInfo.Name)
 						}
 
+						// Union embedded in an ordinary struct: []byte field tagged xdr:"union=KeyField"
+						if unionKey, ok := xdrTagOptions["union"]; ok {
+							if fieldInfo.Type != "[]byte" {
+								log.Fatalf("Union field %s.%s must be []byte, got %s", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
+							}
+							var keyFieldType string
+							for _, prev := range typeInfo.Fields {
+								if prev.Name == unionKey {
+									keyFieldType = prev.Type
+								}
+							}
+							if keyFieldType == "" {
+								log.Fatalf("Union field %s.%s references key field %s which must be declared before it", typeInfo.Name, fieldInfo.Name, unionKey)
+							}
+							unknown := xdrTagOptions["unknown"]
+							if unknown != "" && !isValidUnknownPolicy(unknown) {
+								log.Fatalf("Union field %s.%s has invalid unknown policy %q (must be %s, %s or %s)", typeInfo.Name, fieldInfo.Name, unknown, UnknownPolicyVoid, UnknownPolicyReject, UnknownPolicyCapture)
+							}
+							_, exhaustive := xdrTagOptions["exhaustive"]
+							var declaredVoid []string
+							if void, ok := xdrTagOptions["void"]; ok {
+								declaredVoid = parseVoidList(void)
+							}
+							var declaredArms []string
+							if arms, ok := xdrTagOptions["arms"]; ok {
+								declaredArms = parseVoidList(arms)
+							}
+							fieldInfo.IsUnion = true
+							fieldInfo.UnionKey = unionKey
+							fieldInfo.XDRType = "bytes"
+							fieldInfo.DefaultType = xdrTagOptions["default"]
+							fieldInfo.UnionConfig = &UnionConfig{
+								ContainerType:    fieldUnionName(typeInfo.Name, fieldInfo.Name),
+								DiscriminantType: keyFieldType,
+								DefaultCase:      xdrTagOptions["default"],
+								UnknownPolicy:    unknown,
+								Exhaustive:       exhaustive,
+								DeclaredVoid:     declaredVoid,
+								DeclaredArms:     declaredArms,
+								Cases:            make(map[string]string),
+								VoidCases:        []string{},
+							}
+							debugf("Detected embedded union field %s.%s (key %s)", typeInfo.Name, fieldInfo.Name, unionKey)
+						}
+
+						// Linked list: self-referential next pointer, list head pointer or slice of nodes
+						if _, ok := xdrTagOptions["list"]; ok {
+							elemType := strings.TrimPrefix(strings.TrimPrefix(fieldInfo.Type, "[]"), "*")
+							if !strings.HasPrefix(fieldInfo.Type, "*") && !strings.HasPrefix(fieldInfo.Type, "[]") {
+								log.Fatalf("List field %s.%s must be a pointer or slice, got %s", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
+							}
+							underlying, known := typeAliases[elemType]
+							if isBuiltinType(elemType) || strings.HasPrefix(elemType, "[") || (known && underlying != "*ast.StructType") {
+								log.Fatalf("List field %s.%s must hold struct nodes, got %s", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
+							}
+							fieldInfo.IsList = true
+							fieldInfo.XDRType = "struct"
+							debugf("Detected list field %s.%s", typeInfo.Name, fieldInfo.Name)
+						}
+
+						// Length bounds per array dimension, outermost first: xdr:"max=16|4", empty for unbounded
+						if bounds, ok := xdrTagOptions["max"]; ok {
+							if !strings.HasPrefix(fieldInfo.ResolvedType, "[") || fieldInfo.XDRType == "bytes" || fieldInfo.IsList {
+								log.Fatalf("Field %s.%s has max bounds but %s is not an array", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
+							}
+							for _, bound := range strings.Split(bounds, "|") {
+								bound = strings.TrimSpace(bound)
+								if n, err := strconv.ParseUint(bound, 10, 32); bound != "" && (err != nil || n == 0) {
+									log.Fatalf("Field %s.%s has invalid max bound %q (must be a positive integer or empty)", typeInfo.Name, fieldInfo.Name, bound)
+								}
+								fieldInfo.MaxLengths = append(fieldInfo.MaxLengths, bound)
+							}
+						}
+
+						// Constant fields such as magic numbers are written from the tag and verified on decode
+						if value, ok := xdrTagOptions["const"]; ok {
+							if !validConstValue(value, fieldInfo.XDRType) {
+								log.Fatalf("Field %s.%s has invalid const %q for type %s (must be an integer literal that fits the field)", typeInfo.Name, fieldInfo.Name, value, fieldInfo.Type)
+							}
+							fieldInfo.Const = value
+						}
+
+						// Reserved areas are written as zeros and skipped, or checked with strict, on decode
+						if size, ok := xdrTagOptions["reserved"]; ok {
+							n, err := strconv.Atoi(size)
+							if err != nil || n <= 0 {
+								log.Fatalf("Field %s.%s has invalid reserved size %q (must be a positive number of bytes)", typeInfo.Name, fieldInfo.Name, size)
+							}
+							if fieldInfo.Const != "" {
+								log.Fatalf("Field %s.%s cannot be both const and reserved", typeInfo.Name, fieldInfo.Name)
+							}
+							fieldInfo.XDRType = "reserved"
+							fieldInfo.Reserved = n
+							_, fieldInfo.StrictZero = xdrTagOptions["strict"]
+						}
+
+						// Opaque-encapsulated structs are length-prefixed so readers can skip them
+						if _, ok := xdrTagOptions["opaque"]; ok {
+							if fieldInfo.XDRType != "struct" || fieldInfo.IsList || fieldInfo.TypeParam != "" || fieldInfo.CodecParam || strings.HasPrefix(fieldInfo.Type, "[") {
+								log.Fatalf("Opaque field %s.%s must be a struct or pointer to struct, got %s", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
+							}
+							fieldInfo.Opaque = true
+						}
+
+						// Checksum and length fields are computed over other fields: xdr:"crc32=Body" or xdr:"len=Header|Body"
+						for algorithm := range checksumFuncs {
+							covers, ok := xdrTagOptions[algorithm]
+							if !ok {
+								continue
+							}
+							if fieldInfo.Checksum != "" || fieldInfo.Const != "" || fieldInfo.Reserved > 0 {
+								log.Fatalf("Field %s.%s can only be one of const, reserved, a checksum or a length", typeInfo.Name, fieldInfo.Name)
+							}
+							if fieldInfo.XDRType != "uint32" || fieldInfo.IsKey {
+								log.Fatalf("Checksum field %s.%s must be a uint32, got %s", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
+							}
+							fieldInfo.Checksum = algorithm
+							fieldInfo.Covers = parseVoidList(covers)
+							if len(fieldInfo.Covers) == 0 {
+								log.Fatalf("Checksum field %s.%s must name the fields it covers (%s=Field or %s=A|B)", typeInfo.Name, fieldInfo.Name, algorithm, algorithm)
+							}
+						}
+
+						// Blank fields have no value to encode, only a constant or reserved area
+						if fieldInfo.Name == "_" && fieldInfo.Const == "" && fieldInfo.Reserved == 0 {
+							log.Fatalf("Blank field in %s must be tagged xdr:\"const=V\" or xdr:\"reserved=N\"", typeInfo.Name)
+						}
+
+						// Maps are a counted array of key/value pairs; map[K]struct{} is a set of keys
+						if fieldInfo.XDRType == "map" {
+							resolveMapFieldInfo(&fieldInfo, typeInfo.Name, typeAliases, file, filename)
+						}
+
 						// Mark struct as discriminated union if it contains key field
 						if fieldInfo.IsKey {
 							typeInfo.IsDiscriminatedUnion = true
-							// Key fields must be uint32 type or an alias of uint32
-							underlyingType, ok := typeAliases[fieldInfo.Type]
-							if fieldInfo.Type != "uint32" && (!ok || underlyingType != "uint32") {
+							// Key fields must be uint32, int32 or bool, or an alias of one
+							keyXDRType := resolveDiscriminantXDRType(fieldInfo.Type, typeAliases)
+							if keyXDRType == "" {
+								underlyingType, ok := typeAliases[fieldInfo.Type]
 								if !ok {
 									if !forCollection {
 										log.Fatalf("Key field %s.%s references type %s which is not defined in this file. For cross-file dependencies, process the entire package directory instead of individual files", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
@@ -1429,16 +2183,17 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 									// During package collection, skip validation for types that might be defined in other files
 									debugf("Skipping validation for key field %s.%s (type %s) during package collection", typeInfo.Name, fieldInfo.Name, fieldInfo.Type)
 								} else if !forCollection {
-									log.Fatalf("Key field %s.%s must be uint32 or an alias of uint32, got %s (resolves to %s)", typeInfo.Name, fieldInfo.Name, fieldInfo.Type, underlyingType)
+									log.Fatalf("Key field %s.%s must be uint32, int32, bool or an alias of one, got %s (resolves to %s)", typeInfo.Name, fieldInfo.Name, fieldInfo.Type, underlyingType)
 								}
+								keyXDRType = "uint32"
 							}
-							// Key fields should be treated as uint32 for encoding/decoding
-							fieldInfo.XDRType = "uint32"
+							// Key fields are encoded as their discriminant XDR type
+							fieldInfo.XDRType = keyXDRType
 						}
 
 						// Validate that we can handle this XDR type (after key/union processing)
 						if !isSupportedXDRType(fieldInfo.XDRType) {
-							log.Fatalf("Unsupported XDR type '%s' for field %s.%s. Supported types: uint32, uint64, int32, int64, string, bytes, bool, struct, key, union. Arrays are auto-detected from []Type and [N]Type syntax.",
+							log.Fatalf("Unsupported XDR type '%s' for field %s.%s. Supported types: uint32, uint64, int32, int64, narrower integers, int, uint, string, bytes, bool, struct, key, union. Arrays are auto-detected from []Type and [N]Type syntax.",
 								fieldInfo.XDRType, typeInfo.Name, fieldInfo.Name)
 						}
 
@@ -1446,6 +2201,40 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 					}
 				}
 
+				// The next pointer of a list node is encoded after the node's other fields,
+				// so it must be the last field for the chain to be walked iteratively
+				for i, field := range typeInfo.Fields {
+					if field.isListLink(typeInfo.Name) && i != len(typeInfo.Fields)-1 {
+						log.Fatalf("List field %s.%s must be the last field of %s", typeInfo.Name, field.Name, typeInfo.Name)
+					}
+				}
+
+				// Checksums cover other fields of the struct, ordered as they are encoded
+				for i, field := range typeInfo.Fields {
+					if field.Checksum == "" {
+						continue
+					}
+					covered := make(map[string]bool)
+					for _, name := range field.Covers {
+						covered[name] = true
+					}
+					var covers []string
+					for j, other := range typeInfo.Fields {
+						if !covered[other.Name] {
+							continue
+						}
+						if j == i || other.Name == "_" || other.isListLink(typeInfo.Name) {
+							log.Fatalf("Checksum field %s.%s cannot cover %s", typeInfo.Name, field.Name, other.Name)
+						}
+						delete(covered, other.Name)
+						covers = append(covers, other.Name)
+					}
+					for name := range covered {
+						log.Fatalf("Checksum field %s.%s covers unknown field %s", typeInfo.Name, field.Name, name)
+					}
+					typeInfo.Fields[i].Covers = covers
+				}
+
 				// Associate union comments with this struct
 				if typeInfo.IsDiscriminatedUnion {
 					// Container struct - look for union config by container type name
@@ -1454,6 +2243,19 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 						if debug {
 							debugf("Associated union config with container struct %s", typeInfo.Name)
 						}
+					} else if xdrDirectives.Union != nil {
+						// Directive-based container - cases are filled in from payload directives at package level
+						typeInfo.UnionConfig = &UnionConfig{
+							ContainerType:    typeInfo.Name,
+							DiscriminantType: findKeyFieldType(typeInfo),
+							DefaultCase:      xdrDirectives.Union.Default,
+							UnknownPolicy:    xdrDirectives.Union.Unknown,
+							Exhaustive:       xdrDirectives.Union.Exhaustive,
+							Mux:              xdrDirectives.Union.Mux,
+							DeclaredVoid:     xdrDirectives.Union.Void,
+							Cases:            make(map[string]string),
+							VoidCases:        []string{},
+						}
 					}
 				} else {
 					// Check if this struct has a union comment (payload struct)
@@ -1474,6 +2276,9 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 				if len(typeInfo.Fields) > 0 {
 					types = append(types, typeInfo)
 				}
+			} else if typeInfo, ok := parseNamedType(node, file, filename, typeAliases); ok {
+				// Named slice, array, map and primitive-based types marked +xdr:generate
+				types = append(types, typeInfo)
 			}
 		}
 		return true
@@ -1519,6 +2324,7 @@ func parseFileInternalWithPackageAliases(filename string, packageTypeDefs map[st
 					}
 				}
 			}
+			sort.Strings(typeInfo.UnionConfig.VoidCases)
 		}
 	}
 
@@ -1554,7 +2360,7 @@ func getUnionFieldNames(unionTypeName string, typeDefs map[string]ast.Node) (key
 		}
 
 		for _, spec := range n.Specs {
-			if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == unionTypeName {
+			if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == unqualifiedName(unionTypeName) {
 				if st, ok := typeSpec.Type.(*ast.StructType); ok {
 					structType = st
 					break
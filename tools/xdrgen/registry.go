@@ -0,0 +1,174 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// RegisterDirective represents a +xdr:register directive adding a Codec type to the
+// registry of an interface
+type RegisterDirective struct {
+	Interface string // interface type whose fields may hold the type, e.g., "Plugin"
+	Type      string // registered type, e.g., "GzipPlugin"
+	ID        string // type ID: an integer literal or a constant
+}
+
+// RegistryConfig is the generated registry of an interface and the types registered with it
+type RegistryConfig struct {
+	Interface string
+	Registry  string
+	Types     []*RegisterDirective
+}
+
+// registryName returns the name of the generated registry variable of an interface
+func registryName(iface string) string {
+	return iface + "Registry"
+}
+
+// EnvelopeDirective represents a +xdr:envelope directive registering a message type
+// for xdr.MarshalAny and xdr.UnmarshalAny
+type EnvelopeDirective struct {
+	Type    string // registered type, e.g., "AccountCreated"
+	ID      string // type ID: an integer literal or a constant
+	Version string // schema version: an integer literal or a constant, 1 when not given
+}
+
+// IDExpr returns the type ID as a uint32 expression
+func (r *RegisterDirective) IDExpr() string {
+	return uint32Expr(r.ID)
+}
+
+// IDExpr returns the type ID as a uint32 expression
+func (e *EnvelopeDirective) IDExpr() string {
+	return uint32Expr(e.ID)
+}
+
+// VersionExpr returns the schema version as a uint32 expression
+func (e *EnvelopeDirective) VersionExpr() string {
+	return uint32Expr(e.Version)
+}
+
+// uint32Expr returns an integer literal as is and converts a constant to uint32
+func uint32Expr(value string) string {
+	if isUint32Literal(value) {
+		return value
+	}
+	return "uint32(" + value + ")"
+}
+
+// isUint32Literal reports whether value is an integer literal that fits a uint32
+func isUint32Literal(value string) bool {
+	_, err := strconv.ParseUint(value, 0, 32)
+	return err == nil
+}
+
+// collectRegisterDirectives collects all // +xdr:register directives in a file
+// Register directives are free-standing; all registrations of an interface must share a file
+func collectRegisterDirectives(file *ast.File) []*RegisterDirective {
+	var registrations []*RegisterDirective
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			directive, args, isXDR := parseXDRDirective(comment.Text)
+			if !isXDR || directive != "register" {
+				continue
+			}
+			registration := &RegisterDirective{Interface: args["interface"], Type: args["type"], ID: args["id"]}
+			if registration.Interface == "" || registration.Type == "" || registration.ID == "" {
+				log.Fatalf("Invalid +xdr:register directive %q: interface, type and id are required", comment.Text)
+			}
+			if strings.Contains(registration.Interface, ".") || strings.Contains(registration.Type, ".") {
+				log.Fatalf("Invalid +xdr:register directive %q: interface and type must be declared in this package", comment.Text)
+			}
+			if !isUint32Literal(registration.ID) && !token.IsIdentifier(registration.ID) {
+				log.Fatalf("Invalid +xdr:register directive %q: id must be a uint32 literal or a constant", comment.Text)
+			}
+			registrations = append(registrations, registration)
+		}
+	}
+	return registrations
+}
+
+// collectEnvelopeDirectives collects all // +xdr:envelope directives in a file
+func collectEnvelopeDirectives(file *ast.File) []*EnvelopeDirective {
+	var envelopes []*EnvelopeDirective
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			directive, args, isXDR := parseXDRDirective(comment.Text)
+			if !isXDR || directive != "envelope" {
+				continue
+			}
+			envelope := &EnvelopeDirective{Type: args["type"], ID: args["id"], Version: args["version"]}
+			if envelope.Version == "" {
+				envelope.Version = "1"
+			}
+			if envelope.Type == "" || envelope.ID == "" {
+				log.Fatalf("Invalid +xdr:envelope directive %q: type and id are required", comment.Text)
+			}
+			if strings.Contains(envelope.Type, ".") {
+				log.Fatalf("Invalid +xdr:envelope directive %q: type must be declared in this package", comment.Text)
+			}
+			for _, value := range []string{envelope.ID, envelope.Version} {
+				if !isUint32Literal(value) && !token.IsIdentifier(value) {
+					log.Fatalf("Invalid +xdr:envelope directive %q: id and version must be uint32 literals or constants", comment.Text)
+				}
+			}
+			envelopes = append(envelopes, envelope)
+		}
+	}
+	return envelopes
+}
+
+// groupRegistrations returns the registry of each interface in order of first registration
+func groupRegistrations(registrations []*RegisterDirective) []RegistryConfig {
+	var registries []RegistryConfig
+	index := make(map[string]int)
+	for _, registration := range registrations {
+		i, ok := index[registration.Interface]
+		if !ok {
+			i = len(registries)
+			index[registration.Interface] = i
+			registries = append(registries, RegistryConfig{
+				Interface: registration.Interface,
+				Registry:  registryName(registration.Interface),
+			})
+		}
+		registries[i].Types = append(registries[i].Types, registration)
+	}
+	return registries
+}
+
+// lookupInterface returns the declaration of an interface type in the package, or nil
+func lookupInterface(typeName string, typeDefs map[string]ast.Node) *ast.InterfaceType {
+	genDecl, ok := typeDefs[typeName].(*ast.GenDecl)
+	if !ok {
+		return nil
+	}
+	for _, spec := range genDecl.Specs {
+		if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == typeName {
+			iface, _ := typeSpec.Type.(*ast.InterfaceType)
+			return iface
+		}
+	}
+	return nil
+}
+
+// embedsCodec reports whether an interface embeds xdr.Codec or declares Encode and Decode
+func embedsCodec(iface *ast.InterfaceType) bool {
+	hasEncode, hasDecode := false, false
+	for _, method := range iface.Methods.List {
+		if len(method.Names) == 0 {
+			if sel, ok := method.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Codec" {
+				return true
+			}
+			continue
+		}
+		for _, name := range method.Names {
+			hasEncode = hasEncode || name.Name == "Encode"
+			hasDecode = hasDecode || name.Name == "Decode"
+		}
+	}
+	return hasEncode && hasDecode
+}
@@ -24,9 +24,12 @@ type FileData struct {
 
 // TypeData represents data for type templates
 type TypeData struct {
-	TypeName     string
-	Fields       []FieldData
-	CanHaveLoops bool // from static analysis
+	TypeName      string
+	Fields        []FieldData
+	CanHaveLoops  bool   // from static analysis; emits depth tracking via Enter/Leave
+	ListField     string // self-referential list field walked iteratively (empty if none)
+	TypeParams    string // receiver type parameter list of a generic struct, e.g. "[T]"
+	TypeParamDecl string // type parameter list with constraints, for the generic Codec assertion
 }
 
 // FieldData represents data for field templates
@@ -39,16 +42,37 @@ type FieldData struct {
 	ElementIsPointer          bool   // true if ElementType is a pointer (starts with *)
 	ElementTypeWithoutPointer string // ElementType with * stripped off
 	IsPointer                 bool   // true if FieldType is a pointer (for field_decode_struct)
+	TypeParam                 string // T when FieldType or ElementType is pointer type parameter PT (allocated with PT(new(T)))
+	CodecParam                bool   // FieldType or ElementType is type parameter T xdr.Codec (allocated with xdr.NewCodec)
 	TypeWithoutPointer        string // FieldType with * stripped off (for field_decode_struct)
-	EncodeCode                string
-	DecodeCode                string
-	Method                    string
-	VarName                   string
-	DiscriminantField         string
-	Cases                     []UnionCaseData
-	HasDefaultCase            bool
-	ParentHasLoops            bool // true if the containing struct needs loop detection
-	// Alias-specific fields
+	IntMethod                 string // runtime method suffix for narrow integer elements (e.g., Uint16), empty otherwise
+	// Nested array fields (one dimension or the innermost element per template)
+	Expr        string // expression of the array or element at this level, e.g. v.Grid[i]
+	Index       string // loop variable over this dimension
+	Length      string // length of a fixed-size dimension, empty for variable-length
+	MaxLength   string // bound of a variable-length dimension, empty for unbounded
+	ElementCode string // code for one element of this dimension
+	FixedBytes  bool   // element is a [N]byte array
+	// Constant and reserved fields
+	Label      string // field name in error messages, descriptive for blank fields
+	ConstValue string // wire value of a constant field as written in its tag
+	Reserved   int    // size in bytes of a reserved area
+	StrictZero bool   // reserved bytes are checked to be zero on decode
+	// Checksum and length fields
+	Covers            string // fields a checksum covers, for comments and errors, e.g. "Header and Body"
+	Plural            bool   // the checksum covers more than one field
+	Verb              string // format verb for the checksum value in errors
+	Registry          string // registry of an interface field
+	EncodeCode        string
+	DecodeCode        string
+	Method            string
+	VarName           string
+	DiscriminantField string
+	Cases             []UnionCaseData
+	HasDefaultCase    bool
+	DefaultCode       string // encode/decode code for the default case (when HasDefaultCase)
+	UnknownPolicy     string // unknown discriminant policy (when no default case)
+	// Alias-specific fields (also the +xdr:converter functions)
 	UnderlyingType string
 	AliasType      string
 	EncodeMethod   string
@@ -56,6 +80,17 @@ type FieldData struct {
 	// Type conversion fields
 	TypeConversion    string
 	TypeConversionEnd string
+	// Map-specific fields (conversions are empty when the Go type needs none)
+	KeyType           string
+	KeyEncodeMethod   string
+	KeyDecodeMethod   string
+	KeyConversion     string
+	ValueType         string
+	ValueEncodeMethod string
+	ValueDecodeMethod string
+	ValueConversion   string
+	ValueIsStruct     bool
+	ValueIsSet        bool
 }
 
 // UnionCaseData represents data for union case templates
@@ -187,7 +222,6 @@ func NewTemplateManager() (*TemplateManager, error) {
 				TypeConversionEnd:  "",
 				IsPointer:          false,
 				TypeWithoutPointer: "string",
-				ParentHasLoops:     false,
 			}
 		case "array_encode", "array_decode":
 			dummy = FieldData{
@@ -197,7 +231,6 @@ func NewTemplateManager() (*TemplateManager, error) {
 				ElementIsStruct:           false,
 				ElementIsPointer:          false,
 				ElementTypeWithoutPointer: "string",
-				ParentHasLoops:            false,
 			}
 		case "field_encode_alias", "field_decode_alias":
 			dummy = FieldData{
@@ -234,12 +267,99 @@ func NewTemplateManager() (*TemplateManager, error) {
 				FieldType:       "[16]byte",
 				ElementType:     "byte",
 				ElementIsStruct: false,
-				ParentHasLoops:  false,
+			}
+		case "map_encode", "map_decode":
+			dummy = FieldData{
+				FieldName:         "TestMap",
+				FieldType:         "map[string]uint32",
+				KeyType:           "string",
+				KeyEncodeMethod:   "EncodeString",
+				KeyDecodeMethod:   "DecodeString",
+				ValueType:         "uint32",
+				ValueEncodeMethod: "EncodeUint32",
+				ValueDecodeMethod: "DecodeUint32",
+			}
+		case "list_encode", "list_decode":
+			dummy = FieldData{
+				FieldName:   "TestList",
+				FieldType:   "[]TestNode",
+				ElementType: "TestNode",
+			}
+		case "checksum_encode", "checksum_patch", "checksum_verify", "checksum_covered":
+			dummy = FieldData{
+				FieldName:   "TestSum",
+				VarName:     "tempTestSum",
+				Method:      "ChecksumCRC32",
+				Expr:        "enc.Len()",
+				ElementCode: "enc.Bytes()[startTestBody:endTestBody]",
+				Covers:      "TestBody",
+				Verb:        "%#x",
+			}
+		case "opaque_encode", "opaque_decode":
+			dummy = FieldData{
+				FieldName:          "TestBody",
+				FieldType:          "*TestBody",
+				IsPointer:          true,
+				TypeWithoutPointer: "TestBody",
+			}
+		case "embedded_encode", "embedded_decode":
+			dummy = FieldData{
+				FieldName:          "TestHeader",
+				TypeWithoutPointer: "TestHeader",
+			}
+		case "converter_encode", "converter_decode":
+			dummy = FieldData{
+				FieldName:    "TestAddr",
+				VarName:      "tempTestAddr",
+				EncodeMethod: "encodeTestAddr",
+				DecodeMethod: "decodeTestAddr",
 			}
 		case "fixed_bytes_encode", "fixed_bytes_decode":
 			dummy = FieldData{
-				FieldName:      "TestBytes",
-				ParentHasLoops: false,
+				FieldName: "TestBytes",
+			}
+		case "const_encode", "const_decode":
+			dummy = FieldData{
+				FieldName:  "TestMagic",
+				Label:      "TestMagic",
+				VarName:    "tempTestMagic",
+				Method:     "EncodeUint32",
+				ConstValue: "0xDEADBEEF",
+			}
+		case "reserved_encode", "reserved_decode":
+			dummy = FieldData{
+				Label:      "TestType reserved area",
+				Reserved:   8,
+				StrictZero: true,
+			}
+		case "named_type":
+			dummy = struct {
+				TypeName    string
+				WrapperType string
+				Underlying  string
+				EncodeCode  string
+				DecodeCode  string
+			}{
+				TypeName:    "TestEntries",
+				WrapperType: "testEntriesValue",
+				Underlying:  "[]TestEntry",
+			}
+		case "nested_array_encode", "nested_array_decode":
+			dummy = FieldData{
+				FieldName:   "TestGrid",
+				FieldType:   "[][]uint32",
+				Expr:        "v.TestGrid",
+				Index:       "i",
+				VarName:     "TestGridLen",
+				MaxLength:   "16",
+				ElementCode: "_ = i",
+			}
+		case "nested_element_encode", "nested_element_decode":
+			dummy = FieldData{
+				FieldName:   "TestGrid",
+				Expr:        "v.TestGrid[i][j]",
+				ElementType: "uint32",
+				Method:      "EncodeUint32",
 			}
 		case "payload_to_union":
 			dummy = struct {
@@ -255,15 +375,58 @@ func NewTemplateManager() (*TemplateManager, error) {
 				PayloadField:    "Payload",
 				Discriminant:    "TestConstant",
 			}
+		case "interface_encode", "interface_decode":
+			dummy = FieldData{
+				FieldName: "TestPlugin",
+				FieldType: "TestPlugin",
+				VarName:   "tempTestPlugin",
+				Registry:  "TestPluginRegistry",
+			}
+		case "registry":
+			dummy = RegistryConfig{
+				Interface: "TestPlugin",
+				Registry:  "TestPluginRegistry",
+				Types:     []*RegisterDirective{{Interface: "TestPlugin", Type: "TestGzip", ID: "1"}},
+			}
+		case "envelope_register":
+			dummy = []*EnvelopeDirective{{Type: "TestEvent", ID: "1", Version: "1"}}
+		case "union_mux":
+			dummy = MuxConfig{
+				UnionType:        "TestUnion",
+				MuxType:          "TestUnionMux",
+				DiscriminantType: "TestType",
+				Cases: []MuxCase{
+					{Discriminant: "TestConstant", Method: "HandleTestConstant", PayloadType: "TestPayload"},
+					{Discriminant: "TestArm", Method: "HandleTestArm", ArmType: "uint64", WrapperType: "testUnionTestArmArm"},
+					{Discriminant: "TestVoid", Method: "HandleTestVoid", Void: true},
+				},
+			}
+		case "ext_union":
+			dummy = ExtConfig{
+				TypeName: "TestExt",
+				Versions: []ExtVersion{{Number: 1, Field: "V1", Type: "TestExtV1"}},
+			}
+		case "union_arm":
+			dummy = ArmConfig{
+				UnionType:    "TestUnion",
+				Discriminant: "TestConstant",
+				ArmType:      "uint64",
+				AccessorName: "TestConstant",
+				WrapperType:  "testUnionTestConstantArm",
+				KeyField:     "Type",
+				PayloadField: "Payload",
+			}
 		case "payload_encode_to_union":
 			dummy = struct {
-				PayloadTypeName string
-				Discriminant    string
-				CanHaveLoops    bool
+				PayloadTypeName    string
+				Discriminant       string
+				DiscriminantMethod string
+				DiscriminantCast   string
 			}{
-				PayloadTypeName: "TestPayload",
-				Discriminant:    "TestConstant",
-				CanHaveLoops:    false,
+				PayloadTypeName:    "TestPayload",
+				Discriminant:       "TestConstant",
+				DiscriminantMethod: "EncodeUint32",
+				DiscriminantCast:   "uint32",
 			}
 		default:
 			dummy = struct{}{}
@@ -320,7 +483,13 @@ func (cg *CodeGenerator) GenerateEncodeMethod(typeInfo TypeInfo) (string, error)
 	debugf("GenerateEncodeMethod: %s, CanHaveLoops=%t", typeInfo.Name, typeInfo.CanHaveLoops)
 	// Convert fields to template data
 	var fields []FieldData
-	for _, field := range typeInfo.Fields {
+	var listField string
+	covered := checksumCoveredFields(typeInfo)
+	for i, field := range typeInfo.Fields {
+		if field.isListLink(typeInfo.Name) {
+			listField = field.Name
+			continue
+		}
 		fieldData := FieldData{
 			FieldName: field.Name,
 			FieldType: field.Type,
@@ -328,6 +497,51 @@ func (cg *CodeGenerator) GenerateEncodeMethod(typeInfo TypeInfo) (string, error)
 
 		// Generate field-specific encode code
 		switch {
+		case field.Checksum != "":
+			encodeCode, err := cg.tm.ExecuteTemplate("checksum_encode", FieldData{FieldName: field.Name})
+			if err != nil {
+				return "", err
+			}
+			fieldData.EncodeCode = encodeCode
+		case field.Const != "" || field.Reserved > 0:
+			encodeCode, err := cg.generateFixedFieldCode(field, typeInfo, i, "encode")
+			if err != nil {
+				return "", err
+			}
+			fieldData.EncodeCode = encodeCode
+		case field.Opaque:
+			encodeCode, err := cg.tm.ExecuteTemplate("opaque_encode", opaqueFieldData(field))
+			if err != nil {
+				return "", err
+			}
+			fieldData.EncodeCode = encodeCode
+		case field.XDRType == "interface":
+			encodeCode, err := cg.tm.ExecuteTemplate("interface_encode", FieldData{
+				FieldName: field.Name,
+				Registry:  field.Registry,
+			})
+			if err != nil {
+				return "", err
+			}
+			fieldData.EncodeCode = encodeCode
+		case field.XDRType == "embedded":
+			encodeCode, err := cg.tm.ExecuteTemplate("embedded_encode", FieldData{FieldName: field.Name})
+			if err != nil {
+				return "", err
+			}
+			fieldData.EncodeCode = encodeCode
+		case field.XDRType == "map":
+			encodeCode, err := cg.generateMapEncodeCode(field)
+			if err != nil {
+				return "", err
+			}
+			fieldData.EncodeCode = encodeCode
+		case field.IsList:
+			encodeCode, err := cg.generateListEncodeCode(field)
+			if err != nil {
+				return "", err
+			}
+			fieldData.EncodeCode = encodeCode
 		case field.IsUnion:
 			// Union field
 			encodeCode, err := cg.generateUnionEncodeCode(field, typeInfo)
@@ -351,13 +565,33 @@ func (cg *CodeGenerator) GenerateEncodeMethod(typeInfo TypeInfo) (string, error)
 			fieldData.EncodeCode = encodeCode
 		}
 
+		// Fields covered by a checksum record where their encoding starts and ends
+		if covered[field.Name] {
+			code, err := cg.tm.ExecuteTemplate("checksum_covered", FieldData{
+				FieldName:   field.Name,
+				Expr:        "enc.Len()",
+				ElementCode: fieldData.EncodeCode,
+			})
+			if err != nil {
+				return "", err
+			}
+			fieldData.EncodeCode = code
+		}
+
 		fields = append(fields, fieldData)
 	}
+	checksums, err := cg.generateChecksumCode(typeInfo, "encode")
+	if err != nil {
+		return "", err
+	}
+	fields = append(fields, checksums...)
 
 	data := TypeData{
 		TypeName:     typeInfo.Name,
 		Fields:       fields,
-		CanHaveLoops: typeInfo.CanHaveLoops,
+		CanHaveLoops: typeInfo.CanHaveLoops && !disableLoopDetection,
+		ListField:    listField,
+		TypeParams:   typeInfo.typeParamList(),
 	}
 	return cg.tm.ExecuteTemplate("encode_method", data)
 }
@@ -366,7 +600,13 @@ func (cg *CodeGenerator) GenerateEncodeMethod(typeInfo TypeInfo) (string, error)
 func (cg *CodeGenerator) GenerateDecodeMethod(typeInfo TypeInfo) (string, error) {
 	// Convert fields to template data
 	var fields []FieldData
-	for _, field := range typeInfo.Fields {
+	var listField string
+	covered := checksumCoveredFields(typeInfo)
+	for i, field := range typeInfo.Fields {
+		if field.isListLink(typeInfo.Name) {
+			listField = field.Name
+			continue
+		}
 		fieldData := FieldData{
 			FieldName: field.Name,
 			FieldType: field.Type,
@@ -374,6 +614,50 @@ func (cg *CodeGenerator) GenerateDecodeMethod(typeInfo TypeInfo) (string, error)
 
 		// Generate field-specific decode code
 		switch {
+		case field.Const != "" || field.Reserved > 0:
+			decodeCode, err := cg.generateFixedFieldCode(field, typeInfo, i, "decode")
+			if err != nil {
+				return "", err
+			}
+			fieldData.DecodeCode = decodeCode
+		case field.Opaque:
+			decodeCode, err := cg.tm.ExecuteTemplate("opaque_decode", opaqueFieldData(field))
+			if err != nil {
+				return "", err
+			}
+			fieldData.DecodeCode = decodeCode
+		case field.XDRType == "interface":
+			decodeCode, err := cg.tm.ExecuteTemplate("interface_decode", FieldData{
+				FieldName: field.Name,
+				FieldType: field.Type,
+				VarName:   "temp" + field.Name,
+				Registry:  field.Registry,
+			})
+			if err != nil {
+				return "", err
+			}
+			fieldData.DecodeCode = decodeCode
+		case field.XDRType == "embedded":
+			decodeCode, err := cg.tm.ExecuteTemplate("embedded_decode", FieldData{
+				FieldName:          field.Name,
+				TypeWithoutPointer: strings.TrimPrefix(field.Type, "*"),
+			})
+			if err != nil {
+				return "", err
+			}
+			fieldData.DecodeCode = decodeCode
+		case field.XDRType == "map":
+			decodeCode, err := cg.generateMapDecodeCode(field)
+			if err != nil {
+				return "", err
+			}
+			fieldData.DecodeCode = decodeCode
+		case field.IsList:
+			decodeCode, err := cg.generateListDecodeCode(field)
+			if err != nil {
+				return "", err
+			}
+			fieldData.DecodeCode = decodeCode
 		case field.IsUnion:
 			// Union field
 			decodeCode, err := cg.generateUnionDecodeCode(field, typeInfo)
@@ -397,17 +681,71 @@ func (cg *CodeGenerator) GenerateDecodeMethod(typeInfo TypeInfo) (string, error)
 			fieldData.DecodeCode = decodeCode
 		}
 
+		// Fields covered by a checksum record where their encoding starts and ends
+		if covered[field.Name] {
+			code, err := cg.tm.ExecuteTemplate("checksum_covered", FieldData{
+				FieldName:   field.Name,
+				Expr:        "dec.Position()",
+				ElementCode: fieldData.DecodeCode,
+			})
+			if err != nil {
+				return "", err
+			}
+			fieldData.DecodeCode = code
+		}
+
 		fields = append(fields, fieldData)
 	}
+	checksums, err := cg.generateChecksumCode(typeInfo, "decode")
+	if err != nil {
+		return "", err
+	}
+	fields = append(fields, checksums...)
 
 	data := TypeData{
 		TypeName:     typeInfo.Name,
 		Fields:       fields,
-		CanHaveLoops: typeInfo.CanHaveLoops,
+		CanHaveLoops: typeInfo.CanHaveLoops && !disableLoopDetection,
+		ListField:    listField,
+		TypeParams:   typeInfo.typeParamList(),
 	}
 	return cg.tm.ExecuteTemplate("decode_method", data)
 }
 
+// GenerateNamedType generates the wrapper struct of a named non-struct type and Encode/Decode
+// methods that convert to and from it
+func (cg *CodeGenerator) GenerateNamedType(typeInfo TypeInfo) (string, error) {
+	wrapper := TypeInfo{
+		Name:         namedTypeWrapper(typeInfo.Name),
+		Fields:       typeInfo.Fields,
+		CanHaveLoops: typeInfo.CanHaveLoops,
+	}
+	encodeCode, err := cg.GenerateEncodeMethod(wrapper)
+	if err != nil {
+		return "", err
+	}
+	decodeCode, err := cg.GenerateDecodeMethod(wrapper)
+	if err != nil {
+		return "", err
+	}
+	cg.trackPackageUsage(typeInfo.Underlying)
+
+	data := struct {
+		TypeName    string
+		WrapperType string
+		Underlying  string
+		EncodeCode  string
+		DecodeCode  string
+	}{
+		TypeName:    typeInfo.Name,
+		WrapperType: wrapper.Name,
+		Underlying:  typeInfo.Underlying,
+		EncodeCode:  encodeCode,
+		DecodeCode:  decodeCode,
+	}
+	return cg.tm.ExecuteTemplate("named_type", data)
+}
+
 // GenerateAssertion generates the compile-time assertion using templates
 func (cg *CodeGenerator) GenerateAssertion(typeName string) (string, error) {
 	data := TypeData{
@@ -416,6 +754,19 @@ func (cg *CodeGenerator) GenerateAssertion(typeName string) (string, error) {
 	return cg.tm.ExecuteTemplate("assertion", data)
 }
 
+// GenerateTypeAssertion generates the compile-time Codec assertion for a struct, generic or not
+func (cg *CodeGenerator) GenerateTypeAssertion(typeInfo TypeInfo) (string, error) {
+	if len(typeInfo.TypeParams) == 0 {
+		return cg.GenerateAssertion(typeInfo.Name)
+	}
+	data := TypeData{
+		TypeName:      typeInfo.Name,
+		TypeParams:    typeInfo.typeParamList(),
+		TypeParamDecl: typeInfo.typeParamDecl(),
+	}
+	return cg.tm.ExecuteTemplate("assertion", data)
+}
+
 // GeneratePayloadToUnion generates ToUnion method for payload types
 func (cg *CodeGenerator) GeneratePayloadToUnion(typeInfo TypeInfo, typeDefs map[string]ast.Node) (string, error) {
 	if typeInfo.PayloadConfig == nil {
@@ -445,20 +796,63 @@ func (cg *CodeGenerator) GeneratePayloadToUnion(typeInfo TypeInfo, typeDefs map[
 	return cg.tm.ExecuteTemplate("payload_to_union", data)
 }
 
+// GenerateUnionArm generates the wrapper type and union accessors for a +xdr:arm arm
+func (cg *CodeGenerator) GenerateUnionArm(armConfig ArmConfig) (string, error) {
+	if armConfig.KeyField == "" || armConfig.PayloadField == "" {
+		return "", fmt.Errorf("failed to find key field (%s) or payload field (%s) for union type %s", armConfig.KeyField, armConfig.PayloadField, armConfig.UnionType)
+	}
+	cg.trackPackageUsage(armConfig.ArmType)
+	return cg.tm.ExecuteTemplate("union_arm", armConfig)
+}
+
+// GenerateExtUnion generates a +xdr:ext version extension union with its Codec methods and
+// version helpers
+func (cg *CodeGenerator) GenerateExtUnion(ext ExtConfig) (string, error) {
+	return cg.tm.ExecuteTemplate("ext_union", ext)
+}
+
+// GenerateRegistry generates the registry variable of an interface and an init function
+// registering its +xdr:register types
+func (cg *CodeGenerator) GenerateRegistry(registry RegistryConfig) (string, error) {
+	return cg.tm.ExecuteTemplate("registry", registry)
+}
+
+// GenerateEnvelopeRegistrations generates an init function registering the +xdr:envelope
+// message types of a file
+func (cg *CodeGenerator) GenerateEnvelopeRegistrations(envelopes []*EnvelopeDirective) (string, error) {
+	return cg.tm.ExecuteTemplate("envelope_register", envelopes)
+}
+
+// GenerateUnionMux generates the typed xdr.Mux of a union container declared with +xdr:union,mux
+func (cg *CodeGenerator) GenerateUnionMux(typeInfo TypeInfo) (string, error) {
+	config, err := buildMuxConfig(typeInfo)
+	if err != nil {
+		return "", err
+	}
+	return cg.tm.ExecuteTemplate("union_mux", config)
+}
+
 // GeneratePayloadEncodeToUnion generates EncodeToUnion method for payload types
 func (cg *CodeGenerator) GeneratePayloadEncodeToUnion(typeInfo TypeInfo) (string, error) {
 	if typeInfo.PayloadConfig == nil {
 		return "", fmt.Errorf("payload config is nil for type %s", typeInfo.Name)
 	}
 
+	discriminantXDR := typeInfo.PayloadConfig.DiscriminantXDR
+	if discriminantXDR == "" {
+		discriminantXDR = "uint32"
+	}
+
 	data := struct {
-		PayloadTypeName string
-		Discriminant    string
-		CanHaveLoops    bool
+		PayloadTypeName    string
+		Discriminant       string
+		DiscriminantMethod string
+		DiscriminantCast   string
 	}{
-		PayloadTypeName: typeInfo.Name,
-		Discriminant:    typeInfo.PayloadConfig.Discriminant,
-		CanHaveLoops:    typeInfo.CanHaveLoops,
+		PayloadTypeName:    typeInfo.Name,
+		Discriminant:       typeInfo.PayloadConfig.Discriminant,
+		DiscriminantMethod: cg.getEncodeMethod(discriminantXDR),
+		DiscriminantCast:   cg.getExpectedGoType(discriminantXDR),
 	}
 
 	return cg.tm.ExecuteTemplate("payload_encode_to_union", data)
@@ -468,6 +862,14 @@ func (cg *CodeGenerator) GeneratePayloadEncodeToUnion(typeInfo TypeInfo) (string
 
 // generateBasicEncodeCode generates basic encode code for a field
 func (cg *CodeGenerator) generateBasicEncodeCode(field FieldInfo, typeInfo TypeInfo) (string, error) {
+	// Types bound with +xdr:converter are passed to the user's encode function
+	if field.Converter != nil && field.Type == field.Converter.Type {
+		return cg.tm.ExecuteTemplate("converter_encode", FieldData{
+			FieldName:    field.Name,
+			EncodeMethod: field.Converter.Encode,
+		})
+	}
+
 	// Special case: []byte with xdr:"bytes" should use bytes encoding, not array encoding
 	// Check both Type and ResolvedType to handle aliases like SessionID which is []byte
 	if (field.Type == "[]byte" || field.ResolvedType == "[]byte") && field.XDRType == "bytes" {
@@ -494,22 +896,26 @@ func (cg *CodeGenerator) generateBasicEncodeCode(field FieldInfo, typeInfo TypeI
 		return cg.tm.ExecuteTemplate("field_encode_basic", data)
 	}
 
+	// Arrays of arrays and arrays with length bounds are generated one dimension at a time
+	if dims, _ := cg.splitArrayDimensions(field.Type); len(dims) > 1 || len(field.MaxLengths) > 0 || (len(dims) > 0 && timeMethodSuffix(field.XDRType) != "") {
+		return cg.generateNestedArrayCode(field, "encode")
+	}
+
 	// Special case: [N]byte alias with xdr:"bytes" should use EncodeFixedBytes optimization
 	// Check if ResolvedType is a fixed byte array, or if Type is a direct fixed byte array
 	if field.XDRType == "bytes" {
 		isFixedByteArray := false
 		// Direct fixed byte array
-		if strings.HasPrefix(field.Type, "[") && strings.Contains(field.Type, "]byte") {
+		if strings.HasPrefix(field.Type, "[") && !strings.HasPrefix(field.Type, "[]") && strings.Contains(field.Type, "]byte") {
 			isFixedByteArray = true
 		}
 		// Alias to fixed byte array
-		if field.ResolvedType != "" && strings.HasPrefix(field.ResolvedType, "[") && strings.Contains(field.ResolvedType, "]byte") {
+		if field.ResolvedType != "" && strings.HasPrefix(field.ResolvedType, "[") && !strings.HasPrefix(field.ResolvedType, "[]") && strings.Contains(field.ResolvedType, "]byte") {
 			isFixedByteArray = true
 		}
 		if isFixedByteArray {
 			return cg.tm.ExecuteTemplate("fixed_bytes_encode", FieldData{
-				FieldName:      field.Name,
-				ParentHasLoops: typeInfo.CanHaveLoops,
+				FieldName: field.Name,
 			})
 		}
 	}
@@ -537,7 +943,6 @@ func (cg *CodeGenerator) generateBasicEncodeCode(field FieldInfo, typeInfo TypeI
 			FieldType:          field.Type,
 			IsPointer:          isPointer,
 			TypeWithoutPointer: typeWithoutPointer,
-			ParentHasLoops:     typeInfo.CanHaveLoops,
 		}
 		return cg.tm.ExecuteTemplate("field_encode_struct", data)
 	}
@@ -584,6 +989,174 @@ func (cg *CodeGenerator) generateBasicEncodeCode(field FieldInfo, typeInfo TypeI
 	return cg.tm.ExecuteTemplate("field_encode_basic", data)
 }
 
+// generateFixedFieldCode generates code for a constant or reserved field, whose wire value comes
+// from its tag rather than the struct; index tells blank fields apart
+func (cg *CodeGenerator) generateFixedFieldCode(field FieldInfo, typeInfo TypeInfo, index int, direction string) (string, error) {
+	data := FieldData{
+		FieldName:  field.Name,
+		Label:      field.Name,
+		VarName:    "temp" + field.Name,
+		ConstValue: field.Const,
+		Reserved:   field.Reserved,
+		StrictZero: field.StrictZero,
+	}
+	if field.Name == "_" {
+		data.VarName = fmt.Sprintf("blank%d", index)
+		data.Label = typeInfo.Name + " constant"
+		if field.Reserved > 0 {
+			data.Label = typeInfo.Name + " reserved area"
+		}
+	}
+	if field.Reserved > 0 {
+		return cg.tm.ExecuteTemplate("reserved_"+direction, data)
+	}
+
+	if direction == "encode" {
+		data.Method = cg.getEncodeMethod(field.XDRType)
+	} else {
+		data.Method = cg.getDecodeMethod(field.XDRType)
+		if field.Type != cg.getExpectedGoType(field.XDRType) {
+			data.TypeConversion = field.Type + "("
+			data.TypeConversionEnd = ")"
+			cg.trackPackageUsage(field.Type)
+		}
+	}
+	if data.Method == "" {
+		return "", fmt.Errorf("unsupported XDR type for constant field %s: %s", field.Name, field.XDRType)
+	}
+	return cg.tm.ExecuteTemplate("const_"+direction, data)
+}
+
+// checksumCoveredFields returns the names of the fields covered by a checksum or length field
+func checksumCoveredFields(typeInfo TypeInfo) map[string]bool {
+	covered := make(map[string]bool)
+	for _, field := range typeInfo.Fields {
+		for _, name := range field.Covers {
+			covered[name] = true
+		}
+	}
+	return covered
+}
+
+// generateChecksumCode generates the code run after all fields: patching each checksum and
+// length field on encode, verifying it on decode
+func (cg *CodeGenerator) generateChecksumCode(typeInfo TypeInfo, direction string) ([]FieldData, error) {
+	var fields []FieldData
+	for _, field := range typeInfo.Fields {
+		if field.Checksum == "" {
+			continue
+		}
+		ranges := make([]string, len(field.Covers))
+		for i, name := range field.Covers {
+			if direction == "encode" {
+				ranges[i] = fmt.Sprintf("enc.Bytes()[start%s:end%s]", name, name)
+			} else {
+				ranges[i] = fmt.Sprintf("dec.GetSlice(start%s, end%s)", name, name)
+			}
+		}
+		covers := strings.Join(field.Covers, ", ")
+		if n := len(field.Covers); n > 1 {
+			covers = strings.Join(field.Covers[:n-1], ", ") + " and " + field.Covers[n-1]
+		}
+		data := FieldData{
+			FieldName:   field.Name,
+			VarName:     "temp" + field.Name,
+			Method:      checksumFuncs[field.Checksum],
+			ElementCode: strings.Join(ranges, ", "),
+			Covers:      covers,
+			Plural:      len(field.Covers) > 1,
+			Verb:        "%#x",
+		}
+		if field.Checksum == "len" {
+			data.Verb = "%d"
+		}
+
+		templateName := "checksum_patch"
+		if direction == "decode" {
+			templateName = "checksum_verify"
+		}
+		code, err := cg.tm.ExecuteTemplate(templateName, data)
+		if err != nil {
+			return nil, err
+		}
+		if direction == "encode" {
+			fields = append(fields, FieldData{FieldName: field.Name, EncodeCode: code})
+		} else {
+			fields = append(fields, FieldData{FieldName: field.Name, DecodeCode: code})
+		}
+	}
+	return fields, nil
+}
+
+// opaqueFieldData returns template data for a struct field encoded as opaque data
+func opaqueFieldData(field FieldInfo) FieldData {
+	return FieldData{
+		FieldName:          field.Name,
+		FieldType:          field.Type,
+		IsPointer:          strings.HasPrefix(field.Type, "*"),
+		TypeWithoutPointer: strings.TrimPrefix(field.Type, "*"),
+	}
+}
+
+// listFieldData returns template data for a list head pointer or slice of list nodes
+func listFieldData(field FieldInfo) FieldData {
+	elementType := strings.TrimPrefix(field.Type, "[]")
+	return FieldData{
+		FieldName:                 field.Name,
+		FieldType:                 field.Type,
+		IsPointer:                 strings.HasPrefix(field.Type, "*"),
+		TypeWithoutPointer:        strings.TrimPrefix(field.Type, "*"),
+		ElementType:               elementType,
+		ElementIsPointer:          strings.HasPrefix(elementType, "*"),
+		ElementTypeWithoutPointer: strings.TrimPrefix(elementType, "*"),
+	}
+}
+
+// generateListEncodeCode generates optional-data encode code for xdr:"list" fields
+func (cg *CodeGenerator) generateListEncodeCode(field FieldInfo) (string, error) {
+	return cg.tm.ExecuteTemplate("list_encode", listFieldData(field))
+}
+
+// generateListDecodeCode generates optional-data decode code for xdr:"list" fields
+func (cg *CodeGenerator) generateListDecodeCode(field FieldInfo) (string, error) {
+	return cg.tm.ExecuteTemplate("list_decode", listFieldData(field))
+}
+
+// mapFieldData returns template data for a map or set field
+func (cg *CodeGenerator) mapFieldData(field FieldInfo) FieldData {
+	data := FieldData{
+		FieldName:     field.Name,
+		FieldType:     field.Type,
+		KeyType:       field.MapKeyType,
+		ValueType:     field.MapValueType,
+		ValueIsStruct: field.MapValueXDR == "struct",
+		ValueIsSet:    field.MapValueXDR == "set",
+	}
+	data.KeyEncodeMethod = cg.getEncodeMethod(field.MapKeyXDR)
+	data.KeyDecodeMethod = cg.getDecodeMethod(field.MapKeyXDR)
+	if expected := cg.getExpectedGoType(field.MapKeyXDR); expected != field.MapKeyType {
+		data.KeyConversion = expected
+	}
+	if !data.ValueIsStruct && !data.ValueIsSet {
+		data.ValueEncodeMethod = cg.getEncodeMethod(field.MapValueXDR)
+		data.ValueDecodeMethod = cg.getDecodeMethod(field.MapValueXDR)
+		if expected := cg.getExpectedGoType(field.MapValueXDR); expected != field.MapValueType {
+			data.ValueConversion = expected
+		}
+	}
+	return data
+}
+
+// generateMapEncodeCode generates sorted key/value pair encode code for map fields
+func (cg *CodeGenerator) generateMapEncodeCode(field FieldInfo) (string, error) {
+	return cg.tm.ExecuteTemplate("map_encode", cg.mapFieldData(field))
+}
+
+// generateMapDecodeCode generates key/value pair decode code for map fields
+func (cg *CodeGenerator) generateMapDecodeCode(field FieldInfo) (string, error) {
+	return cg.tm.ExecuteTemplate("map_decode", cg.mapFieldData(field))
+}
+
 // generateVariableArrayEncodeCode generates encode code for []Type fields
 func (cg *CodeGenerator) generateVariableArrayEncodeCode(field FieldInfo, typeInfo TypeInfo) (string, error) {
 	elementType := strings.TrimPrefix(field.Type, "[]")
@@ -608,9 +1181,12 @@ func (cg *CodeGenerator) generateVariableArrayEncodeCode(field FieldInfo, typeIn
 		ElementType:         elementType,
 		ResolvedElementType: resolvedElementType,
 		ElementIsStruct:     elementIsStruct,
-		ParentHasLoops:      typeInfo.CanHaveLoops,
+		IntMethod:           narrowIntMethods[resolvedElementType],
 		// Use the XDR tag as the element encoding type
 	}
+	if field.Converter != nil {
+		data.EncodeMethod = field.Converter.Encode
+	}
 	return cg.tm.ExecuteTemplate("array_encode", data)
 }
 
@@ -633,8 +1209,7 @@ func (cg *CodeGenerator) generateFixedArrayEncodeCode(field FieldInfo, typeInfo
 	// Special case: [N]byte with xdr:"bytes" -> use EncodeFixedBytes optimization
 	if elementType == "byte" && field.XDRType == "bytes" {
 		return cg.tm.ExecuteTemplate("fixed_bytes_encode", FieldData{
-			FieldName:      field.Name,
-			ParentHasLoops: typeInfo.CanHaveLoops,
+			FieldName: field.Name,
 		})
 	}
 
@@ -645,13 +1220,25 @@ func (cg *CodeGenerator) generateFixedArrayEncodeCode(field FieldInfo, typeInfo
 		FieldType:       field.Type,
 		ElementType:     elementType,
 		ElementIsStruct: elementIsStruct,
-		ParentHasLoops:  typeInfo.CanHaveLoops,
+		IntMethod:       narrowIntMethods[elementType],
+	}
+	if field.Converter != nil {
+		data.EncodeMethod = field.Converter.Encode
 	}
 	return cg.tm.ExecuteTemplate("fixed_array_encode", data)
 }
 
 // generateBasicDecodeCode generates basic decode code for a field
 func (cg *CodeGenerator) generateBasicDecodeCode(field FieldInfo, typeInfo TypeInfo) (string, error) {
+	// Types bound with +xdr:converter are returned by the user's decode function
+	if field.Converter != nil && field.Type == field.Converter.Type {
+		return cg.tm.ExecuteTemplate("converter_decode", FieldData{
+			FieldName:    field.Name,
+			VarName:      "temp" + field.Name,
+			DecodeMethod: field.Converter.Decode,
+		})
+	}
+
 	// Special case: []byte with xdr:"bytes" should use bytes decoding, not array decoding
 	if (field.Type == "[]byte" || field.ResolvedType == "[]byte") && field.XDRType == "bytes" {
 		method := cg.getDecodeMethod(field.XDRType)
@@ -678,13 +1265,17 @@ func (cg *CodeGenerator) generateBasicDecodeCode(field FieldInfo, typeInfo TypeI
 		return cg.tm.ExecuteTemplate("field_decode_basic", data)
 	}
 
+	// Arrays of arrays and arrays with length bounds are generated one dimension at a time
+	if dims, _ := cg.splitArrayDimensions(field.Type); len(dims) > 1 || len(field.MaxLengths) > 0 || (len(dims) > 0 && timeMethodSuffix(field.XDRType) != "") {
+		return cg.generateNestedArrayCode(field, "decode")
+	}
+
 	// Special case: [N]byte alias with xdr:"bytes" should use DecodeFixedBytesInto optimization
 	// Check if ResolvedType is a fixed byte array ([N]byte) but NOT variable array ([]byte)
 	if field.XDRType == "bytes" && field.ResolvedType != "" &&
 		strings.HasPrefix(field.ResolvedType, "[") && !strings.HasPrefix(field.ResolvedType, "[]") && strings.Contains(field.ResolvedType, "]byte") {
 		return cg.tm.ExecuteTemplate("fixed_bytes_decode", FieldData{
-			FieldName:      field.Name,
-			ParentHasLoops: typeInfo.CanHaveLoops,
+			FieldName: field.Name,
 		})
 	}
 
@@ -713,6 +1304,8 @@ func (cg *CodeGenerator) generateBasicDecodeCode(field FieldInfo, typeInfo TypeI
 			FieldType:          field.Type,
 			IsPointer:          isPointer,
 			TypeWithoutPointer: typeWithoutPointer,
+			TypeParam:          field.TypeParam,
+			CodecParam:         field.CodecParam,
 		}
 		return cg.tm.ExecuteTemplate("field_decode_struct", data)
 	}
@@ -776,9 +1369,14 @@ func (cg *CodeGenerator) generateVariableArrayDecodeCode(field FieldInfo, typeIn
 		ElementIsStruct:           elementIsStruct,
 		ElementIsPointer:          elementIsPointer,
 		ElementTypeWithoutPointer: elementTypeWithoutPointer,
-		ParentHasLoops:            typeInfo.CanHaveLoops,
+		IntMethod:                 narrowIntMethods[resolvedElementType],
 		// Use the XDR tag as the element encoding type
 	}
+	if field.Converter != nil {
+		data.DecodeMethod = field.Converter.Decode
+	}
+	data.TypeParam = field.TypeParam
+	data.CodecParam = field.CodecParam
 	return cg.tm.ExecuteTemplate("array_decode", data)
 }
 
@@ -801,8 +1399,7 @@ func (cg *CodeGenerator) generateFixedArrayDecodeCode(field FieldInfo, typeInfo
 	// Special case: [N]byte with xdr:"bytes" -> use DecodeFixedBytesInto optimization
 	if elementType == "byte" && field.XDRType == "bytes" {
 		return cg.tm.ExecuteTemplate("fixed_bytes_decode", FieldData{
-			FieldName:      field.Name,
-			ParentHasLoops: typeInfo.CanHaveLoops,
+			FieldName: field.Name,
 		})
 	}
 
@@ -813,21 +1410,147 @@ func (cg *CodeGenerator) generateFixedArrayDecodeCode(field FieldInfo, typeInfo
 		FieldType:       field.Type,
 		ElementType:     elementType,
 		ElementIsStruct: elementIsStruct,
-		ParentHasLoops:  typeInfo.CanHaveLoops,
+		IntMethod:       narrowIntMethods[elementType],
+	}
+	if field.Converter != nil {
+		data.DecodeMethod = field.Converter.Decode
 	}
+	data.TypeParam = field.TypeParam
+	data.CodecParam = field.CodecParam
 	return cg.tm.ExecuteTemplate("fixed_array_decode", data)
 }
 
+// arrayDimension is one level of a nested array type
+type arrayDimension struct {
+	GoType string // type of the array at this level as written (aliases keep their name)
+	Length string // length of a fixed-size dimension, empty for variable-length
+}
+
+// isByteArrayType reports whether a type is []byte or [N]byte, which encode as opaque data
+func isByteArrayType(typeName string) bool {
+	if !strings.HasPrefix(typeName, "[") {
+		return false
+	}
+	elementType := typeName[strings.Index(typeName, "]")+1:]
+	return elementType == "byte" || elementType == "uint8"
+}
+
+// arrayIndexName returns the loop variable for a dimension of a nested array
+func arrayIndexName(depth int) string {
+	names := []string{"i", "j", "k", "l", "m", "n"}
+	if depth < len(names) {
+		return names[depth]
+	}
+	return fmt.Sprintf("i%d", depth)
+}
+
+// splitArrayDimensions splits an array type into its dimensions, outermost first, and its element type
+// Byte arrays are elements (opaque data) rather than dimensions
+func (cg *CodeGenerator) splitArrayDimensions(goType string) ([]arrayDimension, string) {
+	var dims []arrayDimension
+	current := goType
+	for {
+		resolved := cg.resolveTypeAlias(current)
+		if !strings.HasPrefix(resolved, "[") || isByteArrayType(resolved) {
+			return dims, current
+		}
+		closeBracket := strings.Index(resolved, "]")
+		dims = append(dims, arrayDimension{GoType: current, Length: resolved[1:closeBracket]})
+		current = resolved[closeBracket+1:]
+	}
+}
+
+// nestedElementData returns template data for the innermost element of a nested array
+func (cg *CodeGenerator) nestedElementData(field FieldInfo, elementType, expr, direction string) FieldData {
+	data := FieldData{
+		FieldName:                 field.Name,
+		Expr:                      expr,
+		ElementType:               elementType,
+		ElementIsPointer:          strings.HasPrefix(elementType, "*"),
+		ElementTypeWithoutPointer: strings.TrimPrefix(elementType, "*"),
+	}
+
+	resolved := cg.resolveTypeAlias(elementType)
+	xdrType := resolved
+	switch {
+	case timeMethodSuffix(field.XDRType) != "":
+		// Arrays of time values carry the element layout as the field's XDR type
+		xdrType = field.XDRType
+	case isByteArrayType(resolved) && !strings.HasPrefix(resolved, "[]"):
+		data.FixedBytes = true
+		return data
+	case isByteArrayType(resolved):
+		xdrType = "bytes"
+	case !isPrimitiveType(resolved):
+		data.ElementIsStruct = true
+		return data
+	}
+
+	if direction == "encode" {
+		data.Method = cg.getEncodeMethod(xdrType)
+	} else {
+		data.Method = cg.getDecodeMethod(xdrType)
+	}
+	if expected := cg.getExpectedGoType(xdrType); expected != elementType {
+		data.TypeConversion = expected
+	}
+	return data
+}
+
+// generateNestedArrayCode generates encode or decode code for arrays of arrays and bounded arrays,
+// wrapping the element code in one loop per dimension from the innermost outwards
+func (cg *CodeGenerator) generateNestedArrayCode(field FieldInfo, direction string) (string, error) {
+	dims, elementType := cg.splitArrayDimensions(field.Type)
+	if len(field.MaxLengths) > len(dims) {
+		return "", fmt.Errorf("field %s has %d max bounds but only %d array dimensions", field.Name, len(field.MaxLengths), len(dims))
+	}
+
+	exprs := []string{"v." + field.Name}
+	for depth := range dims {
+		exprs = append(exprs, exprs[depth]+"["+arrayIndexName(depth)+"]")
+	}
+
+	code, err := cg.tm.ExecuteTemplate("nested_element_"+direction, cg.nestedElementData(field, elementType, exprs[len(dims)], direction))
+	if err != nil {
+		return "", err
+	}
+	for depth := len(dims) - 1; depth >= 0; depth-- {
+		data := FieldData{
+			FieldName:   field.Name,
+			FieldType:   dims[depth].GoType,
+			Expr:        exprs[depth],
+			Index:       arrayIndexName(depth),
+			Length:      dims[depth].Length,
+			VarName:     field.Name + "Len",
+			ElementCode: code,
+		}
+		if depth > 0 {
+			data.VarName = fmt.Sprintf("%sLen%d", field.Name, depth)
+		}
+		if depth < len(field.MaxLengths) && field.MaxLengths[depth] != "" {
+			if data.Length != "" {
+				return "", fmt.Errorf("field %s has a max bound on fixed-size dimension %d", field.Name, depth+1)
+			}
+			data.MaxLength = field.MaxLengths[depth]
+		}
+		if code, err = cg.tm.ExecuteTemplate("nested_array_"+direction, data); err != nil {
+			return "", err
+		}
+	}
+	return code, nil
+}
+
 // generateUnionEncodeCode generates union encode code for a field
 func (cg *CodeGenerator) generateUnionEncodeCode(field FieldInfo, structInfo TypeInfo) (string, error) {
-	keyField := findKeyField(structInfo)
+	keyField := unionKeyForField(field, structInfo)
 	if keyField == "" {
 		return "", fmt.Errorf("no key field found for union field %s", field.Name)
 	}
 
-	if structInfo.UnionConfig == nil {
+	unionConfig := unionConfigForField(field, structInfo)
+	if unionConfig == nil {
 		// Check if this is a void-only union (either explicit default=nil or no union config)
-		if field.DefaultType == "nil" || structInfo.UnionConfig == nil {
+		if field.DefaultType == "nil" || unionConfig == nil {
 			// Generate minimal void-only union encode code
 			data := FieldData{
 				FieldName:         field.Name,
@@ -841,14 +1564,8 @@ func (cg *CodeGenerator) generateUnionEncodeCode(field FieldInfo, structInfo Typ
 
 	var templateCases []UnionCaseData
 
-	// Generate cases for mapped constants (sorted for determinism)
-	var constantValues []string
-	for constantValue := range structInfo.UnionConfig.Cases {
-		constantValues = append(constantValues, constantValue)
-	}
-	sort.Strings(constantValues)
-
-	for _, constantValue := range constantValues {
+	// Generate cases for mapped constants and arms (sorted for determinism)
+	for _, constantValue := range unionCaseConstants(unionConfig) {
 		var encodeCode string
 		var err error
 
@@ -875,7 +1592,7 @@ func (cg *CodeGenerator) generateUnionEncodeCode(field FieldInfo, structInfo Typ
 	}
 
 	// Generate void cases
-	for _, voidCase := range structInfo.UnionConfig.VoidCases {
+	for _, voidCase := range unionConfig.VoidCases {
 		encodeCode, err := cg.tm.ExecuteTemplate("union_case_void", nil)
 		if err != nil {
 			return "", err
@@ -888,24 +1605,33 @@ func (cg *CodeGenerator) generateUnionEncodeCode(field FieldInfo, structInfo Typ
 		})
 	}
 
+	defaultCode, hasDefault, err := cg.generateUnionDefaultCode(field, unionConfig, "encode")
+	if err != nil {
+		return "", err
+	}
+
 	data := FieldData{
 		FieldName:         field.Name,
 		DiscriminantField: keyField,
 		Cases:             templateCases,
+		HasDefaultCase:    hasDefault,
+		DefaultCode:       defaultCode,
+		UnknownPolicy:     resolveUnknownPolicy(unionConfig),
 	}
 	return cg.tm.ExecuteTemplate("union_encode", data)
 }
 
 // generateUnionDecodeCode generates union decode code for a field
 func (cg *CodeGenerator) generateUnionDecodeCode(field FieldInfo, structInfo TypeInfo) (string, error) {
-	keyField := findKeyField(structInfo)
+	keyField := unionKeyForField(field, structInfo)
 	if keyField == "" {
 		return "", fmt.Errorf("no key field found for union field %s", field.Name)
 	}
 
-	if structInfo.UnionConfig == nil {
+	unionConfig := unionConfigForField(field, structInfo)
+	if unionConfig == nil {
 		// Check if this is a void-only union (either explicit default=nil or no union config)
-		if field.DefaultType == "nil" || structInfo.UnionConfig == nil {
+		if field.DefaultType == "nil" || unionConfig == nil {
 			// Generate minimal void-only union decode code
 			data := FieldData{
 				FieldName:         field.Name,
@@ -919,14 +1645,8 @@ func (cg *CodeGenerator) generateUnionDecodeCode(field FieldInfo, structInfo Typ
 
 	var templateCases []UnionCaseData
 
-	// Generate cases for mapped constants (sorted for determinism)
-	var constantValues []string
-	for constantValue := range structInfo.UnionConfig.Cases {
-		constantValues = append(constantValues, constantValue)
-	}
-	sort.Strings(constantValues)
-
-	for _, constantValue := range constantValues {
+	// Generate cases for mapped constants and arms (sorted for determinism)
+	for _, constantValue := range unionCaseConstants(unionConfig) {
 		var decodeCode string
 		var err error
 
@@ -954,7 +1674,7 @@ func (cg *CodeGenerator) generateUnionDecodeCode(field FieldInfo, structInfo Typ
 	}
 
 	// Generate void cases
-	for _, voidCase := range structInfo.UnionConfig.VoidCases {
+	for _, voidCase := range unionConfig.VoidCases {
 		decodeCode, err := cg.tm.ExecuteTemplate("union_case_void", nil)
 		if err != nil {
 			return "", err
@@ -967,14 +1687,78 @@ func (cg *CodeGenerator) generateUnionDecodeCode(field FieldInfo, structInfo Typ
 		})
 	}
 
+	defaultCode, hasDefault, err := cg.generateUnionDefaultCode(field, unionConfig, "decode")
+	if err != nil {
+		return "", err
+	}
+
 	data := FieldData{
 		FieldName:         field.Name,
 		DiscriminantField: keyField,
 		Cases:             templateCases,
+		HasDefaultCase:    hasDefault,
+		DefaultCode:       defaultCode,
+		UnknownPolicy:     resolveUnknownPolicy(unionConfig),
 	}
 	return cg.tm.ExecuteTemplate("union_decode", data)
 }
 
+// unionKeyForField returns the key field name for a union field
+// Embedded unions name their key in the tag; container unions use the struct's key field
+func unionKeyForField(field FieldInfo, structInfo TypeInfo) string {
+	if field.UnionKey != "" {
+		return field.UnionKey
+	}
+	return findKeyField(structInfo)
+}
+
+// unionConfigForField returns the union configuration for a union field
+// Embedded unions carry their own configuration; container unions use the struct's configuration
+func unionConfigForField(field FieldInfo, structInfo TypeInfo) *UnionConfig {
+	if field.UnionConfig != nil {
+		return field.UnionConfig
+	}
+	return structInfo.UnionConfig
+}
+
+// unionCaseConstants returns the sorted discriminants that carry data: payload structs and +xdr:arm arms
+// Both are stored as encoded bytes in the union payload field
+func unionCaseConstants(config *UnionConfig) []string {
+	var constantValues []string
+	for constantValue := range config.Cases {
+		constantValues = append(constantValues, constantValue)
+	}
+	for constantValue := range config.Arms {
+		constantValues = append(constantValues, constantValue)
+	}
+	sort.Strings(constantValues)
+	return constantValues
+}
+
+// generateUnionDefaultCode generates the default case body for a union with default=...
+// A default naming a struct type carries payload bytes; default=nil (or a void constant) is void
+func (cg *CodeGenerator) generateUnionDefaultCode(field FieldInfo, config *UnionConfig, direction string) (string, bool, error) {
+	if config == nil || config.DefaultCase == "" {
+		return "", false, nil
+	}
+	if config.DefaultCase == "nil" || !cg.structTypes[config.DefaultCase] {
+		code, err := cg.tm.ExecuteTemplate("union_case_void", nil)
+		return code, true, err
+	}
+	code, err := cg.tm.ExecuteTemplate("union_case_bytes_"+direction, FieldData{
+		FieldName: field.Name,
+	})
+	return code, true, err
+}
+
+// resolveUnknownPolicy returns the union's unknown discriminant policy, falling back to the global flag
+func resolveUnknownPolicy(config *UnionConfig) string {
+	if config != nil && config.UnknownPolicy != "" {
+		return config.UnknownPolicy
+	}
+	return unknownDiscriminantPolicy
+}
+
 // getEncodeMethod returns the appropriate encoder method for an XDR type
 func (cg *CodeGenerator) getEncodeMethod(xdrType string) string {
 	switch xdrType {
@@ -999,6 +1783,12 @@ func (cg *CodeGenerator) getEncodeMethod(xdrType string) string {
 		if strings.HasPrefix(xdrType, "fixed:") {
 			return "EncodeFixedBytes"
 		}
+		if suffix := timeMethodSuffix(xdrType); suffix != "" {
+			return "Encode" + suffix
+		}
+		if suffix, ok := narrowIntMethods[xdrType]; ok {
+			return "Encode" + suffix
+		}
 		// For unknown types, check if they resolve to a known primitive
 		// This handles cross-package type aliases that should resolve to primitives
 		if strings.Contains(xdrType, ".") {
@@ -1016,6 +1806,22 @@ func (cg *CodeGenerator) getEncodeMethod(xdrType string) string {
 	}
 }
 
+// timeMethodSuffix returns the runtime method suffix for a "time:layout" or "duration:layout" XDR type
+func timeMethodSuffix(xdrType string) string {
+	kind, layout, _ := strings.Cut(xdrType, ":")
+	suffix, ok := timeLayoutMethods[layout]
+	switch {
+	case !ok:
+		return ""
+	case kind == "time":
+		return "Time" + suffix
+	case kind == "duration":
+		return "Duration" + suffix
+	default:
+		return ""
+	}
+}
+
 // getDecodeMethod returns the appropriate decoder method for an XDR type
 func (cg *CodeGenerator) getDecodeMethod(xdrType string) string {
 	switch xdrType {
@@ -1040,6 +1846,12 @@ func (cg *CodeGenerator) getDecodeMethod(xdrType string) string {
 		if strings.HasPrefix(xdrType, "fixed:") {
 			return "DecodeFixedBytes"
 		}
+		if suffix := timeMethodSuffix(xdrType); suffix != "" {
+			return "Decode" + suffix
+		}
+		if suffix, ok := narrowIntMethods[xdrType]; ok {
+			return "Decode" + suffix
+		}
 		// For unknown types, check if they resolve to a known primitive
 		// This handles cross-package type aliases that should resolve to primitives
 		if strings.Contains(xdrType, ".") {
@@ -1063,7 +1875,8 @@ func isPrimitiveType(typeName string) bool {
 		"string": true, "[]byte": true, "uint32": true, "uint64": true,
 		"int32": true, "int64": true, "bool": true, "byte": true,
 	}
-	return primitives[typeName]
+	_, narrow := narrowIntMethods[typeName]
+	return primitives[typeName] || narrow
 }
 
 // isPrimitiveTypeWithAliases checks if a type resolves to a primitive, following type aliases
@@ -1104,7 +1917,7 @@ func (cg *CodeGenerator) resolveAliasToStruct(typeName string) bool {
 	}
 	seen := map[string]bool{}
 	for {
-		if builtins[typeName] {
+		if _, narrow := narrowIntMethods[typeName]; builtins[typeName] || narrow {
 			return false
 		}
 		if cg.structTypes[typeName] {
@@ -1152,6 +1965,12 @@ func (cg *CodeGenerator) getExpectedGoType(xdrType string) string {
 		if strings.HasPrefix(xdrType, "fixed:") {
 			return "[]byte" // Fixed arrays are treated as []byte for encoding
 		}
+		if strings.HasPrefix(xdrType, "time:") {
+			return "time.Time"
+		}
+		if strings.HasPrefix(xdrType, "duration:") {
+			return "time.Duration"
+		}
 		// For struct types and other custom types, return the type as-is
 		return xdrType
 	}
@@ -119,6 +119,197 @@ func TestGenerateDecodeMethod(t *testing.T) {
 	assert.Contains(t, result, "Decode", "Result should contain Decode method")
 }
 
+func TestGenerateDepthTracking(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	typeInfo := TypeInfo{
+		Name:         "TestNode",
+		CanHaveLoops: true,
+		Fields: []FieldInfo{
+			{Name: "Next", Type: "*TestNode", XDRType: "struct"},
+		},
+	}
+
+	encode, err := cg.GenerateEncodeMethod(typeInfo)
+	require.NoError(t, err, "GenerateEncodeMethod failed")
+	assert.Contains(t, encode, "enc.Enter(v)", "Recursive type should enter the encoder")
+	assert.Contains(t, encode, "defer enc.Leave(v)", "Recursive type should leave the encoder")
+	assert.Contains(t, encode, "v.Next.Encode(enc)", "Nested values should use plain Encode")
+
+	decode, err := cg.GenerateDecodeMethod(typeInfo)
+	require.NoError(t, err, "GenerateDecodeMethod failed")
+	assert.Contains(t, decode, "dec.Enter()", "Recursive type should enter the decoder")
+	assert.Contains(t, decode, "defer dec.Leave()", "Recursive type should leave the decoder")
+
+	disableLoopDetection = true
+	defer func() { disableLoopDetection = false }()
+	encode, err = cg.GenerateEncodeMethod(typeInfo)
+	require.NoError(t, err, "GenerateEncodeMethod failed")
+	assert.NotContains(t, encode, "enc.Enter", "Depth tracking should be omitted when disabled")
+}
+
+func TestGenerateListCode(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	node := TypeInfo{
+		Name: "Entry",
+		Fields: []FieldInfo{
+			{Name: "FileID", Type: "uint64", XDRType: "uint64"},
+			{Name: "Next", Type: "*Entry", XDRType: "struct", IsList: true},
+		},
+	}
+	encode, err := cg.GenerateEncodeMethod(node)
+	require.NoError(t, err, "GenerateEncodeMethod failed")
+	assert.Contains(t, encode, "for v := v; v != nil; v = v.Next", "List node should walk the chain iteratively")
+	assert.Contains(t, encode, "enc.EncodeBool(v.Next != nil)", "List node should encode the next flag")
+	assert.NotContains(t, encode, "v.Next.Encode", "List node should not recurse")
+
+	decode, err := cg.GenerateDecodeMethod(node)
+	require.NoError(t, err, "GenerateDecodeMethod failed")
+	assert.Contains(t, decode, "v.Next = &Entry{}", "List node should allocate the next node")
+
+	graph := AnalyzeTypeDependencies([]TypeInfo{node})
+	assert.Empty(t, graph.DetectCycles(), "List links should not count as recursive references")
+
+	slice, err := cg.generateListEncodeCode(FieldInfo{Name: "Entries", Type: "[]Item", IsList: true})
+	require.NoError(t, err, "generateListEncodeCode failed")
+	assert.Contains(t, slice, "enc.EncodeBool(true)", "Slice list should flag each element")
+	assert.Contains(t, slice, "enc.EncodeBool(false)", "Slice list should be terminated")
+
+	head, err := cg.generateListDecodeCode(FieldInfo{Name: "Entries", Type: "*Entry", IsList: true})
+	require.NoError(t, err, "generateListDecodeCode failed")
+	assert.Contains(t, head, "EntriesPresent, err := dec.DecodeBool()", "List head should be optional data")
+	assert.Contains(t, head, "v.Entries = &Entry{}", "List head should allocate the first node")
+}
+
+func TestGenerateMapCode(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	keyType, valueType := splitMapType("map[Label]struct{}")
+	assert.Equal(t, "Label", keyType)
+	assert.Equal(t, "struct{}", valueType)
+
+	scores := FieldInfo{
+		Name: "Scores", Type: "map[Label]Score", XDRType: "map",
+		MapKeyType: "Label", MapKeyXDR: "string", MapValueType: "Score", MapValueXDR: "int32",
+	}
+	encode, err := cg.generateMapEncodeCode(scores)
+	require.NoError(t, err, "generateMapEncodeCode failed")
+	assert.Contains(t, encode, "range xdr.SortedKeys(v.Scores)", "Map should be encoded in key order")
+	assert.Contains(t, encode, "enc.EncodeString(string(key))", "Alias key should be converted")
+	assert.Contains(t, encode, "enc.EncodeInt32(int32(v.Scores[key]))", "Alias value should be converted")
+
+	decode, err := cg.generateMapDecodeCode(scores)
+	require.NoError(t, err, "generateMapDecodeCode failed")
+	assert.Contains(t, decode, "xdr.ErrDuplicateKey", "Map decode should reject duplicate keys")
+	assert.Contains(t, decode, "v.Scores[Label(key)] = Score(value)", "Alias key and value should be converted back")
+
+	members := FieldInfo{
+		Name: "Members", Type: "map[uint32]struct{}", XDRType: "map",
+		MapKeyType: "uint32", MapKeyXDR: "uint32", MapValueType: "struct{}", MapValueXDR: "set",
+	}
+	encode, err = cg.generateMapEncodeCode(members)
+	require.NoError(t, err, "generateMapEncodeCode failed")
+	assert.NotContains(t, encode, "value", "Set should encode keys only")
+
+	decode, err = cg.generateMapDecodeCode(members)
+	require.NoError(t, err, "generateMapDecodeCode failed")
+	assert.Contains(t, decode, "v.Members[key] = struct{}{}", "Set decode should insert keys")
+}
+
+func TestGenerateTimeCode(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	assert.Equal(t, "EncodeTimeNFS", cg.getEncodeMethod("time:nfs"))
+	assert.Equal(t, "DecodeTimeTimespec", cg.getDecodeMethod("time:timespec"))
+	assert.Equal(t, "EncodeDurationUnixNano", cg.getEncodeMethod("duration:unixnano"))
+	assert.Empty(t, timeMethodSuffix("time:rfc3339"), "Unknown layouts have no method")
+
+	birth := FieldInfo{Name: "Birth", Type: "Stamp", XDRType: "time:nfs"}
+	encode, err := cg.generateBasicEncodeCode(birth, TypeInfo{Name: "Attr"})
+	require.NoError(t, err, "generateBasicEncodeCode failed")
+	assert.Contains(t, encode, "enc.EncodeTimeNFS(time.Time(v.Birth))", "Named time should be converted")
+
+	decode, err := cg.generateBasicDecodeCode(birth, TypeInfo{Name: "Attr"})
+	require.NoError(t, err, "generateBasicDecodeCode failed")
+	assert.Contains(t, decode, "v.Birth = Stamp(tempBirth)", "Decoded time should be converted back")
+}
+
+func TestGenerateConverterCode(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	converter := &ConverterDirective{Type: "netip.Addr", Encode: "encodeAddr", Decode: "decodeAddr"}
+	addr := FieldInfo{Name: "Addr", Type: "netip.Addr", ResolvedType: "netip.Addr", XDRType: "converter", Converter: converter}
+	encode, err := cg.generateBasicEncodeCode(addr, TypeInfo{Name: "Endpoint"})
+	require.NoError(t, err, "generateBasicEncodeCode failed")
+	assert.Contains(t, encode, "encodeAddr(enc, v.Addr)", "Field should be passed to the converter")
+
+	decode, err := cg.generateBasicDecodeCode(addr, TypeInfo{Name: "Endpoint"})
+	require.NoError(t, err, "generateBasicDecodeCode failed")
+	assert.Contains(t, decode, "tempAddr, err := decodeAddr(dec)", "Field should be returned by the converter")
+
+	backups := FieldInfo{Name: "Backups", Type: "[]netip.Addr", ResolvedType: "[]netip.Addr", XDRType: "converter", Converter: converter}
+	encode, err = cg.generateBasicEncodeCode(backups, TypeInfo{Name: "Endpoint"})
+	require.NoError(t, err, "generateBasicEncodeCode failed")
+	assert.Contains(t, encode, "encodeAddr(enc, elem)", "Elements should be passed to the converter")
+
+	decode, err = cg.generateBasicDecodeCode(backups, TypeInfo{Name: "Endpoint"})
+	require.NoError(t, err, "generateBasicDecodeCode failed")
+	assert.Contains(t, decode, "val, err := decodeAddr(dec)", "Elements should be returned by the converter")
+}
+
+func TestGenerateGenericCode(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	typeInfo := TypeInfo{
+		Name:          "Pair",
+		TypeParams:    []string{"K", "PK", "V", "PV"},
+		PointerParams: map[string]string{"PK": "K", "PV": "V"},
+		Fields: []FieldInfo{
+			{Name: "Key", Type: "PK", ResolvedType: "PK", XDRType: "struct", TypeParam: "K"},
+			{Name: "Values", Type: "[]PV", ResolvedType: "[]PV", XDRType: "array", TypeParam: "V"},
+		},
+	}
+	encode, err := cg.GenerateEncodeMethod(typeInfo)
+	require.NoError(t, err, "GenerateEncodeMethod failed")
+	assert.Contains(t, encode, "func (v *Pair[K, PK, V, PV]) Encode(enc *xdr.Encoder) error {", "Receiver should list the type parameters")
+
+	decode, err := cg.GenerateDecodeMethod(typeInfo)
+	require.NoError(t, err, "GenerateDecodeMethod failed")
+	assert.Contains(t, decode, "func (v *Pair[K, PK, V, PV]) Decode(dec *xdr.Decoder) error {", "Receiver should list the type parameters")
+	assert.Contains(t, decode, "v.Key = PK(new(K))", "Type parameter fields should be allocated")
+	assert.Contains(t, decode, "v.Values[i] = PV(new(V))", "Type parameter elements should be allocated")
+
+	assertion, err := cg.GenerateTypeAssertion(typeInfo)
+	require.NoError(t, err, "GenerateTypeAssertion failed")
+	assert.Contains(t, assertion, "func _[K any, PK interface{ *K; xdr.Codec }, V any, PV interface{ *V; xdr.Codec }]() {", "Assertion should hold for every instantiation")
+	assert.Contains(t, assertion, "(*Pair[K, PK, V, PV])(nil)", "Assertion should instantiate with the type parameters")
+
+	page := TypeInfo{
+		Name:        "Page",
+		TypeParams:  []string{"T"},
+		CodecParams: map[string]bool{"T": true},
+		Fields: []FieldInfo{
+			{Name: "Head", Type: "T", ResolvedType: "T", XDRType: "struct", CodecParam: true},
+			{Name: "Items", Type: "[]T", ResolvedType: "[]T", XDRType: "array", CodecParam: true},
+		},
+	}
+	decode, err = cg.GenerateDecodeMethod(page)
+	require.NoError(t, err, "GenerateDecodeMethod failed")
+	assert.Contains(t, decode, "v.Head = xdr.NewCodec[T]()", "Codec type parameter fields should be allocated")
+	assert.Contains(t, decode, "v.Items[i] = xdr.NewCodec[T]()", "Codec type parameter elements should be allocated")
+
+	assertion, err = cg.GenerateTypeAssertion(page)
+	require.NoError(t, err, "GenerateTypeAssertion failed")
+	assert.Contains(t, assertion, "func _[T xdr.Codec]() {", "Assertion should use the Codec constraint")
+}
+
 func TestGenerateAssertion(t *testing.T) {
 	cg, err := NewCodeGenerator([]string{}, map[string]string{})
 	require.NoError(t, err, "NewCodeGenerator failed")
@@ -246,6 +437,37 @@ func TestGenerateArrayDecodeCode(t *testing.T) {
 	assert.Contains(t, result, "DecodeUint32", "Result should contain array length decoding")
 }
 
+func TestGenerateNestedArrayCode(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{"Point"}, map[string]string{"Row": "[]uint16", "Digest": "[4]byte"})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	dims, elementType := cg.splitArrayDimensions("[2][]Row")
+	assert.Equal(t, []arrayDimension{{GoType: "[2][]Row", Length: "2"}, {GoType: "[]Row"}, {GoType: "Row"}}, dims)
+	assert.Equal(t, "uint16", elementType)
+	dims, elementType = cg.splitArrayDimensions("[][]Digest")
+	assert.Len(t, dims, 2, "Byte arrays should be elements, not dimensions")
+	assert.Equal(t, "Digest", elementType)
+
+	grid := FieldInfo{Name: "Grid", Type: "[][3]*Point", ResolvedType: "[][3]*Point", XDRType: "struct", MaxLengths: []string{"8"}}
+	encode, err := cg.generateBasicEncodeCode(grid, TypeInfo{Name: "Board"})
+	require.NoError(t, err, "generateBasicEncodeCode failed")
+	assert.Contains(t, encode, "if len(v.Grid) > 8 {", "Variable dimension should be bounded")
+	assert.Contains(t, encode, "if err := v.Grid[i][j].Encode(enc); err != nil {", "Elements should be indexed per dimension")
+
+	decode, err := cg.generateBasicDecodeCode(grid, TypeInfo{Name: "Board"})
+	require.NoError(t, err, "generateBasicDecodeCode failed")
+	assert.Contains(t, decode, "if GridLen > 8 {", "Decoded length should be checked before allocating")
+	assert.Contains(t, decode, "v.Grid = make([][3]*Point, GridLen)")
+	assert.Contains(t, decode, "v.Grid[i][j] = &Point{}", "Pointer elements should be allocated")
+
+	grid.MaxLengths = []string{"", "4"}
+	_, err = cg.generateBasicEncodeCode(grid, TypeInfo{Name: "Board"})
+	assert.Error(t, err, "Bounds on fixed-size dimensions should be rejected")
+	grid.MaxLengths = []string{"1", "2", "3"}
+	_, err = cg.generateBasicEncodeCode(grid, TypeInfo{Name: "Board"})
+	assert.Error(t, err, "More bounds than dimensions should be rejected")
+}
+
 func TestGetEncodeMethod(t *testing.T) {
 	cg, err := NewCodeGenerator([]string{}, map[string]string{})
 	require.NoError(t, err, "NewCodeGenerator failed")
@@ -258,6 +480,9 @@ func TestGetEncodeMethod(t *testing.T) {
 		{"uint64", "EncodeUint64"},
 		{"int32", "EncodeInt32"},
 		{"int64", "EncodeInt64"},
+		{"uint8", "EncodeUint8"},
+		{"int16", "EncodeInt16"},
+		{"uint", "EncodeUint"},
 		{"string", "EncodeString"},
 		{"bytes", "EncodeBytes"},
 		{"bool", "EncodeBool"},
@@ -286,6 +511,9 @@ func TestGetDecodeMethod(t *testing.T) {
 		{"uint64", "DecodeUint64"},
 		{"int32", "DecodeInt32"},
 		{"int64", "DecodeInt64"},
+		{"uint8", "DecodeUint8"},
+		{"int16", "DecodeInt16"},
+		{"uint", "DecodeUint"},
 		{"string", "DecodeString"},
 		{"bytes", "DecodeBytes"},
 		{"bool", "DecodeBool"},
@@ -469,6 +697,350 @@ func TestGenerateUnionEncodeDecodeCodeErrors(t *testing.T) {
 	})
 }
 
+func TestGenerateUnionUnknownPolicy(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+	cg.structTypes["FallbackResult"] = true
+
+	tests := []struct {
+		name        string
+		config      UnionConfig
+		wantEncode  string
+		wantDecode  string
+		notContains string
+	}{
+		{
+			name:       "void",
+			config:     UnionConfig{UnknownPolicy: UnknownPolicyVoid},
+			wantEncode: "// unknown key - encode nothing",
+			wantDecode: "// unknown key - decode nothing",
+		},
+		{
+			name:       "reject",
+			config:     UnionConfig{UnknownPolicy: UnknownPolicyReject},
+			wantEncode: "xdr.ErrUnknownDiscriminant, v.Type)",
+			wantDecode: "xdr.ErrUnknownDiscriminant, v.Type)",
+		},
+		{
+			name:       "capture",
+			config:     UnionConfig{UnknownPolicy: UnknownPolicyCapture},
+			wantEncode: "// unknown key - encode the captured arm",
+			wantDecode: "// unknown key - capture the opaque arm",
+		},
+		{
+			name:        "default struct overrides policy",
+			config:      UnionConfig{DefaultCase: "FallbackResult", UnknownPolicy: UnknownPolicyReject},
+			wantEncode:  "enc.EncodeBytes(v.Data)",
+			wantDecode:  "v.Data, err = dec.DecodeBytes()",
+			notContains: "ErrUnknownDiscriminant",
+		},
+		{
+			name:        "default nil overrides policy",
+			config:      UnionConfig{DefaultCase: "nil", UnknownPolicy: UnknownPolicyCapture},
+			wantEncode:  "default:",
+			wantDecode:  "default:",
+			notContains: "captured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.ContainerType = "TestUnion"
+			config.Cases = map[string]string{"SUCCESS": "SuccessResult"}
+			structInfo := TypeInfo{
+				Name: "TestUnion",
+				Fields: []FieldInfo{
+					{Name: "Type", Type: "uint32", XDRType: "uint32", IsKey: true},
+					{Name: "Data", Type: "[]byte", XDRType: "union", IsUnion: true},
+				},
+				IsDiscriminatedUnion: true,
+				UnionConfig:          &config,
+			}
+
+			encode, err := cg.generateUnionEncodeCode(structInfo.Fields[1], structInfo)
+			require.NoError(t, err)
+			assert.Contains(t, encode, tt.wantEncode)
+
+			decode, err := cg.generateUnionDecodeCode(structInfo.Fields[1], structInfo)
+			require.NoError(t, err)
+			assert.Contains(t, decode, tt.wantDecode)
+
+			if tt.notContains != "" {
+				assert.NotContains(t, encode, tt.notContains)
+				assert.NotContains(t, decode, tt.notContains)
+			}
+		})
+	}
+}
+
+func TestGenerateUnionArm(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	result, err := cg.GenerateUnionArm(ArmConfig{
+		UnionType:    "Result",
+		Discriminant: "ResOK",
+		ArmType:      "uint64",
+		AccessorName: "ResOK",
+		WrapperType:  "resultResOKArm",
+		KeyField:     "Status",
+		PayloadField: "Body",
+	})
+	require.NoError(t, err, "GenerateUnionArm failed")
+
+	assert.Contains(t, result, "type resultResOKArm struct {\n\tValue uint64\n}")
+	assert.Contains(t, result, "func (v *Result) ResOK() (uint64, error) {")
+	assert.Contains(t, result, "if v.Status != ResOK {")
+	assert.Contains(t, result, "func (v *Result) SetResOK(val uint64) error {")
+	assert.Contains(t, result, "v.Body = data")
+
+	_, err = cg.GenerateUnionArm(ArmConfig{UnionType: "Result", Discriminant: "ResOK", ArmType: "uint64"})
+	require.Error(t, err, "expected error for missing key and payload fields")
+}
+
+func TestGenerateFixedFieldCode(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+	header := TypeInfo{Name: "Header"}
+
+	magic := FieldInfo{Name: "_", Type: "uint32", XDRType: "uint32", Const: "0xDEADBEEF"}
+	encode, err := cg.generateFixedFieldCode(magic, header, 0, "encode")
+	require.NoError(t, err)
+	assert.Contains(t, encode, "enc.EncodeUint32(0xDEADBEEF)")
+	decode, err := cg.generateFixedFieldCode(magic, header, 0, "decode")
+	require.NoError(t, err)
+	assert.Contains(t, decode, "if blank0 != 0xDEADBEEF {")
+	assert.Contains(t, decode, "Header constant is %#x, expected 0xDEADBEEF")
+	assert.NotContains(t, decode, "v._", "Blank constants are only verified")
+
+	version := FieldInfo{Name: "Version", Type: "Version", XDRType: "uint16", Const: "2"}
+	decode, err = cg.generateFixedFieldCode(version, header, 1, "decode")
+	require.NoError(t, err)
+	assert.Contains(t, decode, "tempVersion, err := dec.DecodeUint16()")
+	assert.Contains(t, decode, "v.Version = Version(tempVersion)")
+
+	reserved := FieldInfo{Name: "_", Type: "[8]byte", XDRType: "reserved", Reserved: 8}
+	encode, err = cg.generateFixedFieldCode(reserved, header, 2, "encode")
+	require.NoError(t, err)
+	assert.Contains(t, encode, "enc.EncodeReserved(8)")
+	decode, err = cg.generateFixedFieldCode(reserved, header, 2, "decode")
+	require.NoError(t, err)
+	assert.Contains(t, decode, "dec.SkipReserved(8)")
+	reserved.StrictZero = true
+	decode, err = cg.generateFixedFieldCode(reserved, header, 2, "decode")
+	require.NoError(t, err)
+	assert.Contains(t, decode, "dec.DecodeReserved(8)")
+}
+
+func TestGenerateChecksumCode(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	typeInfo := TypeInfo{
+		Name: "Frame",
+		Fields: []FieldInfo{
+			{Name: "Size", Type: "uint32", XDRType: "uint32", Checksum: "len", Covers: []string{"Body"}},
+			{Name: "Seq", Type: "uint32", XDRType: "uint32"},
+			{Name: "Body", Type: "[]byte", ResolvedType: "[]byte", XDRType: "bytes"},
+			{Name: "Sum", Type: "uint32", XDRType: "uint32", Checksum: "crc32c", Covers: []string{"Seq", "Body"}},
+		},
+	}
+	encode, err := cg.GenerateEncodeMethod(typeInfo)
+	require.NoError(t, err)
+	assert.Contains(t, encode, "posSize := enc.Len()")
+	assert.Contains(t, encode, "startBody := enc.Len()")
+	assert.Contains(t, encode, "enc.PatchUint32(posSize, xdr.ByteLength(enc.Bytes()[startBody:endBody]))")
+	assert.Contains(t, encode, "xdr.ChecksumCRC32C(enc.Bytes()[startSeq:endSeq], enc.Bytes()[startBody:endBody])")
+	assert.NotContains(t, encode, "v.Sum", "Checksums are computed, not read from the struct")
+
+	decode, err := cg.GenerateDecodeMethod(typeInfo)
+	require.NoError(t, err)
+	assert.Contains(t, decode, "startSeq := dec.Position()")
+	assert.Contains(t, decode, "sum != tempSize")
+	assert.Contains(t, decode, "Size is %d, computed %d over Body")
+	assert.Contains(t, decode, "Sum is %#x, computed %#x over Seq and Body")
+}
+
+func TestGenerateOpaqueField(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	typeInfo := TypeInfo{
+		Name: "Call",
+		Fields: []FieldInfo{
+			{Name: "Args", Type: "Args", XDRType: "struct", Opaque: true},
+			{Name: "Cred", Type: "*Cred", XDRType: "struct", Opaque: true},
+		},
+	}
+	encode, err := cg.GenerateEncodeMethod(typeInfo)
+	require.NoError(t, err)
+	assert.Contains(t, encode, "enc.EncodeOpaque(&v.Args)")
+	assert.Contains(t, encode, "if v.Cred == nil {")
+	assert.Contains(t, encode, "enc.EncodeOpaque(v.Cred)")
+
+	decode, err := cg.GenerateDecodeMethod(typeInfo)
+	require.NoError(t, err)
+	assert.Contains(t, decode, "dec.DecodeOpaque(&v.Args)")
+	assert.Contains(t, decode, "v.Cred = &Cred{}")
+	assert.Contains(t, decode, "dec.DecodeOpaque(v.Cred)")
+}
+
+func TestGenerateNamedType(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	result, err := cg.GenerateNamedType(TypeInfo{
+		Name:       "Path",
+		Underlying: "string",
+		Fields:     []FieldInfo{{Name: "Value", Type: "string", XDRType: "string"}},
+	})
+	require.NoError(t, err, "GenerateNamedType failed")
+
+	assert.Contains(t, result, "type pathValue struct {\n\tValue string\n}")
+	assert.Contains(t, result, "func (v *pathValue) Encode(enc *xdr.Encoder) error {")
+	assert.Contains(t, result, "func (v *Path) Encode(enc *xdr.Encoder) error {")
+	assert.Contains(t, result, "w := pathValue{Value: string(*v)}")
+	assert.Contains(t, result, "*v = Path(w.Value)")
+}
+
+func TestGenerateExtUnion(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	result, err := cg.GenerateExtUnion(ExtConfig{
+		TypeName: "AccountExt",
+		Versions: []ExtVersion{
+			{Number: 1, Field: "V1", Type: "AccountExtV1"},
+			{Number: 2, Field: "V2", Type: "AccountExtV2"},
+		},
+	})
+	require.NoError(t, err, "GenerateExtUnion failed")
+
+	assert.Contains(t, result, "const AccountExtMaxVersion int32 = 2")
+	assert.Contains(t, result, "V2 *AccountExtV2")
+	assert.Contains(t, result, "case 0:\n\tcase 1:")
+	assert.Contains(t, result, "v.V2 = &AccountExtV2{}")
+	assert.Contains(t, result, "func (v *AccountExt) Latest() int32 {\n\tif v.V2 != nil {")
+	assert.Contains(t, result, "func (v *AccountExt) EncodeVersion(enc *xdr.Encoder, version int32) error {")
+	assert.Contains(t, result, "xdr.ErrUnknownDiscriminant")
+}
+
+func TestGenerateInterfaceField(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	typeInfo := TypeInfo{
+		Name:   "Message",
+		Fields: []FieldInfo{{Name: "Plugin", Type: "Plugin", XDRType: "interface", Registry: "PluginRegistry"}},
+	}
+	encode, err := cg.GenerateEncodeMethod(typeInfo)
+	require.NoError(t, err, "GenerateEncodeMethod failed")
+	assert.Contains(t, encode, "if v.Plugin == nil {")
+	assert.Contains(t, encode, "PluginRegistry.Encode(enc, v.Plugin)")
+
+	decode, err := cg.GenerateDecodeMethod(typeInfo)
+	require.NoError(t, err, "GenerateDecodeMethod failed")
+	assert.Contains(t, decode, "tempPlugin, err := xdr.DecodeInterface[Plugin](dec, PluginRegistry)")
+	assert.Contains(t, decode, "v.Plugin = tempPlugin")
+
+	registry, err := cg.GenerateRegistry(RegistryConfig{
+		Interface: "Plugin",
+		Registry:  "PluginRegistry",
+		Types:     []*RegisterDirective{{Interface: "Plugin", Type: "Gzip", ID: "GzipID"}},
+	})
+	require.NoError(t, err, "GenerateRegistry failed")
+	assert.Contains(t, registry, "var PluginRegistry = xdr.NewTypeRegistry()")
+	assert.Contains(t, registry, "PluginRegistry.MustRegister(uint32(GzipID), func() xdr.Codec { return &Gzip{} })")
+}
+
+func TestGenerateEnvelopeRegistrations(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	result, err := cg.GenerateEnvelopeRegistrations([]*EnvelopeDirective{
+		{Type: "Created", ID: "0x10", Version: "2"},
+		{Type: "Deleted", ID: "DeletedID", Version: "1"},
+	})
+	require.NoError(t, err, "GenerateEnvelopeRegistrations failed")
+	assert.Contains(t, result, "func init() {")
+	assert.Contains(t, result, "xdr.MustRegister[Created](0x10, 2)")
+	assert.Contains(t, result, "xdr.MustRegister[Deleted](uint32(DeletedID), 1)")
+}
+
+func TestGenerateUnionMux(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	typeInfo := TypeInfo{
+		Name:                 "Request",
+		IsDiscriminatedUnion: true,
+		UnionConfig: &UnionConfig{
+			ContainerType:    "Request",
+			DiscriminantType: "Op",
+			DiscriminantXDR:  "uint32",
+			Mux:              true,
+			Cases:            map[string]string{"OpRead": "ReadArgs"},
+			Arms:             map[string]string{"OpSize": "uint64"},
+			VoidCases:        []string{"OpNull"},
+		},
+	}
+	result, err := cg.GenerateUnionMux(typeInfo)
+	require.NoError(t, err, "GenerateUnionMux failed")
+
+	assert.Contains(t, result, "type RequestMux struct {\n\t*xdr.Mux[Op]\n}")
+	assert.Contains(t, result, "func (m *RequestMux) HandleOpNull(handler func() error) {\n\txdr.HandleVoid(m.Mux, OpNull, handler)")
+	assert.Contains(t, result, "func (m *RequestMux) HandleOpRead(handler func(*ReadArgs) error) {\n\txdr.Handle(m.Mux, OpRead, handler)")
+	assert.Contains(t, result, "xdr.Handle(m.Mux, OpSize, func(arm *requestOpSizeArm) error {")
+	assert.Less(t, strings.Index(result, "HandleOpNull"), strings.Index(result, "HandleOpRead"), "Cases should be in name order")
+
+	typeInfo.UnionConfig.DiscriminantXDR = "bool"
+	_, err = cg.GenerateUnionMux(typeInfo)
+	assert.Error(t, err, "Mux requires a 32-bit key")
+
+	typeInfo.UnionConfig.DiscriminantXDR = "int32"
+	typeInfo.UnionConfig.VoidCases = []string{"Unknown"}
+	_, err = cg.GenerateUnionMux(typeInfo)
+	assert.Error(t, err, "HandleUnknown is the fallback")
+}
+
+func TestGenerateEmbeddedUnionCode(t *testing.T) {
+	cg, err := NewCodeGenerator([]string{}, map[string]string{})
+	require.NoError(t, err, "NewCodeGenerator failed")
+
+	typeInfo := TypeInfo{
+		Name: "Reply",
+		Fields: []FieldInfo{
+			{Name: "Status", Type: "Stat", XDRType: "int32"},
+			{Name: "Result", Type: "[]byte", XDRType: "bytes", IsUnion: true, UnionKey: "Status", UnionConfig: &UnionConfig{
+				ContainerType: "Reply.Result",
+				Cases:         map[string]string{"StatOK": "ReadOK"},
+				VoidCases:     []string{"StatPerm"},
+			}},
+			{Name: "AttrFollows", Type: "bool", XDRType: "bool"},
+			{Name: "Attr", Type: "[]byte", XDRType: "bytes", IsUnion: true, UnionKey: "AttrFollows", UnionConfig: &UnionConfig{
+				ContainerType: "Reply.Attr",
+				Cases:         map[string]string{"true": "Attr"},
+				VoidCases:     []string{"false"},
+				UnknownPolicy: UnknownPolicyReject,
+			}},
+		},
+	}
+
+	encodeResult, err := cg.GenerateEncodeMethod(typeInfo)
+	require.NoError(t, err, "GenerateEncodeMethod failed")
+	assert.Contains(t, encodeResult, "switch v.Status {")
+	assert.Contains(t, encodeResult, "case StatOK:")
+	assert.Contains(t, encodeResult, "switch v.AttrFollows {")
+	assert.Contains(t, encodeResult, "case true:")
+	assert.Contains(t, encodeResult, "xdr.ErrUnknownDiscriminant, v.AttrFollows)")
+
+	decodeResult, err := cg.GenerateDecodeMethod(typeInfo)
+	require.NoError(t, err, "GenerateDecodeMethod failed")
+	assert.Contains(t, decodeResult, "v.Result, err = dec.DecodeBytes()")
+	assert.Contains(t, decodeResult, "v.Attr, err = dec.DecodeBytes()")
+}
+
 func TestArrayAliasResolution(t *testing.T) {
 	// Test that slice aliases don't get [:] conversion but fixed array aliases do
 	tests := []struct {
@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // FieldInfo represents a struct field with XDR encoding information
 type FieldInfo struct {
@@ -9,15 +12,40 @@ type FieldInfo struct {
 	ResolvedType string // Resolved underlying type (e.g., "[]byte")
 	XDRType      string
 	Tag          string
-	IsKey        bool   // true if this field is a discriminated union key
-	IsUnion      bool   // true if this field is a discriminated union payload
-	DefaultType  string // default type from union tag (empty, "nil", or struct name)
+	IsKey        bool                // true if this field is a discriminated union key
+	IsUnion      bool                // true if this field is a discriminated union payload
+	DefaultType  string              // default type from union tag (empty, "nil", or struct name)
+	UnionKey     string              // key field name for a union embedded via xdr:"union=KeyField"
+	UnionConfig  *UnionConfig        // union configuration for an embedded union field
+	IsList       bool                // encoded as an XDR optional-data linked list (xdr:"list")
+	MapKeyType   string              // Go key type of a map field
+	MapKeyXDR    string              // XDR type of the map key: "string", "uint32", "uint64", "int32" or "int64"
+	MapValueType string              // Go value type of a map field ("struct{}" for sets)
+	MapValueXDR  string              // XDR type of the map value: a primitive, "struct" or "set"
+	Converter    *ConverterDirective // user encode/decode functions for the field or its elements (+xdr:converter)
+	TypeParam    string              // the field or its array elements are of pointer type parameter PT; holds the T it points to
+	CodecParam   bool                // the field or its array elements are of type parameter T xdr.Codec (allocated with xdr.NewCodec)
+	MaxLengths   []string            // per-dimension length bounds of an array, outermost first, empty for unbounded (xdr:"max=A|B")
+	Const        string              // wire value of a constant field, written on encode and verified on decode (xdr:"const=V")
+	Reserved     int                 // size in bytes of a reserved area written as zeros (xdr:"reserved=N")
+	StrictZero   bool                // reserved bytes must be zero on decode (xdr:"reserved=N,strict")
+	Opaque       bool                // struct encoded as length-prefixed opaque data and decoded within its length (xdr:"opaque")
+	Checksum     string              // algorithm of a checksum or length field: crc32, crc32c, adler32 or len (xdr:"crc32=Body")
+	Covers       []string            // fields a checksum or length field is computed over, in wire order
+	Registry     string              // generated registry of an interface field's type, e.g., "PluginRegistry"
+}
+
+// isListLink reports whether the field is the self-referential next pointer of a list node
+func (f FieldInfo) isListLink(typeName string) bool {
+	return f.IsList && f.Type == "*"+typeName
 }
 
 // PayloadConfig represents configuration for payload structs
 type PayloadConfig struct {
-	UnionType     string // e.g., "NetworkMessage"
-	Discriminant  string // e.g., "MessageTypeText"
+	UnionType       string // e.g., "NetworkMessage"
+	UnionField      string // union field name for unions embedded in ordinary structs (optional)
+	Discriminant    string // e.g., "MessageTypeText"
+	DiscriminantXDR string // XDR type of the union discriminant (empty = uint32)
 }
 
 // TypeInfo represents a struct that needs XDR generation
@@ -28,16 +56,145 @@ type TypeInfo struct {
 	IsPayload            bool // true if this struct is a payload for a union
 	UnionConfig          *UnionConfig
 	PayloadConfig        *PayloadConfig
-	CanHaveLoops         bool // determined by static analysis of type dependencies
+	ArmConfig            *ArmConfig        // set on generated wrapper types for +xdr:arm union arms
+	Underlying           string            // underlying Go type of a named non-struct type, encoded through a wrapper struct
+	CanHaveLoops         bool              // determined by static analysis of type dependencies
+	TypeParams           []string          // type parameter names of a generic struct, in declaration order
+	PointerParams        map[string]string // pointer type parameter PT interface{ *T; xdr.Codec } -> T
+	CodecParams          map[string]bool   // type parameters T xdr.Codec, instantiated with pointer types such as *Entry
+}
+
+// typeParamList returns the type parameter list for method receivers ("[K, V]"), empty if not generic
+func (t TypeInfo) typeParamList() string {
+	if len(t.TypeParams) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(t.TypeParams, ", ") + "]"
+}
+
+// typeParamDecl returns the type parameter list with constraints ("[T any, PT interface{ *T; xdr.Codec }]" or "[T xdr.Codec]")
+// Used by the compile-time assertion, which is a generic function since the type has no single instance
+func (t TypeInfo) typeParamDecl() string {
+	params := make([]string, len(t.TypeParams))
+	for i, name := range t.TypeParams {
+		if pointee, ok := t.PointerParams[name]; ok {
+			params[i] = name + " interface{ *" + pointee + "; xdr.Codec }"
+		} else if t.CodecParams[name] {
+			params[i] = name + " xdr.Codec"
+		} else {
+			params[i] = name + " any"
+		}
+	}
+	return "[" + strings.Join(params, ", ") + "]"
 }
 
 // UnionConfig represents discriminated union configuration
 type UnionConfig struct {
-	ContainerType   string            // e.g., "OperationResult"
+	ContainerType    string            // e.g., "OperationResult"
 	DiscriminantType string            // e.g., "MessageType"
-	DefaultCase     string            // struct name for default case (if any)
-	Cases           map[string]string // constant name -> struct name
-	VoidCases       []string          // constant names that are void
+	DiscriminantXDR  string            // XDR type of the discriminant: "uint32", "int32" or "bool"
+	DefaultCase      string            // struct name for default case (if any)
+	UnknownPolicy    string            // unknown discriminant policy from the directive (empty = global flag)
+	Exhaustive       bool              // every constant of the key type must have a payload, arm or void declaration
+	Mux              bool              // generate a typed xdr.Mux dispatching the union's messages (container unions only)
+	DeclaredVoid     []string          // void discriminants from void=A|B (nil = infer from the key type's constants)
+	DeclaredArms     []string          // payload and arm discriminants from arms=A|B, checked against the directives (nil = not declared)
+	Cases            map[string]string // constant name -> struct name
+	Arms             map[string]string // constant name -> primitive/array Go type (from +xdr:arm)
+	VoidCases        []string          // constant names that are void
+}
+
+// ArmConfig represents a primitive, alias or array union arm declared with +xdr:arm
+type ArmConfig struct {
+	UnionType    string // e.g., "Result"
+	Discriminant string // e.g., "ResOK"
+	ArmType      string // Go type of the arm, e.g., "uint64"
+	AccessorName string // exported accessor name, e.g., "ResOK"
+	WrapperType  string // generated wrapper struct, e.g., "resultResOKArm"
+	KeyField     string // union key field name
+	PayloadField string // union payload field name
+}
+
+// Unknown discriminant policies for unions without a default case
+const (
+	UnknownPolicyVoid    = "void"    // treat unknown discriminants as void (encode/decode nothing)
+	UnknownPolicyReject  = "reject"  // return xdr.ErrUnknownDiscriminant
+	UnknownPolicyCapture = "capture" // capture the opaque arm in the payload field
+)
+
+// Wire layouts for time.Time and time.Duration fields, selected with xdr:"time=layout"
+const (
+	TimeLayoutNFS      = "nfs"      // uint32 seconds, uint32 nanoseconds
+	TimeLayoutTimespec = "timespec" // int64 seconds, uint32 nanoseconds (default for time.Time)
+	TimeLayoutUnixNano = "unixnano" // int64 nanoseconds (default for time.Duration)
+)
+
+// timeLayoutMethods maps a time layout to the suffix of its runtime Encode/Decode methods
+var timeLayoutMethods = map[string]string{
+	TimeLayoutNFS:      "NFS",
+	TimeLayoutTimespec: "Timespec",
+	TimeLayoutUnixNano: "UnixNano",
+}
+
+// narrowIntMethods maps integers XDR widens to 32 or 64 bits to the suffix of their runtime Encode/Decode methods
+var narrowIntMethods = map[string]string{
+	"uint8":  "Uint8",
+	"byte":   "Uint8",
+	"uint16": "Uint16",
+	"int8":   "Int8",
+	"int16":  "Int16",
+	"int":    "Int",
+	"uint":   "Uint",
+}
+
+// checksumFuncs maps the checksum and length tags to the runtime functions computing them
+var checksumFuncs = map[string]string{
+	"crc32":   "ChecksumCRC32",
+	"crc32c":  "ChecksumCRC32C",
+	"adler32": "ChecksumAdler32",
+	"len":     "ByteLength",
+}
+
+// fieldUnionName returns the union identity of a union embedded in an ordinary struct
+func fieldUnionName(typeName, fieldName string) string {
+	return typeName + "." + fieldName
+}
+
+// unionName returns the union identity a payload belongs to
+func (p *PayloadConfig) unionName() string {
+	if p.UnionField != "" {
+		return fieldUnionName(p.UnionType, p.UnionField)
+	}
+	return p.UnionType
+}
+
+// resolveDiscriminantXDRType resolves a discriminant Go type to its XDR type through aliases
+// Returns "" if the type is not a valid discriminant (uint32, int32, bool or an alias of one)
+func resolveDiscriminantXDRType(typeName string, typeAliases map[string]string) string {
+	seen := make(map[string]bool)
+	for !seen[typeName] {
+		switch typeName {
+		case "uint32", "int32", "bool":
+			return typeName
+		}
+		seen[typeName] = true
+		underlying, ok := typeAliases[typeName]
+		if !ok {
+			return ""
+		}
+		typeName = underlying
+	}
+	return ""
+}
+
+// isValidUnknownPolicy checks if a policy name is one of the supported unknown discriminant policies
+func isValidUnknownPolicy(policy string) bool {
+	switch policy {
+	case UnknownPolicyVoid, UnknownPolicyReject, UnknownPolicyCapture:
+		return true
+	default:
+		return false
+	}
 }
 
 // ValidationError represents a validation error
@@ -48,4 +205,4 @@ type ValidationError struct {
 
 func (e ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Location, e.Message)
-}
\ No newline at end of file
+}
@@ -3,10 +3,11 @@ package main
 import (
 	"fmt"
 	"go/ast"
+	"strconv"
 )
 
 // validateUnionConfiguration validates discriminated union configuration
-func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantInfo, typeDefs map[string]ast.Node, file *ast.File) []ValidationError {
+func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantInfo, typeDefs map[string]ast.Node, typeAliases map[string]string, file *ast.File) []ValidationError {
 	var errors []ValidationError
 
 	// Helper to resolve type aliases recursively
@@ -53,7 +54,14 @@ func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantI
 	unionConfigs := make(map[string]*UnionConfig)
 	defaultCount := 0
 
+	embeddedKeys := make(map[string]FieldInfo) // embedded union name -> key field
+
+	namedTypes := make(map[string]bool) // named non-struct types with generated Codec methods
+
 	for _, typeInfo := range types {
+		if typeInfo.Underlying != "" {
+			namedTypes[typeInfo.Name] = true
+		}
 		if typeInfo.UnionConfig != nil {
 			unionConfigs[typeInfo.UnionConfig.ContainerType] = typeInfo.UnionConfig
 
@@ -62,6 +70,19 @@ func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantI
 				defaultCount++
 			}
 		}
+
+		// Unions embedded in ordinary structs are validated like containers, keyed by Type.Field
+		for _, field := range typeInfo.Fields {
+			if field.UnionConfig == nil {
+				continue
+			}
+			unionConfigs[field.UnionConfig.ContainerType] = field.UnionConfig
+			for _, keyField := range typeInfo.Fields {
+				if keyField.Name == field.UnionKey {
+					embeddedKeys[field.UnionConfig.ContainerType] = keyField
+				}
+			}
+		}
 	}
 
 	// Validate default count (0 or 1)
@@ -74,30 +95,34 @@ func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantI
 
 	// Validate each union configuration
 	for containerType, config := range unionConfigs {
-		// Find the container struct to get its key field
-		var containerStruct TypeInfo
-		found := false
-		for _, typeInfo := range types {
-			if typeInfo.Name == containerType {
-				containerStruct = typeInfo
-				found = true
-				break
+		var keyField FieldInfo
+		if embeddedKey, embedded := embeddedKeys[containerType]; embedded {
+			keyField = embeddedKey
+		} else {
+			// Find the container struct to get its key field
+			var containerStruct TypeInfo
+			found := false
+			for _, typeInfo := range types {
+				if typeInfo.Name == containerType {
+					containerStruct = typeInfo
+					found = true
+					break
+				}
+			}
+			if !found {
+				errors = append(errors, ValidationError{
+					Location: fmt.Sprintf("union=%s", containerType),
+					Message:  fmt.Sprintf("container type %s not found", containerType),
+				})
+				continue
 			}
-		}
-		if !found {
-			errors = append(errors, ValidationError{
-				Location: fmt.Sprintf("union=%s", containerType),
-				Message:  fmt.Sprintf("container type %s not found", containerType),
-			})
-			continue
-		}
 
-		// Find the key field to get the discriminant type
-		var keyField FieldInfo
-		for _, field := range containerStruct.Fields {
-			if field.IsKey {
-				keyField = field
-				break
+			// Find the key field to get the discriminant type
+			for _, field := range containerStruct.Fields {
+				if field.IsKey {
+					keyField = field
+					break
+				}
 			}
 		}
 		if keyField.Name == "" {
@@ -108,52 +133,16 @@ func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantI
 			continue
 		}
 
-		// Validate that the key field type is a uint32 alias
-		keyFieldGenDecl, exists := typeDefs[keyField.Type]
-		if !exists {
-			errors = append(errors, ValidationError{
-				Location: fmt.Sprintf("union=%s,key=%s", containerType, keyField.Name),
-				Message:  fmt.Sprintf("key field type %s not found in current file(s). If this type is defined in another file, process the entire package instead of individual files", keyField.Type),
-			})
-			continue
-		}
-
-		// Extract the actual type from the GenDecl
-		var keyFieldNode ast.Expr
-		if genDecl, ok := keyFieldGenDecl.(*ast.GenDecl); ok {
-			for _, spec := range genDecl.Specs {
-				if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == keyField.Type {
-					keyFieldNode = typeSpec.Type
-					break
-				}
+		// Validate that the key field type is uint32, int32 or bool, or an alias of one
+		keyXDRType := resolveDiscriminantXDRType(keyField.Type, typeAliases)
+		if keyXDRType == "" {
+			message := fmt.Sprintf("key field type %s must be a uint32, int32 or bool alias", keyField.Type)
+			if _, exists := typeAliases[keyField.Type]; !exists {
+				message = fmt.Sprintf("key field type %s not found in current file(s). If this type is defined in another file, process the entire package instead of individual files", keyField.Type)
 			}
-		} else {
-			// Fallback for cases where it's not a GenDecl
-			keyFieldNode = keyFieldGenDecl.(ast.Expr)
-		}
-
-		if keyFieldNode == nil {
-			errors = append(errors, ValidationError{
-				Location: fmt.Sprintf("union=%s,key=%s", containerType, keyField.Name),
-				Message:  fmt.Sprintf("could not extract type information for key field type %s", keyField.Type),
-			})
-			continue
-		}
-
-		// Check that the key field type is a uint32 alias
-		ident, ok := keyFieldNode.(*ast.Ident)
-		if !ok {
-			errors = append(errors, ValidationError{
-				Location: fmt.Sprintf("union=%s,key=%s", containerType, keyField.Name),
-				Message:  fmt.Sprintf("key field type %s must be a uint32 alias", keyField.Type),
-			})
-			continue
-		}
-		// This is a type alias, check if it's uint32
-		if ident.Name != "uint32" {
 			errors = append(errors, ValidationError{
 				Location: fmt.Sprintf("union=%s,key=%s", containerType, keyField.Name),
-				Message:  fmt.Sprintf("key field type %s must be a uint32 alias, got %s", keyField.Type, ident.Name),
+				Message:  message,
 			})
 			continue
 		}
@@ -161,7 +150,7 @@ func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantI
 		// Use aggregated constants for package-level processing
 		// In package-level processing, constants parameter contains all constants from all files
 		typedConstants := constants
-		if len(typedConstants) == 0 {
+		if len(typedConstants) == 0 && keyXDRType != "bool" {
 			errors = append(errors, ValidationError{
 				Location: fmt.Sprintf("union=%s,key=%s", containerType, keyField.Name),
 				Message:  fmt.Sprintf("no constants found for discriminant type %s", keyField.Type),
@@ -172,7 +161,7 @@ func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantI
 		// Validate that each case maps to a valid constant and struct
 		for constantName, structName := range config.Cases {
 			// Check if the constant exists for this discriminant type
-			if _, exists := typedConstants[constantName]; !exists {
+			if _, exists := typedConstants[constantName]; !exists && !(keyXDRType == "bool" && isBoolLiteral(constantName)) {
 				errors = append(errors, ValidationError{
 					Location: fmt.Sprintf("union=%s,case=%s", containerType, constantName),
 					Message:  fmt.Sprintf("constant %s not found for discriminant type %s", constantName, keyField.Type),
@@ -184,6 +173,16 @@ func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantI
 				continue // Void case - no validation needed
 			}
 
+			// Payloads declared in other packages are validated when their own package is generated
+			if isQualifiedRef(structName) {
+				continue
+			}
+
+			// Named slice, array, map and primitive types encode through their generated methods
+			if namedTypes[structName] {
+				continue
+			}
+
 			// Validate struct or alias-to-struct
 			_, kind := resolveToStruct(structName)
 			switch kind {
@@ -202,6 +201,22 @@ func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantI
 			}
 		}
 
+		// Validate that each arm maps to a valid constant
+		for constantName, armType := range config.Arms {
+			if _, exists := typedConstants[constantName]; !exists && !(keyXDRType == "bool" && isBoolLiteral(constantName)) {
+				errors = append(errors, ValidationError{
+					Location: fmt.Sprintf("union=%s,arm=%s", containerType, constantName),
+					Message:  fmt.Sprintf("constant %s not found for discriminant type %s", constantName, keyField.Type),
+				})
+			}
+			if containsAnyOrInterface(armType) {
+				errors = append(errors, ValidationError{
+					Location: fmt.Sprintf("union=%s,arm=%s", containerType, constantName),
+					Message:  fmt.Sprintf("arm type %s contains any/interface{} which is not supported", armType),
+				})
+			}
+		}
+
 		// Find void cases (constants without mappings)
 		// For now, we'll skip this as it requires more complex logic to determine
 		// which constants belong to which discriminant type
@@ -242,6 +257,158 @@ func validateUnionConfiguration(types []TypeInfo, constants map[string]ConstantI
 	return errors
 }
 
+// validateUnionExhaustiveness checks union discriminants against the constants of the key type:
+// payload, arm and declared void discriminants must be of the key type, no two selected constants
+// may share a value, and exhaustive unions must select every constant of the key type
+func validateUnionExhaustiveness(types []TypeInfo, constants map[string]ConstantInfo) []ValidationError {
+	var errors []ValidationError
+
+	var unionConfigs []*UnionConfig
+	for _, typeInfo := range types {
+		if typeInfo.UnionConfig != nil {
+			unionConfigs = append(unionConfigs, typeInfo.UnionConfig)
+		}
+		for _, field := range typeInfo.Fields {
+			if field.UnionConfig != nil {
+				unionConfigs = append(unionConfigs, field.UnionConfig)
+			}
+		}
+	}
+
+	for _, config := range unionConfigs {
+		armOf := func(constantName string) string {
+			if payloadType, ok := config.Cases[constantName]; ok {
+				return payloadType
+			}
+			if armType, ok := config.Arms[constantName]; ok {
+				return armType
+			}
+			return "void"
+		}
+
+		// Declared void discriminants must exist and must not also select a payload or arm
+		for _, voidCase := range config.DeclaredVoid {
+			location := fmt.Sprintf("union=%s,void=%s", config.ContainerType, voidCase)
+			if _, exists := constants[voidCase]; !exists && !isVoidLiteral(voidCase, config.DiscriminantXDR) {
+				errors = append(errors, ValidationError{
+					Location: location,
+					Message:  fmt.Sprintf("void discriminant %s not found for discriminant type %s", voidCase, config.DiscriminantType),
+				})
+			}
+			if armOf(voidCase) != "void" {
+				errors = append(errors, ValidationError{
+					Location: location,
+					Message:  fmt.Sprintf("discriminant %s is declared void but also selects %s", voidCase, armOf(voidCase)),
+				})
+			}
+		}
+
+		selectedByValue := make(map[string]string) // value -> first constant selecting it
+		for _, constantName := range append(unionCaseConstants(config), config.DeclaredVoid...) {
+			location := fmt.Sprintf("union=%s,case=%s", config.ContainerType, constantName)
+			constantInfo, exists := constants[constantName]
+			if !exists && isVoidLiteral(constantName, config.DiscriminantXDR) {
+				constantInfo, exists = ConstantInfo{Value: constantName}, true
+			}
+			if !exists {
+				continue // Reported by validateUnionConfiguration or above
+			}
+
+			if constantInfo.Type != "" && constantInfo.Type != config.DiscriminantType {
+				errors = append(errors, ValidationError{
+					Location: location,
+					Message:  fmt.Sprintf("discriminant %s has type %s but the union key type is %s", constantName, constantInfo.Type, config.DiscriminantType),
+				})
+			}
+
+			valueKey := constantValueKey(constantInfo.Value)
+			if other, duplicate := selectedByValue[valueKey]; duplicate && other != constantName {
+				message := fmt.Sprintf("discriminants %s and %s have the same value %s but select different arms (%s and %s)", other, constantName, constantInfo.Value, armOf(other), armOf(constantName))
+				if armOf(other) == armOf(constantName) {
+					message = fmt.Sprintf("discriminants %s and %s have the same value %s; select %s with only one of them", other, constantName, constantInfo.Value, armOf(constantName))
+				}
+				errors = append(errors, ValidationError{Location: location, Message: message})
+				continue
+			}
+			selectedByValue[valueKey] = constantName
+		}
+
+		// Declared arms must list exactly the payload and arm discriminants, for the reflection codec
+		if config.DeclaredArms != nil {
+			valueOf := func(name string) (string, bool) {
+				if constantInfo, exists := constants[name]; exists {
+					return constantValueKey(constantInfo.Value), true
+				}
+				if isVoidLiteral(name, config.DiscriminantXDR) {
+					return constantValueKey(name), true
+				}
+				return "", false
+			}
+			declared := make(map[string]bool)
+			for _, armCase := range config.DeclaredArms {
+				location := fmt.Sprintf("union=%s,arms=%s", config.ContainerType, armCase)
+				valueKey, exists := valueOf(armCase)
+				if !exists {
+					errors = append(errors, ValidationError{
+						Location: location,
+						Message:  fmt.Sprintf("arm discriminant %s not found for discriminant type %s", armCase, config.DiscriminantType),
+					})
+					continue
+				}
+				declared[valueKey] = true
+			}
+			selected := make(map[string]bool)
+			for _, constantName := range unionCaseConstants(config) {
+				valueKey, exists := valueOf(constantName)
+				if !exists {
+					continue
+				}
+				selected[valueKey] = true
+				if !declared[valueKey] {
+					errors = append(errors, ValidationError{
+						Location: fmt.Sprintf("union=%s,case=%s", config.ContainerType, constantName),
+						Message:  fmt.Sprintf("discriminant %s selects %s but is not listed in arms=", constantName, armOf(constantName)),
+					})
+				}
+			}
+			for _, armCase := range config.DeclaredArms {
+				if valueKey, exists := valueOf(armCase); exists && !selected[valueKey] {
+					errors = append(errors, ValidationError{
+						Location: fmt.Sprintf("union=%s,arms=%s", config.ContainerType, armCase),
+						Message:  fmt.Sprintf("discriminant %s is listed in arms= but selects no payload or arm", armCase),
+					})
+				}
+			}
+		}
+
+		if config.Exhaustive {
+			for _, constantName := range unselectedConstants(config, constants) {
+				errors = append(errors, ValidationError{
+					Location: fmt.Sprintf("union=%s,case=%s", config.ContainerType, constantName),
+					Message:  fmt.Sprintf("discriminant %s of key type %s has no payload, arm or void declaration (union is exhaustive)", constantName, config.DiscriminantType),
+				})
+			}
+		}
+	}
+
+	return errors
+}
+
+// isBoolLiteral reports whether a case value is the true or false literal
+func isBoolLiteral(value string) bool {
+	return value == "true" || value == "false"
+}
+
+// isVoidLiteral reports whether a void=A|B entry is a literal of the discriminant's XDR type
+// rather than a constant: true or false for bool keys, an integer literal otherwise
+func isVoidLiteral(value, discriminantXDR string) bool {
+	if discriminantXDR == "bool" {
+		return isBoolLiteral(value)
+	}
+	_, err := strconv.ParseInt(value, 0, 64)
+	return err == nil
+}
+
 // validateDiscriminatedUnions validates that discriminated union structures are correctly formed
 func validateDiscriminatedUnions(types []TypeInfo, constants map[string]ConstantInfo) error {
 	for _, typeInfo := range types {
@@ -257,7 +424,7 @@ func validateDiscriminatedUnions(types []TypeInfo, constants map[string]Constant
 					keyCount++
 					keyIndex = i
 				}
-				if field.IsUnion {
+				if field.IsUnion && field.UnionKey == "" { // embedded unions have their own key
 					unionCount++
 					unionIndex = i
 				}
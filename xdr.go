@@ -1,23 +1,49 @@
 package xdr
 
 import (
+	"cmp"
 	"encoding/binary"
 	"errors"
 	"io"
+	"maps"
 	"math"
+	"slices"
 )
 
 // XDR errors
 var (
-	ErrBufferTooSmall = errors.New("buffer too small")
-	ErrInvalidData    = errors.New("invalid XDR data")
-	ErrUnexpectedEOF  = errors.New("unexpected end of data")
+	ErrBufferTooSmall      = errors.New("buffer too small")
+	ErrInvalidData         = errors.New("invalid XDR data")
+	ErrUnexpectedEOF       = errors.New("unexpected end of data")
+	ErrUnknownDiscriminant = errors.New("unknown union discriminant")
+	ErrArmNotSelected      = errors.New("union arm not selected by discriminant")
+	ErrMaxDepthExceeded    = errors.New("maximum nesting depth exceeded")
+	ErrEncodingLoop        = errors.New("encoding loop detected")
+	ErrDuplicateKey        = errors.New("duplicate map key")
+	ErrOverflow            = errors.New("value out of range for XDR type")
+	ErrChecksumMismatch    = errors.New("checksum mismatch")
+	ErrUnregisteredType    = errors.New("type not registered")
+	ErrUnsupportedType     = errors.New("type not supported by reflection")
 )
 
+// DefaultMaxDepth is the nesting limit for recursive types used when none is set
+const DefaultMaxDepth = 256
+
+// depthLimit returns the effective nesting limit for a configured maximum
+func depthLimit(maxDepth int) int {
+	if maxDepth <= 0 {
+		return DefaultMaxDepth
+	}
+	return maxDepth
+}
+
 // Encoder provides methods for encoding data in XDR format
 type Encoder struct {
-	buf []byte
-	pos int
+	buf      []byte
+	pos      int
+	depth    int
+	maxDepth int
+	visiting map[any]struct{} // nil unless cycle checking is enabled
 }
 
 // NewEncoder creates a new XDR encoder with the provided buffer
@@ -36,9 +62,60 @@ func (e *Encoder) Len() int {
 }
 
 // Reset resets the encoder to use a new buffer
+// The depth limit and cycle checking settings are kept
 func (e *Encoder) Reset(buf []byte) {
 	e.buf = buf
 	e.pos = 0
+	e.depth = 0
+	clear(e.visiting)
+}
+
+// SetMaxDepth sets how deeply recursive types may nest; n <= 0 restores DefaultMaxDepth
+func (e *Encoder) SetMaxDepth(n int) {
+	e.maxDepth = n
+}
+
+// SetCycleCheck enables or disables tracking of the pointers being encoded
+// With it enabled, a value that contains itself fails with ErrEncodingLoop
+// instead of recursing until the depth limit is reached
+func (e *Encoder) SetCycleCheck(enabled bool) {
+	switch {
+	case enabled && e.visiting == nil:
+		e.visiting = make(map[any]struct{})
+	case !enabled:
+		e.visiting = nil
+	}
+}
+
+// CycleCheck reports whether cycle checking is enabled
+// Generated list walks use it to track the nodes they have encoded, since a chain is
+// walked iteratively without calling Enter for each node
+func (e *Encoder) CycleCheck() bool {
+	return e.visiting != nil
+}
+
+// Enter records that encoding has descended into v, a pointer to a recursive type
+// Generated code calls it at the start of Encode and defers Leave on success
+func (e *Encoder) Enter(v any) error {
+	if e.depth >= depthLimit(e.maxDepth) {
+		return ErrMaxDepthExceeded
+	}
+	if e.visiting != nil {
+		if _, ok := e.visiting[v]; ok {
+			return ErrEncodingLoop
+		}
+		e.visiting[v] = struct{}{}
+	}
+	e.depth++
+	return nil
+}
+
+// Leave undoes a successful Enter for v
+func (e *Encoder) Leave(v any) {
+	e.depth--
+	if e.visiting != nil {
+		delete(e.visiting, v)
+	}
 }
 
 // EncodeUint32 encodes a 32-bit unsigned integer
@@ -117,10 +194,18 @@ func (e *Encoder) EncodeString(v string) error {
 	return e.EncodeBytes([]byte(v))
 }
 
+// SortedKeys returns the keys of m in ascending order
+// Generated code encodes maps in this order so the output is deterministic
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
 // Decoder provides methods for decoding XDR format data
 type Decoder struct {
-	buf []byte
-	pos int
+	buf      []byte
+	pos      int
+	depth    int
+	maxDepth int
 }
 
 // NewDecoder creates a new XDR decoder with the provided data
@@ -139,9 +224,31 @@ func (d *Decoder) Position() int {
 }
 
 // Reset resets the decoder to use new data
+// The depth limit is kept
 func (d *Decoder) Reset(buf []byte) {
 	d.buf = buf
 	d.pos = 0
+	d.depth = 0
+}
+
+// SetMaxDepth sets how deeply recursive types may nest; n <= 0 restores DefaultMaxDepth
+func (d *Decoder) SetMaxDepth(n int) {
+	d.maxDepth = n
+}
+
+// Enter records that decoding has descended into a recursive type
+// Deeply nested input fails with ErrMaxDepthExceeded instead of exhausting the stack
+func (d *Decoder) Enter() error {
+	if d.depth >= depthLimit(d.maxDepth) {
+		return ErrMaxDepthExceeded
+	}
+	d.depth++
+	return nil
+}
+
+// Leave undoes a successful Enter
+func (d *Decoder) Leave() {
+	d.depth--
 }
 
 // GetSlice returns a slice into the decoder's buffer from start to end positions.
@@ -165,6 +272,14 @@ func (d *Decoder) DecodeUint32() (uint32, error) {
 	return v, nil
 }
 
+// PeekUint32 returns the next 32-bit unsigned integer without consuming it
+func (d *Decoder) PeekUint32() (uint32, error) {
+	if d.pos+4 > len(d.buf) {
+		return 0, ErrUnexpectedEOF
+	}
+	return binary.BigEndian.Uint32(d.buf[d.pos:]), nil
+}
+
 // DecodeUint64 decodes a 64-bit unsigned integer
 func (d *Decoder) DecodeUint64() (uint64, error) {
 	if d.pos+8 > len(d.buf) {
@@ -564,6 +564,59 @@ func TestEncoderMethods(t *testing.T) {
 	})
 }
 
+func TestDepthTracking(t *testing.T) {
+	t.Run("Encoder depth limit", func(t *testing.T) {
+		encoder := NewEncoder(make([]byte, 16))
+		encoder.SetMaxDepth(2)
+
+		a, b, c := new(int), new(int), new(int)
+		require.NoError(t, encoder.Enter(a))
+		require.NoError(t, encoder.Enter(b))
+		assert.ErrorIs(t, encoder.Enter(c), ErrMaxDepthExceeded)
+
+		encoder.Leave(b)
+		assert.NoError(t, encoder.Enter(c), "Expected room after Leave")
+	})
+
+	t.Run("Encoder cycle check", func(t *testing.T) {
+		encoder := NewEncoder(make([]byte, 16))
+		v := new(int)
+
+		// Without cycle checking the same pointer may be entered repeatedly
+		require.NoError(t, encoder.Enter(v))
+		require.NoError(t, encoder.Enter(v))
+
+		assert.False(t, encoder.CycleCheck())
+		encoder.Reset(make([]byte, 16))
+		encoder.SetCycleCheck(true)
+		assert.True(t, encoder.CycleCheck())
+		require.NoError(t, encoder.Enter(v))
+		assert.ErrorIs(t, encoder.Enter(v), ErrEncodingLoop)
+
+		encoder.Leave(v)
+		assert.NoError(t, encoder.Enter(v), "Expected pointer to be released by Leave")
+	})
+
+	t.Run("Decoder depth limit", func(t *testing.T) {
+		decoder := NewDecoder(nil)
+		for range DefaultMaxDepth {
+			require.NoError(t, decoder.Enter())
+		}
+		assert.ErrorIs(t, decoder.Enter(), ErrMaxDepthExceeded)
+
+		decoder.Reset(nil)
+		decoder.SetMaxDepth(1)
+		require.NoError(t, decoder.Enter())
+		assert.ErrorIs(t, decoder.Enter(), ErrMaxDepthExceeded)
+	})
+}
+
+func TestSortedKeys(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, SortedKeys(map[string]int{"c": 3, "a": 1, "b": 2}))
+	assert.Equal(t, []int32{-5, 0, 7}, SortedKeys(map[int32]struct{}{7: {}, -5: {}, 0: {}}))
+	assert.Empty(t, SortedKeys(map[uint32]bool(nil)))
+}
+
 func TestPadding(t *testing.T) {
 	buf := make([]byte, 1024)
 	encoder := NewEncoder(buf)